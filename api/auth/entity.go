@@ -0,0 +1,71 @@
+package auth
+
+import (
+	"errors"
+	"github.com/golang-jwt/jwt/v5"
+	"time"
+)
+
+// Credential is the locally stored password of a user, only the bcrypt hash is kept
+type Credential struct {
+	ID           uint      `json:"id" gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
+	UserID       uint      `json:"user_id" gorm:"NOT NULL;uniqueIndex:credential_user_id"`
+	PasswordHash string    `json:"-" gorm:"NOT NULL"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// Identity is the authenticated caller of a request, roles are read from user_roles on every request
+type Identity struct {
+	UserID uint     `json:"user_id"`
+	Email  string   `json:"email"`
+	Roles  []string `json:"roles"`
+}
+
+// Claims are the claims of the issued tokens, the subject is the user id
+type Claims struct {
+	Email string `json:"email"`
+	jwt.RegisteredClaims
+}
+
+type Token struct {
+	AccessToken string    `json:"access_token"`
+	TokenType   string    `json:"token_type"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// Role names seeded from seed_data.json
+const (
+	RoleAdmin = "Admin"
+	RoleUser  = "User"
+)
+
+const (
+	TokenTypeBearer   = "Bearer"
+	TokenIssuer       = "resource-profile-management"
+	DefaultTokenTTL   = 24 * time.Hour
+	MinPasswordLength = 8
+)
+
+var (
+	ErrInvalidCredentials = errors.New("invalid email or password")
+	ErrInvalidToken       = errors.New("invalid or expired token")
+	ErrMissingToken       = errors.New("missing bearer token")
+	ErrCredentialExists   = errors.New("the user already has a password")
+	ErrWeakPassword       = errors.New("password is too weak")
+	ErrForbidden          = errors.New("you are not allowed to perform this action")
+	ErrInvalidPolicyInput = errors.New("invalid request")
+)
+
+func (identity Identity) HasRole(role string) bool {
+	for _, assignedRole := range identity.Roles {
+		if assignedRole == role {
+			return true
+		}
+	}
+	return false
+}
+
+func (identity Identity) IsAdmin() bool {
+	return identity.HasRole(RoleAdmin)
+}
@@ -0,0 +1,198 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"github.com/Octek/resource-profile-management-backend.git/utils"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"net/http"
+)
+
+var validate = utils.NewValidator()
+
+// Routes Exports all routes handled by this service
+func Routes(router *gin.Engine, authSvc AuthService) {
+	authRouter := router.Group("/auth")
+	{
+		authRouter.POST("/login", func(c *gin.Context) {
+			HandlerToLogin(c, authSvc)
+		})
+	}
+	protectedRouter := router.Group("/auth", RequireAuthentication(authSvc))
+	{
+		protectedRouter.GET("/me", func(c *gin.Context) {
+			HandlerToGetCurrentIdentity(c)
+		})
+		protectedRouter.PUT("/password", func(c *gin.Context) {
+			HandlerToChangePassword(c, authSvc)
+		})
+		protectedRouter.POST("/password/user/:id", Authorize(AllowAdmin), func(c *gin.Context) {
+			HandlerToSetInitialPassword(c, authSvc)
+		})
+	}
+}
+
+// HandlerToLogin godoc
+// @Tags Auth
+// @Summary Login
+// @Description Exchange an email and password for a signed bearer token, send it as "Authorization: Bearer <token>"
+// @ID login
+// @Accept json
+// @Produce json
+// @Param LoginRequest body LoginRequest true "Credentials"
+// @Success 200 {object} Token
+// @Failure 400 {object} string
+// @Failure 401 {object} string
+// @Failure 500 {object} string
+// @Router /auth/login [post]
+func HandlerToLogin(c *gin.Context, authSvc AuthService) {
+	fmt.Println("HandlerToLogin")
+	var loginRequest LoginRequest
+	if err := c.ShouldBind(&loginRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.InvalidJsonBody, err), err))
+		return
+	}
+	if err := validate.Struct(loginRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.RequestSchemaInvalid, err), err))
+		return
+	}
+	token, err := authSvc.Login(loginRequest.Email, loginRequest.Password)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, ErrInvalidCredentials) {
+			statusCode = http.StatusUnauthorized
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileLoggingIn, err), err))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.SuccessfullyLoggedIn, Data: token})
+}
+
+// HandlerToGetCurrentIdentity godoc
+// @Tags Auth
+// @Summary Current user
+// @Description Get the user the bearer token was issued to
+// @ID get-current-identity
+// @Security ApiAuthKey
+// @Produce json
+// @Success 200 {object} Identity
+// @Failure 401 {object} string
+// @Router /auth/me [get]
+func HandlerToGetCurrentIdentity(c *gin.Context) {
+	fmt.Println("HandlerToGetCurrentIdentity")
+	identity, _ := CurrentIdentity(c)
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.Success, Data: identity})
+}
+
+// HandlerToChangePassword godoc
+// @Tags Auth
+// @Summary Change password
+// @Description Change the password of the logged in user
+// @ID change-password
+// @Security ApiAuthKey
+// @Accept json
+// @Param ChangePasswordRequest body ChangePasswordRequest true "Passwords"
+// @Success 200 {object} string
+// @Failure 400 {object} string
+// @Failure 401 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /auth/password [put]
+func HandlerToChangePassword(c *gin.Context, authSvc AuthService) {
+	fmt.Println("HandlerToChangePassword")
+	identity, _ := CurrentIdentity(c)
+	var changePasswordRequest ChangePasswordRequest
+	if err := c.ShouldBind(&changePasswordRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.InvalidJsonBody, err), err))
+		return
+	}
+	if err := validate.Struct(changePasswordRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.RequestSchemaInvalid, err), err))
+		return
+	}
+	err := authSvc.ChangePassword(identity.UserID, changePasswordRequest.CurrentPassword, changePasswordRequest.NewPassword)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, ErrWeakPassword) {
+			statusCode = http.StatusBadRequest
+		}
+		if errors.Is(err, ErrInvalidCredentials) {
+			statusCode = http.StatusUnauthorized
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileSavingPassword, err), err))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.SuccessfullySavedPassword, Data: nil})
+}
+
+// HandlerToSetInitialPassword godoc
+// @Tags Auth
+// @Summary Set initial password
+// @Description Set the first password of a user so they can log in, admins only. Fails when the user already has a password
+// @ID set-initial-password
+// @Security ApiAuthKey
+// @Accept json
+// @Param id path int true "User ID"
+// @Param SetPasswordRequest body SetPasswordRequest true "Password"
+// @Success 200 {object} string
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Router /auth/password/user/{id} [post]
+func HandlerToSetInitialPassword(c *gin.Context, authSvc AuthService) {
+	fmt.Println("HandlerToSetInitialPassword")
+	userID, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
+	var setPasswordRequest SetPasswordRequest
+	if err := c.ShouldBind(&setPasswordRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.InvalidJsonBody, err), err))
+		return
+	}
+	if err := validate.Struct(setPasswordRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.RequestSchemaInvalid, err), err))
+		return
+	}
+	err := authSvc.SetInitialPassword(userID, setPasswordRequest.Password)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, ErrWeakPassword) {
+			statusCode = http.StatusBadRequest
+		}
+		if errors.Is(err, ErrCredentialExists) {
+			statusCode = http.StatusConflict
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileSavingPassword, err), err))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.SuccessfullySavedPassword, Data: nil})
+}
+
+// All requested and response structs
+
+type LoginRequest struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required,min=8,max=72"`
+}
+
+type SetPasswordRequest struct {
+	Password string `json:"password" validate:"required,min=8,max=72"`
+}
@@ -0,0 +1,43 @@
+package auth
+
+import (
+	"errors"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// IdentityProvider checks the credentials sent to the login endpoint. The local provider
+// uses the stored passwords, another backend (LDAP, an OIDC password grant, ...) can be
+// plugged in by passing its implementation to NewService.
+type IdentityProvider interface {
+	Authenticate(email, password string) (Identity, error)
+}
+
+type localIdentityProvider struct {
+	authRepository AuthRepository
+}
+
+func NewLocalIdentityProvider(r AuthRepository) IdentityProvider {
+	return &localIdentityProvider{authRepository: r}
+}
+
+func (provider *localIdentityProvider) Authenticate(email, password string) (Identity, error) {
+	identity, err := provider.authRepository.getUserIdentityByEmail(email)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Identity{}, ErrInvalidCredentials
+	}
+	if err != nil {
+		return Identity{}, err
+	}
+	credential, err := provider.authRepository.getCredentialByUserId(identity.UserID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Identity{}, ErrInvalidCredentials
+	}
+	if err != nil {
+		return Identity{}, err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(credential.PasswordHash), []byte(password)); err != nil {
+		return Identity{}, ErrInvalidCredentials
+	}
+	return identity, nil
+}
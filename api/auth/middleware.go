@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"github.com/Octek/resource-profile-management-backend.git/utils"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"net/http"
+	"strings"
+)
+
+const identityContextKey = "auth.identity"
+
+// RequireAuthentication rejects requests without a valid bearer token and stores the caller in the gin context
+func RequireAuthentication(authSvc AuthService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tokenString, err := bearerToken(c.GetHeader("Authorization"))
+		if err == nil {
+			var identity Identity
+			identity, err = authSvc.ParseToken(tokenString)
+			if err == nil {
+				identity, err = authSvc.ResolveIdentity(identity)
+			}
+			if err == nil {
+				c.Set(identityContextKey, identity)
+				c.Next()
+				return
+			}
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = fmt.Errorf("%w: the user no longer exists", ErrInvalidToken)
+		}
+		c.Header("WWW-Authenticate", TokenTypeBearer)
+		utils.AbortWithError(c, utils.NewError(http.StatusUnauthorized, fmt.Sprintf(utils.Unauthorized, err), err))
+	}
+}
+
+// CurrentIdentity returns the caller of an authenticated request
+func CurrentIdentity(c *gin.Context) (Identity, bool) {
+	value, ok := c.Get(identityContextKey)
+	if !ok {
+		return Identity{}, false
+	}
+	identity, ok := value.(Identity)
+	return identity, ok
+}
+
+func bearerToken(header string) (string, error) {
+	scheme, token, found := strings.Cut(strings.TrimSpace(header), " ")
+	if !found || !strings.EqualFold(scheme, TokenTypeBearer) || strings.TrimSpace(token) == "" {
+		return "", ErrMissingToken
+	}
+	return strings.TrimSpace(token), nil
+}
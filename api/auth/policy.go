@@ -0,0 +1,146 @@
+package auth
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/Octek/resource-profile-management-backend.git/utils"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"io"
+	"net/http"
+)
+
+// Policy decides whether the caller may use a route, returning nil allows the request.
+// Routes declare their policies with Authorize, e.g.
+//
+//	router.PATCH("/:id", auth.Authorize(auth.AllowAdmin, auth.AllowSelf(auth.UserIDFromParam("id"))), handler)
+type Policy func(c *gin.Context, identity Identity) error
+
+// UserIDSource reads the id of the user a request acts on
+type UserIDSource func(c *gin.Context) (uint, error)
+
+// Authorize lets the request through when any of the policies allows it. When none does the
+// most telling error is returned, a missing resource or bad input before a plain 403.
+func Authorize(policies ...Policy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		identity, ok := CurrentIdentity(c)
+		if !ok {
+			utils.AbortWithError(c, utils.NewError(http.StatusUnauthorized, fmt.Sprintf(utils.Unauthorized, ErrMissingToken), ErrMissingToken))
+			return
+		}
+		deniedErr := ErrForbidden
+		for _, policy := range policies {
+			err := policy(c, identity)
+			if err == nil {
+				c.Next()
+				return
+			}
+			if !errors.Is(err, ErrForbidden) {
+				deniedErr = err
+			}
+		}
+
+		statusCode := http.StatusInternalServerError
+		if errors.Is(deniedErr, ErrForbidden) {
+			statusCode = http.StatusForbidden
+		}
+		if errors.Is(deniedErr, ErrInvalidPolicyInput) {
+			statusCode = http.StatusBadRequest
+		}
+		if errors.Is(deniedErr, gorm.ErrRecordNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.Forbidden, deniedErr), deniedErr))
+	}
+}
+
+// AllowAuthenticated allows every logged in user, it documents routes that are open to all roles
+func AllowAuthenticated(c *gin.Context, identity Identity) error {
+	return nil
+}
+
+func AllowAdmin(c *gin.Context, identity Identity) error {
+	if identity.IsAdmin() {
+		return nil
+	}
+	return ErrForbidden
+}
+
+// AllowSelf allows the request when it acts on the caller's own user
+func AllowSelf(source UserIDSource) Policy {
+	return func(c *gin.Context, identity Identity) error {
+		userID, err := source(c)
+		if err != nil {
+			return err
+		}
+		if userID != identity.UserID {
+			return ErrForbidden
+		}
+		return nil
+	}
+}
+
+// AllowOwner allows the request when isOwner confirms the addressed row belongs to the caller
+func AllowOwner(isOwner func(c *gin.Context, userID uint) (bool, error)) Policy {
+	return func(c *gin.Context, identity Identity) error {
+		owned, err := isOwner(c, identity.UserID)
+		if err != nil {
+			return err
+		}
+		if !owned {
+			return ErrForbidden
+		}
+		return nil
+	}
+}
+
+func UserIDFromParam(name string) UserIDSource {
+	return func(c *gin.Context) (uint, error) {
+		return parseUserID(name, c.Param(name))
+	}
+}
+
+func UserIDFromQuery(name string) UserIDSource {
+	return func(c *gin.Context) (uint, error) {
+		return parseUserID(name, c.Query(name))
+	}
+}
+
+// UserIDFromJSONBody reads a field of the JSON body and puts the body back for the handler
+func UserIDFromJSONBody(field string) UserIDSource {
+	return func(c *gin.Context) (uint, error) {
+		if c.Request.Body == nil {
+			return 0, fmt.Errorf("%w: %s is required", ErrInvalidPolicyInput, field)
+		}
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			return 0, err
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(body, &fields); err != nil {
+			return 0, fmt.Errorf("%w: %v", ErrInvalidPolicyInput, err)
+		}
+		var userID uint
+		if err := json.Unmarshal(fields[field], &userID); err != nil || userID == 0 {
+			return 0, fmt.Errorf("%w: %s is required", ErrInvalidPolicyInput, field)
+		}
+		return userID, nil
+	}
+}
+
+// ParamID parses a numeric path parameter, for owner checks in other packages
+func ParamID(c *gin.Context, name string) (uint, error) {
+	return parseUserID(name, c.Param(name))
+}
+
+func parseUserID(name, value string) (uint, error) {
+	id, err := utils.ParseID(value)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid %s %q", ErrInvalidPolicyInput, name, value)
+	}
+	return id, nil
+}
@@ -0,0 +1,11 @@
+package auth
+
+// AuthRepository Used to store and retrieve user credentials
+type AuthRepository interface {
+	getUserIdentityByEmail(email string) (Identity, error)
+	getUserIdentityById(userID uint) (Identity, error)
+	getUserRoleNames(userID uint) ([]string, error)
+	getCredentialByUserId(userID uint) (Credential, error)
+	createCredential(credential *Credential) error
+	updateCredential(credential Credential) error
+}
@@ -0,0 +1,94 @@
+package auth
+
+import (
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"strings"
+)
+
+type authRepositoryPostgres struct {
+	db *gorm.DB
+}
+
+func NewAuthRepositoryPostgres(db *gorm.DB) AuthRepository {
+	log.Print("Successfully connected to postgres in auth service!")
+
+	return &authRepositoryPostgres{
+		db: db,
+	}
+}
+
+// getUserIdentityByEmail reads the users table directly, the users package depends on this one
+func (repo *authRepositoryPostgres) getUserIdentityByEmail(email string) (Identity, error) {
+	var identity Identity
+	result := repo.db.Table("users").Select("id AS user_id, email").
+		Where("LOWER(email) = ? AND deleted_at IS NULL", strings.ToLower(strings.TrimSpace(email))).
+		Limit(1).Scan(&identity)
+	if result.Error != nil {
+		return Identity{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return Identity{}, gorm.ErrRecordNotFound
+	}
+	return identity, nil
+}
+
+func (repo *authRepositoryPostgres) getUserIdentityById(userID uint) (Identity, error) {
+	var identity Identity
+	result := repo.db.Table("users").Select("id AS user_id, email").
+		Where("id = ? AND deleted_at IS NULL", userID).
+		Limit(1).Scan(&identity)
+	if result.Error != nil {
+		return Identity{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return Identity{}, fmt.Errorf("user with id %d: %w", userID, gorm.ErrRecordNotFound)
+	}
+	return identity, nil
+}
+
+func (repo *authRepositoryPostgres) getUserRoleNames(userID uint) ([]string, error) {
+	var roleNames []string
+	err := repo.db.Table("user_roles").Select("roles.name").
+		Joins("JOIN roles ON roles.id = user_roles.role_id").
+		Where("user_roles.user_id = ?", userID).
+		Order("roles.name").Pluck("roles.name", &roleNames).Error
+	if err != nil {
+		return nil, err
+	}
+	return roleNames, nil
+}
+
+func (repo *authRepositoryPostgres) getCredentialByUserId(userID uint) (Credential, error) {
+	var credential Credential
+	if err := repo.db.Where("user_id = ?", userID).First(&credential).Error; err != nil {
+		return Credential{}, err
+	}
+	return credential, nil
+}
+
+func (repo *authRepositoryPostgres) createCredential(credential *Credential) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&Credential{}).Where("user_id = ?", credential.UserID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrCredentialExists
+		}
+		if err := tx.Create(credential).Error; err != nil {
+			return err
+		}
+		fmt.Printf("Credential of user %d has been stored\n", credential.UserID)
+		return nil
+	})
+}
+
+func (repo *authRepositoryPostgres) updateCredential(credential Credential) error {
+	if err := repo.db.Save(&credential).Error; err != nil {
+		return err
+	}
+	fmt.Printf("Credential of user %d has been updated\n", credential.UserID)
+	return nil
+}
@@ -0,0 +1,134 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/crypto/bcrypt"
+	"strconv"
+	"time"
+)
+
+type AuthService struct {
+	authRepository   AuthRepository
+	identityProvider IdentityProvider
+	signingKey       []byte
+	tokenTTL         time.Duration
+}
+
+func NewService(r AuthRepository, identityProvider IdentityProvider, signingKey []byte, tokenTTL time.Duration) AuthService {
+	return AuthService{authRepository: r, identityProvider: identityProvider, signingKey: signingKey, tokenTTL: tokenTTL}
+}
+
+// Login authenticates the user against the identity provider and issues a signed token
+func (svc *AuthService) Login(email, password string) (Token, error) {
+	identity, err := svc.identityProvider.Authenticate(email, password)
+	if err != nil {
+		return Token{}, err
+	}
+	return svc.IssueToken(identity)
+}
+
+func (svc *AuthService) IssueToken(identity Identity) (Token, error) {
+	now := time.Now()
+	expiresAt := now.Add(svc.tokenTTL)
+	claims := Claims{
+		Email: identity.Email,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   strconv.FormatUint(uint64(identity.UserID), 10),
+			Issuer:    TokenIssuer,
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+	signedToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(svc.signingKey)
+	if err != nil {
+		return Token{}, err
+	}
+	return Token{AccessToken: signedToken, TokenType: TokenTypeBearer, ExpiresAt: expiresAt}, nil
+}
+
+// ParseToken validates the signature, issuer and expiry of a token and returns the caller it was issued to
+func (svc *AuthService) ParseToken(tokenString string) (Identity, error) {
+	var claims Claims
+	_, err := jwt.ParseWithClaims(tokenString, &claims, func(token *jwt.Token) (interface{}, error) {
+		return svc.signingKey, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithIssuer(TokenIssuer), jwt.WithExpirationRequired())
+	if err != nil {
+		return Identity{}, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	userID, err := strconv.ParseUint(claims.Subject, 10, 64)
+	if err != nil || userID == 0 {
+		return Identity{}, fmt.Errorf("%w: invalid subject", ErrInvalidToken)
+	}
+	return Identity{UserID: uint(userID), Email: claims.Email}, nil
+}
+
+// ResolveIdentity reloads the caller of a token, so deleted users and revoked roles take effect immediately
+func (svc *AuthService) ResolveIdentity(tokenIdentity Identity) (Identity, error) {
+	identity, err := svc.authRepository.getUserIdentityById(tokenIdentity.UserID)
+	if err != nil {
+		return Identity{}, err
+	}
+	identity.Roles, err = svc.authRepository.getUserRoleNames(identity.UserID)
+	if err != nil {
+		return Identity{}, err
+	}
+	return identity, nil
+}
+
+// SetInitialPassword stores the first password of a user, changing it goes through ChangePassword
+func (svc *AuthService) SetInitialPassword(userID uint, password string) error {
+	if _, err := svc.authRepository.getUserIdentityById(userID); err != nil {
+		return err
+	}
+	passwordHash, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+	return svc.authRepository.createCredential(&Credential{UserID: userID, PasswordHash: passwordHash})
+}
+
+func (svc *AuthService) ChangePassword(userID uint, currentPassword, newPassword string) error {
+	credential, err := svc.authRepository.getCredentialByUserId(userID)
+	if err != nil {
+		return err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(credential.PasswordHash), []byte(currentPassword)); err != nil {
+		return ErrInvalidCredentials
+	}
+	credential.PasswordHash, err = hashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+	return svc.authRepository.updateCredential(credential)
+}
+
+// EnsureCredential sets the password of the user with the given email unless one is already stored,
+// used to bootstrap the first login. It reports whether the password was set by this call.
+func (svc *AuthService) EnsureCredential(email, password string) (Identity, bool, error) {
+	identity, err := svc.authRepository.getUserIdentityByEmail(email)
+	if err != nil {
+		return Identity{}, false, err
+	}
+	err = svc.SetInitialPassword(identity.UserID, password)
+	if errors.Is(err, ErrCredentialExists) {
+		return identity, false, nil
+	}
+	if err != nil {
+		return Identity{}, false, err
+	}
+	return identity, true, nil
+}
+
+func hashPassword(password string) (string, error) {
+	if len(password) < MinPasswordLength {
+		return "", fmt.Errorf("%w: at least %d characters are required", ErrWeakPassword, MinPasswordLength)
+	}
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(passwordHash), nil
+}
@@ -1,8 +1,11 @@
 package bookings
 
 import (
+	"errors"
+	"fmt"
 	"github.com/Octek/resource-profile-management-backend.git/api/questions"
 	"gorm.io/gorm"
+	"sort"
 	"time"
 )
 
@@ -10,8 +13,13 @@ type Booking struct {
 	ID              uint                       `json:"id" gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
 	UserID          uint                       `json:"user_id" gorm:"NOT NULL;index:user_id"`
 	BookingDateTime time.Time                  `json:"booking_date_time"`
+	DurationMinutes int                        `json:"duration_minutes"`
 	MeetingLink     string                     `json:"meeting_link"`
+	ClientName      string                     `json:"client_name"`
+	ClientEmail     string                     `json:"client_email"`
 	QuestionOptions []questions.QuestionOption `json:"question_options" gorm:"many2many:booking_questions;"`
+	Answers         []BookingQuestion          `json:"answers" gorm:"foreignKey:BookingID"`
+	Skills          []BookedSkill              `json:"skills" gorm:"-"`
 	DeletedAt       gorm.DeletedAt             `json:"deleted_at"`
 	CreatedAt       time.Time                  `json:"created_at"`
 	UpdatedAt       time.Time                  `json:"updated_at"`
@@ -25,10 +33,253 @@ type BookingSkill struct {
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+// BookedSkill is the part of a skill shown on a booking, the skills package depends on this one
+type BookedSkill struct {
+	ID        uint   `json:"id"`
+	BookingID uint   `json:"-"`
+	Name      string `json:"name"`
+	Icon      string `json:"icon"`
+}
+
 type BookingQuestion struct {
 	ID               uint      `json:"id" gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
-	QuestionOptionID uint      `json:"question_option_id" gorm:"NOT NULL;index:question_option_id"`
+	QuestionID       uint      `json:"question_id" gorm:"index:booking_question_id"`
+	QuestionOptionID *uint     `json:"question_option_id" gorm:"index:question_option_id"`
+	Answer           string    `json:"answer"`
 	BookingID        uint      `json:"booking_id" gorm:"NOT NULL;index:booking_id"`
 	CreatedAt        time.Time `json:"created_at"`
 	UpdatedAt        time.Time `json:"updated_at"`
 }
+
+// BookingAnswer is the answer to one intake question, option ids for choice questions and text for free text ones
+type BookingAnswer struct {
+	QuestionID uint   `json:"question_id" validate:"required"`
+	OptionIDs  []uint `json:"option_ids"`
+	Answer     string `json:"answer"`
+}
+
+const (
+	BookingStatusUpcoming = "upcoming"
+	BookingStatusPast     = "past"
+)
+
+var (
+	ErrBookingInPast         = errors.New("booking date time must be in the future")
+	ErrSkillAlreadyOnBooking = errors.New("skill is already attached to the booking")
+)
+
+const (
+	DefaultBookingDurationMinutes = 30
+	MaxBookingDurationMinutes     = 8 * 60
+	MaxFreeSlotsRangeDays         = 31
+	availabilityDateLayout        = "2006-01-02"
+	// CalendarFeedPastDays keeps recent bookings in the feed so they do not vanish from calendars right after they end
+	CalendarFeedPastDays = 30
+)
+
+var (
+	ErrInvalidAvailability = errors.New("invalid availability")
+	ErrOutsideAvailability = errors.New("booking is outside the availability of the user")
+	ErrBookingConflict     = errors.New("booking overlaps with another booking of the user")
+)
+
+// UserAvailability is the weekly schedule in which a user can be booked
+type UserAvailability struct {
+	ID            uint                   `json:"id" gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
+	UserID        uint                   `json:"user_id" gorm:"NOT NULL;uniqueIndex:availability_user_id"`
+	TimeZone      string                 `json:"time_zone" gorm:"NOT NULL"`
+	SlotMinutes   int                    `json:"slot_minutes"`
+	WorkingHours  []AvailabilityWindow   `json:"working_hours" gorm:"foreignKey:UserAvailabilityID"`
+	BlackoutDates []AvailabilityBlackout `json:"blackout_dates" gorm:"foreignKey:UserAvailabilityID"`
+	CreatedAt     time.Time              `json:"created_at"`
+	UpdatedAt     time.Time              `json:"updated_at"`
+}
+
+// AvailabilityWindow is a working period on a weekday, times are "15:04" in the time zone of the availability
+type AvailabilityWindow struct {
+	ID                 uint      `json:"id" gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
+	UserAvailabilityID uint      `json:"user_availability_id" gorm:"NOT NULL;index:availability_window_availability_id"`
+	Weekday            int       `json:"weekday"`
+	StartTime          string    `json:"start_time"`
+	EndTime            string    `json:"end_time"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+// AvailabilityBlackout is a whole day, "2006-01-02", on which the user cannot be booked
+type AvailabilityBlackout struct {
+	ID                 uint      `json:"id" gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
+	UserAvailabilityID uint      `json:"user_availability_id" gorm:"NOT NULL;index:availability_blackout_availability_id"`
+	Date               string    `json:"date"`
+	Reason             string    `json:"reason"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+// CalendarFeed holds the secret token of the iCalendar subscription of a user,
+// calendar apps cannot send an authorization header so the token is part of the feed url
+type CalendarFeed struct {
+	ID        uint      `json:"id" gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
+	UserID    uint      `json:"user_id" gorm:"NOT NULL;uniqueIndex:calendar_feed_user_id"`
+	Token     string    `json:"token" gorm:"NOT NULL;uniqueIndex:calendar_feed_token"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type TimeSlot struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// EndTime falls back to the default duration for bookings created before durations were stored
+func (booking *Booking) EndTime() time.Time {
+	durationMinutes := booking.DurationMinutes
+	if durationMinutes <= 0 {
+		durationMinutes = DefaultBookingDurationMinutes
+	}
+	return booking.BookingDateTime.Add(time.Duration(durationMinutes) * time.Minute)
+}
+
+func (booking *Booking) Overlaps(start, end time.Time) bool {
+	return booking.BookingDateTime.Before(end) && booking.EndTime().After(start)
+}
+
+func (availability *UserAvailability) Validate() error {
+	if _, err := time.LoadLocation(availability.TimeZone); err != nil || availability.TimeZone == "" {
+		return fmt.Errorf("%w: unknown time zone %q", ErrInvalidAvailability, availability.TimeZone)
+	}
+	if availability.SlotMinutes < 5 || availability.SlotMinutes > MaxBookingDurationMinutes {
+		return fmt.Errorf("%w: slot minutes must be between 5 and %d", ErrInvalidAvailability, MaxBookingDurationMinutes)
+	}
+	for _, window := range availability.WorkingHours {
+		if window.Weekday < int(time.Sunday) || window.Weekday > int(time.Saturday) {
+			return fmt.Errorf("%w: weekday %d must be between 0 (Sunday) and 6 (Saturday)", ErrInvalidAvailability, window.Weekday)
+		}
+		startMinute, err := parseClock(window.StartTime)
+		if err != nil {
+			return err
+		}
+		endMinute, err := parseClock(window.EndTime)
+		if err != nil {
+			return err
+		}
+		if endMinute <= startMinute {
+			return fmt.Errorf("%w: working hours %s-%s must end after they start", ErrInvalidAvailability, window.StartTime, window.EndTime)
+		}
+	}
+	for _, blackout := range availability.BlackoutDates {
+		if _, err := time.Parse(availabilityDateLayout, blackout.Date); err != nil {
+			return fmt.Errorf("%w: blackout date %q must be formatted as YYYY-MM-DD", ErrInvalidAvailability, blackout.Date)
+		}
+	}
+	return nil
+}
+
+// Covers reports whether the whole period falls inside one working window on a day that is not blacked out
+func (availability *UserAvailability) Covers(start time.Time, duration time.Duration) (bool, error) {
+	location, err := time.LoadLocation(availability.TimeZone)
+	if err != nil {
+		return false, err
+	}
+	localStart := start.In(location)
+	if availability.isBlackedOut(localStart) {
+		return false, nil
+	}
+	end := start.Add(duration)
+	for _, window := range availability.WorkingHours {
+		if window.Weekday != int(localStart.Weekday()) {
+			continue
+		}
+		windowStart, windowEnd, err := window.on(localStart, location)
+		if err != nil {
+			return false, err
+		}
+		if !start.Before(windowStart) && !end.After(windowEnd) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// FreeSlots lists the slots between two calendar dates, both inclusive, that are in the future and not taken by a booking
+func (availability *UserAvailability) FreeSlots(fromDate, toDate time.Time, booked []Booking, now time.Time) ([]TimeSlot, error) {
+	location, err := time.LoadLocation(availability.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+	slotLength := time.Duration(availability.SlotMinutes) * time.Minute
+	freeSlots := make([]TimeSlot, 0)
+	for day := fromDate; !day.After(toDate); day = day.AddDate(0, 0, 1) {
+		localDay := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, location)
+		if availability.isBlackedOut(localDay) {
+			continue
+		}
+		for _, window := range availability.WorkingHours {
+			if window.Weekday != int(localDay.Weekday()) {
+				continue
+			}
+			windowStart, windowEnd, err := window.on(localDay, location)
+			if err != nil {
+				return nil, err
+			}
+			for slotStart := windowStart; !slotStart.Add(slotLength).After(windowEnd); slotStart = slotStart.Add(slotLength) {
+				slotEnd := slotStart.Add(slotLength)
+				if !slotStart.After(now) || isTaken(booked, slotStart, slotEnd) {
+					continue
+				}
+				freeSlots = append(freeSlots, TimeSlot{Start: slotStart, End: slotEnd})
+			}
+		}
+	}
+	sort.Slice(freeSlots, func(i, j int) bool {
+		return freeSlots[i].Start.Before(freeSlots[j].Start)
+	})
+	return freeSlots, nil
+}
+
+func (availability *UserAvailability) isBlackedOut(localDay time.Time) bool {
+	date := localDay.Format(availabilityDateLayout)
+	for _, blackout := range availability.BlackoutDates {
+		if blackout.Date == date {
+			return true
+		}
+	}
+	return false
+}
+
+// on returns the start and end of the window on the calendar day of localDay
+func (window *AvailabilityWindow) on(localDay time.Time, location *time.Location) (time.Time, time.Time, error) {
+	startMinute, err := parseClock(window.StartTime)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	endMinute, err := parseClock(window.EndTime)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	year, month, day := localDay.Date()
+	windowStart := time.Date(year, month, day, startMinute/60, startMinute%60, 0, 0, location)
+	windowEnd := time.Date(year, month, day, endMinute/60, endMinute%60, 0, 0, location)
+	return windowStart, windowEnd, nil
+}
+
+func isTaken(booked []Booking, start, end time.Time) bool {
+	for i := range booked {
+		if booked[i].Overlaps(start, end) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseClock turns "15:04" into minutes since midnight, "24:00" is allowed as the end of the day
+func parseClock(clock string) (int, error) {
+	if clock == "24:00" {
+		return 24 * 60, nil
+	}
+	parsed, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, fmt.Errorf("%w: time %q must be formatted as HH:MM", ErrInvalidAvailability, clock)
+	}
+	return parsed.Hour()*60 + parsed.Minute(), nil
+}
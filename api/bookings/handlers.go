@@ -1,13 +1,740 @@
 package bookings
 
 import (
+	"errors"
+	"fmt"
+	"github.com/Octek/resource-profile-management-backend.git/api/auth"
+	"github.com/Octek/resource-profile-management-backend.git/api/questions"
+	"github.com/Octek/resource-profile-management-backend.git/utils"
 	"github.com/gin-gonic/gin"
-	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+	"net/http"
+	"strings"
+	"time"
 )
 
-var validate = validator.New()
+var validate = utils.NewValidator()
 
-// Routes Exports all routes handled by this service
-func Routes(router *gin.Engine, bookingSvc BookingService) {
+// bookingSortColumns are the columns the booking list can be ordered by
+var bookingSortColumns = []string{"id", "booking_date_time", "duration_minutes", "client_name", "created_at", "updated_at"}
 
+// Routes Exports all routes handled by this service. Clients book without an account, so creating a booking,
+// the free slots and the calendar feed, which is guarded by its token, are public.
+func Routes(router gin.IRouter, authenticatedRouter gin.IRouter, bookingSvc BookingService) {
+	bookingsRouter := authenticatedRouter.Group("/bookings")
+	{
+		bookingsRouter.GET("/:id", auth.Authorize(auth.AllowAdmin, auth.AllowOwner(bookingSvc.isBookingOwner)), func(c *gin.Context) {
+			HandlerToGetBookingByID(c, bookingSvc)
+		})
+		bookingsRouter.PATCH("/:id", auth.Authorize(auth.AllowAdmin, auth.AllowOwner(bookingSvc.isBookingOwner)), func(c *gin.Context) {
+			HandlerToRescheduleBookingByID(c, bookingSvc)
+		})
+		bookingsRouter.DELETE("/:id", auth.Authorize(auth.AllowAdmin, auth.AllowOwner(bookingSvc.isBookingOwner)), func(c *gin.Context) {
+			HandlerToCancelBookingByID(c, bookingSvc)
+		})
+		bookingsRouter.POST("/:id/skills", auth.Authorize(auth.AllowAdmin, auth.AllowOwner(bookingSvc.isBookingOwner)), func(c *gin.Context) {
+			HandlerToAttachSkillsToBooking(c, bookingSvc)
+		})
+		bookingsRouter.DELETE("/:id/skills/:skillId", auth.Authorize(auth.AllowAdmin, auth.AllowOwner(bookingSvc.isBookingOwner)), func(c *gin.Context) {
+			HandlerToDetachSkillFromBooking(c, bookingSvc)
+		})
+		bookingsRouter.PUT("/:id/answers", auth.Authorize(auth.AllowAdmin, auth.AllowOwner(bookingSvc.isBookingOwner)), func(c *gin.Context) {
+			HandlerToRecordBookingAnswers(c, bookingSvc)
+		})
+		bookingsRouter.GET("/user/:id", auth.Authorize(auth.AllowAdmin, auth.AllowSelf(auth.UserIDFromParam("id"))), func(c *gin.Context) {
+			HandlerToGetAllUserBookings(c, bookingSvc)
+		})
+		bookingsRouter.GET("/availability/user/:id", auth.Authorize(auth.AllowAuthenticated), func(c *gin.Context) {
+			HandlerToGetUserAvailability(c, bookingSvc)
+		})
+		bookingsRouter.PUT("/availability/user/:id", auth.Authorize(auth.AllowAdmin, auth.AllowSelf(auth.UserIDFromParam("id"))), func(c *gin.Context) {
+			HandlerToSaveUserAvailability(c, bookingSvc)
+		})
+		bookingsRouter.GET("/:id/ics", auth.Authorize(auth.AllowAdmin, auth.AllowOwner(bookingSvc.isBookingOwner)), func(c *gin.Context) {
+			HandlerToGetBookingICalendar(c, bookingSvc)
+		})
+		bookingsRouter.POST("/calendar/user/:id", auth.Authorize(auth.AllowAdmin, auth.AllowSelf(auth.UserIDFromParam("id"))), func(c *gin.Context) {
+			HandlerToRotateCalendarFeed(c, bookingSvc)
+		})
+	}
+	publicRouter := router.Group("/bookings")
+	{
+		publicRouter.POST("", func(c *gin.Context) {
+			HandlerToCreateBooking(c, bookingSvc)
+		})
+		publicRouter.GET("/availability/user/:id/slots", func(c *gin.Context) {
+			HandlerToGetFreeSlots(c, bookingSvc)
+		})
+		publicRouter.GET("/calendar/feed/:token", func(c *gin.Context) {
+			HandlerToGetCalendarFeed(c, bookingSvc)
+		})
+	}
+}
+
+// HandlerToCreateBooking godoc
+// @Tags Bookings
+// @Summary Create booking
+// @Description Book time with a resource profile and attach the skills the booking is about. The slot must be within the availability of the user and must not overlap another booking.
+// @Description The answers to the questionnaire of GET /questions/questionnaire are stored with the booking, every required question has to be answered once answers are sent
+// @ID create-booking
+// @Security ApiAuthKey
+// @Accept json
+// @Produce json
+// @Param CreateBookingRequest body CreateBookingRequest true "Booking"
+// @Success 200 {object} utils.ResponseMessage
+// @Failure 400 {object} utils.ResponseMessage
+// @Failure 404 {object} utils.ResponseMessage
+// @Failure 409 {object} utils.ResponseMessage
+// @Failure 500 {object} utils.ResponseMessage
+// @Router /bookings [post]
+func HandlerToCreateBooking(c *gin.Context, bookingSvc BookingService) {
+	fmt.Println("HandlerToCreateBooking")
+	var createBookingRequest CreateBookingRequest
+	if err := c.ShouldBind(&createBookingRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.InvalidJsonBody, err), err))
+		return
+	}
+
+	if err := validate.Struct(createBookingRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.RequestSchemaInvalid, err), err))
+		return
+	}
+	bookingObj := Booking{
+		UserID:          createBookingRequest.UserID,
+		BookingDateTime: createBookingRequest.BookingDateTime,
+		MeetingLink:     createBookingRequest.MeetingLink,
+		ClientName:      createBookingRequest.ClientName,
+		ClientEmail:     createBookingRequest.ClientEmail,
+		DurationMinutes: createBookingRequest.DurationMinutes,
+	}
+	err := bookingSvc.CreateBooking(&bookingObj, createBookingRequest.SkillIDs, createBookingRequest.Answers)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, ErrBookingInPast) || errors.Is(err, ErrOutsideAvailability) || errors.Is(err, questions.ErrInvalidAnswer) {
+			statusCode = http.StatusBadRequest
+		}
+		if errors.Is(err, ErrBookingConflict) {
+			statusCode = http.StatusConflict
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileCreatingBooking, err), err))
+		return
+	}
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.SuccessfullyCreatedBooking, Data: bookingObj})
+}
+
+// HandlerToGetBookingByID godoc
+// @Tags Bookings
+// @Summary Get booking
+// @Description Get booking
+// @ID get-booking
+// @Security ApiAuthKey
+// @Accept  json
+// @Param id path int true "Booking ID"
+// @Success 200 {object} string
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /bookings/{id} [get]
+func HandlerToGetBookingByID(c *gin.Context, bookingSvc BookingService) {
+	fmt.Println("HandlerToGetBookingByID")
+	bookingID, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
+	fetchedBooking, err := bookingSvc.GetBookingById(bookingID)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileGettingBooking, err), err))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.Success, Data: fetchedBooking})
+}
+
+// HandlerToRescheduleBookingByID godoc
+// @Tags Bookings
+// @Summary Reschedule booking
+// @Description Move a booking to a new date time within the availability of the user and optionally change the duration or meeting link
+// @ID reschedule-booking
+// @Security ApiAuthKey
+// @Accept json
+// @Param id path int true "Booking ID"
+// @Param RescheduleBookingRequest body RescheduleBookingRequest true "Booking"
+// @Success 200 {object} string
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Router /bookings/{id} [patch]
+func HandlerToRescheduleBookingByID(c *gin.Context, bookingSvc BookingService) {
+	fmt.Println("HandlerToRescheduleBookingByID")
+	bookingID, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
+
+	var rescheduleBookingRequest RescheduleBookingRequest
+	if err := c.ShouldBind(&rescheduleBookingRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.InvalidJsonBody, err), err))
+		return
+	}
+	if err := validate.Struct(rescheduleBookingRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.RequestSchemaInvalid, err), err))
+		return
+	}
+	fetchedBooking, err := bookingSvc.GetBookingById(bookingID)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileGettingBooking, err), err))
+		return
+	}
+	utils.UpdateEntity(&fetchedBooking, rescheduleBookingRequest)
+	err = bookingSvc.RescheduleBooking(fetchedBooking)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, ErrBookingInPast) || errors.Is(err, ErrOutsideAvailability) {
+			statusCode = http.StatusBadRequest
+		}
+		if errors.Is(err, ErrBookingConflict) {
+			statusCode = http.StatusConflict
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileReschedulingBooking, err), err))
+		return
+	}
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.SuccessfullyRescheduledBooking, Data: fetchedBooking})
+}
+
+// HandlerToCancelBookingByID godoc
+// @Tags Bookings
+// @Summary Cancel booking
+// @Description Cancels (soft deletes) a booking
+// @ID cancel-booking
+// @Security ApiAuthKey
+// @Accept  json
+// @Param id path int true "Booking ID"
+// @Success 200 {object} string
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /bookings/{id} [delete]
+func HandlerToCancelBookingByID(c *gin.Context, bookingSvc BookingService) {
+	fmt.Println("HandlerToCancelBookingByID")
+	bookingID, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
+	err := bookingSvc.CancelBookingById(bookingID)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileCancellingBooking, err), err))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.SuccessfullyCancelledBooking, Data: nil})
+}
+
+// HandlerToAttachSkillsToBooking godoc
+// @Tags Bookings
+// @Summary Attach skills to booking
+// @Description Attach the skills a booking is about
+// @ID attach-skills-to-booking
+// @Security ApiAuthKey
+// @Accept json
+// @Param id path int true "Booking ID"
+// @Param BookingSkillsRequest body BookingSkillsRequest true "Skills"
+// @Success 200 {object} string
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Router /bookings/{id}/skills [post]
+func HandlerToAttachSkillsToBooking(c *gin.Context, bookingSvc BookingService) {
+	fmt.Println("HandlerToAttachSkillsToBooking")
+	bookingID, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
+
+	var bookingSkillsRequest BookingSkillsRequest
+	if err := c.ShouldBind(&bookingSkillsRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.InvalidJsonBody, err), err))
+		return
+	}
+	if err := validate.Struct(bookingSkillsRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.RequestSchemaInvalid, err), err))
+		return
+	}
+	err := bookingSvc.AttachSkillsToBooking(bookingID, bookingSkillsRequest.SkillIDs)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		if errors.Is(err, ErrSkillAlreadyOnBooking) {
+			statusCode = http.StatusConflict
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileAttachingBookingSkills, err), err))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.SuccessfullyAttachedBookingSkills, Data: nil})
+}
+
+// HandlerToDetachSkillFromBooking godoc
+// @Tags Bookings
+// @Summary Detach skill from booking
+// @Description Detach a skill from a booking
+// @ID detach-skill-from-booking
+// @Security ApiAuthKey
+// @Accept  json
+// @Param id path int true "Booking ID"
+// @Param skillId path int true "Skill ID"
+// @Success 200 {object} string
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /bookings/{id}/skills/{skillId} [delete]
+func HandlerToDetachSkillFromBooking(c *gin.Context, bookingSvc BookingService) {
+	fmt.Println("HandlerToDetachSkillFromBooking")
+	bookingID, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
+	skillID, ok := utils.BindID(c, "skillId")
+	if !ok {
+		return
+	}
+	err := bookingSvc.DetachSkillFromBooking(bookingID, skillID)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileDetachingBookingSkill, err), err))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.SuccessfullyDetachedBookingSkill, Data: nil})
+}
+
+// HandlerToRecordBookingAnswers godoc
+// @Tags Bookings
+// @Summary Record booking answers
+// @Description Record the answers to the active intake questionnaire, replacing previous answers of the booking
+// @ID record-booking-answers
+// @Security ApiAuthKey
+// @Accept json
+// @Param id path int true "Booking ID"
+// @Param BookingAnswersRequest body BookingAnswersRequest true "Answers"
+// @Success 200 {object} string
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /bookings/{id}/answers [put]
+func HandlerToRecordBookingAnswers(c *gin.Context, bookingSvc BookingService) {
+	fmt.Println("HandlerToRecordBookingAnswers")
+	bookingID, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
+
+	var bookingAnswersRequest BookingAnswersRequest
+	if err := c.ShouldBind(&bookingAnswersRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.InvalidJsonBody, err), err))
+		return
+	}
+	if err := validate.Struct(bookingAnswersRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.RequestSchemaInvalid, err), err))
+		return
+	}
+	err := bookingSvc.RecordBookingAnswers(bookingID, bookingAnswersRequest.Answers)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, questions.ErrInvalidAnswer) {
+			statusCode = http.StatusBadRequest
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileRecordingBookingAnswers, err), err))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.SuccessfullyRecordedBookingAnswers, Data: nil})
+}
+
+// HandlerToGetAllUserBookings godoc
+// @Tags Bookings
+// @Summary Get all bookings of a user
+// @Description Get the upcoming, past or all bookings of a user
+// @ID get-all-user-bookings
+// @Security ApiAuthKey
+// @Accept  json
+// @Produce  json
+// @Param id path int true "User ID"
+// @Param   status    query     string     false  "upcoming or past, all bookings when empty"
+// @Param   limit    query     int     false  "example - 50"     limit(int)
+// @Param   offset     query     int     false  "example - 0"     offset(int)
+// @Param   orderBy     query     string     false  "example - booking_date_time asc"    orderBy(string)
+// @Success 200 {object} string
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /bookings/user/{id} [get]
+func HandlerToGetAllUserBookings(c *gin.Context, bookingSvc BookingService) {
+	fmt.Println("HandlerToGetAllUserBookings")
+	userID, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
+	baseQuery := c.Request.URL.Query()
+	status := baseQuery.Get("status")
+
+	if status != "" && status != BookingStatusUpcoming && status != BookingStatusPast {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, utils.InvalidBookingStatus, nil))
+		return
+	}
+
+	page, ok := utils.BindPage(c, defaultBookingOrderBy(status), bookingSortColumns...)
+	if !ok {
+		return
+	}
+	bookingList, totalRecords, err := bookingSvc.FetchAllUserBookings(userID, status, page.Limit, page.Offset, page.OrderBy)
+	if err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusInternalServerError, fmt.Sprintf(utils.SomethingWentWrongWhileGettingBooking, err), err))
+		return
+	}
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.Success, Data: utils.RecordsResponse{Total: totalRecords, RecordsFiltered: len(bookingList), Data: bookingList}})
+}
+
+// HandlerToGetUserAvailability godoc
+// @Tags Bookings
+// @Summary Get user availability
+// @Description Get the weekly working hours, time zone and blackout dates of a user
+// @ID get-user-availability
+// @Security ApiAuthKey
+// @Accept  json
+// @Param id path int true "User ID"
+// @Success 200 {object} string
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /bookings/availability/user/{id} [get]
+func HandlerToGetUserAvailability(c *gin.Context, bookingSvc BookingService) {
+	fmt.Println("HandlerToGetUserAvailability")
+	userID, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
+	availability, err := bookingSvc.GetUserAvailability(userID)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileGettingAvailability, err), err))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.Success, Data: availability})
+}
+
+// HandlerToSaveUserAvailability godoc
+// @Tags Bookings
+// @Summary Save user availability
+// @Description Replace the weekly working hours, time zone and blackout dates of a user
+// @ID save-user-availability
+// @Security ApiAuthKey
+// @Accept json
+// @Param id path int true "User ID"
+// @Param AvailabilityRequest body AvailabilityRequest true "Availability"
+// @Success 200 {object} string
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /bookings/availability/user/{id} [put]
+func HandlerToSaveUserAvailability(c *gin.Context, bookingSvc BookingService) {
+	fmt.Println("HandlerToSaveUserAvailability")
+	userID, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
+
+	var availabilityRequest AvailabilityRequest
+	if err := c.ShouldBind(&availabilityRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.InvalidJsonBody, err), err))
+		return
+	}
+	if err := validate.Struct(availabilityRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.RequestSchemaInvalid, err), err))
+		return
+	}
+	availability := UserAvailability{
+		UserID:      userID,
+		TimeZone:    availabilityRequest.TimeZone,
+		SlotMinutes: availabilityRequest.SlotMinutes,
+	}
+	if availability.SlotMinutes == 0 {
+		availability.SlotMinutes = DefaultBookingDurationMinutes
+	}
+	for _, window := range availabilityRequest.WorkingHours {
+		availability.WorkingHours = append(availability.WorkingHours, AvailabilityWindow{Weekday: *window.Weekday, StartTime: window.StartTime, EndTime: window.EndTime})
+	}
+	for _, blackout := range availabilityRequest.BlackoutDates {
+		availability.BlackoutDates = append(availability.BlackoutDates, AvailabilityBlackout{Date: blackout.Date, Reason: blackout.Reason})
+	}
+	err := bookingSvc.SaveUserAvailability(&availability)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, ErrInvalidAvailability) {
+			statusCode = http.StatusBadRequest
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileSavingAvailability, err), err))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.SuccessfullySavedAvailability, Data: availability})
+}
+
+// HandlerToGetFreeSlots godoc
+// @Tags Bookings
+// @Summary Get free slots
+// @Description Get the free booking slots of a user between two dates, both inclusive
+// @ID get-free-slots
+// @Security ApiAuthKey
+// @Accept  json
+// @Produce  json
+// @Param id path int true "User ID"
+// @Param   from    query     string     false  "example - 2024-01-01, defaults to today"
+// @Param   to    query     string     false  "example - 2024-01-07, defaults to a week after from"
+// @Success 200 {object} string
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /bookings/availability/user/{id}/slots [get]
+func HandlerToGetFreeSlots(c *gin.Context, bookingSvc BookingService) {
+	fmt.Println("HandlerToGetFreeSlots")
+	userID, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
+	baseQuery := c.Request.URL.Query()
+	from := baseQuery.Get("from")
+	to := baseQuery.Get("to")
+
+	if from == "" {
+		from = time.Now().UTC().Format(availabilityDateLayout)
+	}
+	fromDate, err := time.Parse(availabilityDateLayout, from)
+	if err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.InvalidDateValueMessage, "from", err), err))
+		return
+	}
+	if to == "" {
+		to = fromDate.AddDate(0, 0, 6).Format(availabilityDateLayout)
+	}
+	toDate, err := time.Parse(availabilityDateLayout, to)
+	if err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.InvalidDateValueMessage, "to", err), err))
+		return
+	}
+	if toDate.Before(fromDate) || toDate.Sub(fromDate) > MaxFreeSlotsRangeDays*24*time.Hour {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.InvalidDateRangeMessage, MaxFreeSlotsRangeDays), nil))
+		return
+	}
+
+	freeSlots, err := bookingSvc.GetFreeSlots(userID, fromDate, toDate)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileGettingAvailability, err), err))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.Success, Data: freeSlots})
+}
+
+// HandlerToGetBookingICalendar godoc
+// @Tags Bookings
+// @Summary Export booking as iCalendar
+// @Description Download a booking as an .ics file. The event keeps the same UID for the life of the booking and a cancelled booking is exported with STATUS:CANCELLED
+// @ID get-booking-icalendar
+// @Security ApiAuthKey
+// @Produce text/calendar
+// @Param id path int true "Booking ID"
+// @Success 200 {string} string
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /bookings/{id}/ics [get]
+func HandlerToGetBookingICalendar(c *gin.Context, bookingSvc BookingService) {
+	fmt.Println("HandlerToGetBookingICalendar")
+	bookingID, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
+	calendar, err := bookingSvc.GetBookingCalendar(bookingID)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileExportingCalendar, err), err))
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=booking-%d.ics", bookingID))
+	c.Data(http.StatusOK, iCalendarContentType, []byte(calendar))
+}
+
+// HandlerToRotateCalendarFeed godoc
+// @Tags Bookings
+// @Summary Create calendar feed
+// @Description Create the subscribable calendar feed url of a user. Calling it again issues a new url and the previous one stops working
+// @ID rotate-calendar-feed
+// @Security ApiAuthKey
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} CalendarFeedResponse
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /bookings/calendar/user/{id} [post]
+func HandlerToRotateCalendarFeed(c *gin.Context, bookingSvc BookingService) {
+	fmt.Println("HandlerToRotateCalendarFeed")
+	userID, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
+	feed, err := bookingSvc.RotateCalendarFeed(userID)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileCreatingCalendarFeed, err), err))
+		return
+	}
+
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+	if forwardedProto := c.GetHeader("X-Forwarded-Proto"); forwardedProto != "" {
+		scheme = forwardedProto
+	}
+	feedResponse := CalendarFeedResponse{
+		UserID: feed.UserID,
+		Token:  feed.Token,
+		URL:    fmt.Sprintf("%s://%s/bookings/calendar/feed/%s.ics", scheme, c.Request.Host, feed.Token),
+	}
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.SuccessfullyCreatedCalendarFeed, Data: feedResponse})
+}
+
+// HandlerToGetCalendarFeed godoc
+// @Tags Bookings
+// @Summary Calendar feed
+// @Description iCalendar feed of the upcoming bookings of a user for calendar subscriptions. The token in the url authorizes the request
+// @ID get-calendar-feed
+// @Produce text/calendar
+// @Param token path string true "Feed token, optionally followed by .ics"
+// @Success 200 {string} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /bookings/calendar/feed/{token} [get]
+func HandlerToGetCalendarFeed(c *gin.Context, bookingSvc BookingService) {
+	fmt.Println("HandlerToGetCalendarFeed")
+	token := strings.TrimSuffix(c.Param("token"), ".ics")
+	calendar, err := bookingSvc.GetCalendarFeed(token)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileExportingCalendar, err), err))
+		return
+	}
+
+	c.Header("Cache-Control", "no-cache")
+	c.Data(http.StatusOK, iCalendarContentType, []byte(calendar))
+}
+
+// defaultBookingOrderBy lists upcoming bookings soonest first and past bookings latest first
+func defaultBookingOrderBy(status string) string {
+	switch status {
+	case BookingStatusUpcoming:
+		return "booking_date_time asc"
+	case BookingStatusPast:
+		return "booking_date_time desc"
+	}
+	return utils.DefaultOrderBy
+}
+
+// All requested and response structs
+
+type CreateBookingRequest struct {
+	UserID          uint      `json:"user_id" validate:"required"`
+	BookingDateTime time.Time `json:"booking_date_time" validate:"required"`
+	MeetingLink     string    `json:"meeting_link" validate:"omitempty,url"`
+	ClientName      string    `json:"client_name"`
+	ClientEmail     string    `json:"client_email" validate:"omitempty,email"`
+	DurationMinutes int       `json:"duration_minutes" validate:"omitempty,min=5,max=480"`
+	SkillIDs        []uint    `json:"skill_ids"`
+	// Answers answer the questionnaire, when set every required question has to be answered
+	Answers []BookingAnswer `json:"answers" validate:"omitempty,dive"`
+}
+
+type RescheduleBookingRequest struct {
+	BookingDateTime time.Time `json:"booking_date_time" validate:"required"`
+	DurationMinutes int       `json:"duration_minutes" validate:"omitempty,min=5,max=480"`
+	MeetingLink     string    `json:"meeting_link" validate:"omitempty,url"`
+}
+
+type BookingSkillsRequest struct {
+	SkillIDs []uint `json:"skill_ids" validate:"required,min=1"`
+}
+
+type BookingAnswersRequest struct {
+	Answers []BookingAnswer `json:"answers" validate:"dive"`
+}
+
+type AvailabilityRequest struct {
+	TimeZone      string                        `json:"time_zone" validate:"required"`
+	SlotMinutes   int                           `json:"slot_minutes" validate:"omitempty,min=5,max=480"`
+	WorkingHours  []AvailabilityWindowRequest   `json:"working_hours" validate:"dive"`
+	BlackoutDates []AvailabilityBlackoutRequest `json:"blackout_dates" validate:"dive"`
+}
+
+type AvailabilityWindowRequest struct {
+	Weekday   *int   `json:"weekday" validate:"required,min=0,max=6"`
+	StartTime string `json:"start_time" validate:"required"`
+	EndTime   string `json:"end_time" validate:"required"`
+}
+
+type AvailabilityBlackoutRequest struct {
+	Date   string `json:"date" validate:"required"`
+	Reason string `json:"reason"`
+}
+
+type CalendarFeedResponse struct {
+	UserID uint   `json:"user_id"`
+	Token  string `json:"token"`
+	URL    string `json:"url"`
 }
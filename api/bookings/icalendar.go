@@ -0,0 +1,140 @@
+package bookings
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	iCalendarContentType = "text/calendar; charset=utf-8"
+	iCalendarProductID   = "-//Octek//Resource Profile Management//EN"
+	iCalendarTimeLayout  = "20060102T150405Z"
+	// bookingUIDDomain must never change, calendar apps match events on the UID
+	bookingUIDDomain = "bookings.resource-profile-management"
+	// RFC 5545 section 3.1, content lines are folded after 75 octets
+	iCalendarLineLimit = 75
+)
+
+// RenderICalendar builds an RFC 5545 calendar with one event per booking.
+// Cancelled bookings are kept as cancelled events so subscribed calendars remove them.
+func RenderICalendar(calendarName string, bookingList []Booking) string {
+	var builder strings.Builder
+	writeICalendarLine(&builder, "BEGIN:VCALENDAR")
+	writeICalendarLine(&builder, "VERSION:2.0")
+	writeICalendarLine(&builder, "PRODID:"+iCalendarProductID)
+	writeICalendarLine(&builder, "CALSCALE:GREGORIAN")
+	writeICalendarLine(&builder, "METHOD:PUBLISH")
+	if calendarName != "" {
+		writeICalendarLine(&builder, "X-WR-CALNAME:"+escapeICalendarText(calendarName))
+	}
+	for _, bookingObj := range bookingList {
+		writeBookingEvent(&builder, bookingObj)
+	}
+	writeICalendarLine(&builder, "END:VCALENDAR")
+	return builder.String()
+}
+
+func writeBookingEvent(builder *strings.Builder, bookingObj Booking) {
+	writeICalendarLine(builder, "BEGIN:VEVENT")
+	writeICalendarLine(builder, "UID:"+BookingUID(bookingObj.ID))
+	// the sequence grows with every change so calendar apps replace their copy of the event
+	writeICalendarLine(builder, fmt.Sprintf("SEQUENCE:%d", bookingSequence(bookingObj)))
+	writeICalendarLine(builder, "DTSTAMP:"+formatICalendarTime(bookingLastModified(bookingObj)))
+	writeICalendarLine(builder, "LAST-MODIFIED:"+formatICalendarTime(bookingLastModified(bookingObj)))
+	writeICalendarLine(builder, "CREATED:"+formatICalendarTime(bookingObj.CreatedAt))
+	writeICalendarLine(builder, "DTSTART:"+formatICalendarTime(bookingObj.BookingDateTime))
+	writeICalendarLine(builder, "DTEND:"+formatICalendarTime(bookingObj.EndTime()))
+	writeICalendarLine(builder, "SUMMARY:"+escapeICalendarText(bookingSummary(bookingObj)))
+	writeICalendarLine(builder, "DESCRIPTION:"+escapeICalendarText(bookingDescription(bookingObj)))
+	if bookingObj.MeetingLink != "" {
+		writeICalendarLine(builder, "LOCATION:"+escapeICalendarText(bookingObj.MeetingLink))
+		writeICalendarLine(builder, "URL:"+bookingObj.MeetingLink)
+	}
+	if bookingObj.DeletedAt.Valid {
+		writeICalendarLine(builder, "STATUS:CANCELLED")
+	} else {
+		writeICalendarLine(builder, "STATUS:CONFIRMED")
+	}
+	writeICalendarLine(builder, "TRANSP:OPAQUE")
+	writeICalendarLine(builder, "END:VEVENT")
+}
+
+// BookingUID stays the same for the whole life of a booking
+func BookingUID(bookingID uint) string {
+	return fmt.Sprintf("booking-%d@%s", bookingID, bookingUIDDomain)
+}
+
+// bookingLastModified includes the cancellation, soft deletes do not touch updated_at
+func bookingLastModified(bookingObj Booking) time.Time {
+	if bookingObj.DeletedAt.Valid && bookingObj.DeletedAt.Time.After(bookingObj.UpdatedAt) {
+		return bookingObj.DeletedAt.Time
+	}
+	return bookingObj.UpdatedAt
+}
+
+// bookingSequence is the number of seconds between creation and the last change, so it only ever grows
+func bookingSequence(bookingObj Booking) int64 {
+	sequence := int64(bookingLastModified(bookingObj).Sub(bookingObj.CreatedAt).Seconds())
+	if sequence < 0 {
+		return 0
+	}
+	return sequence
+}
+
+func bookingSummary(bookingObj Booking) string {
+	if bookingObj.ClientName == "" {
+		return "Booking"
+	}
+	return "Booking with " + bookingObj.ClientName
+}
+
+func bookingDescription(bookingObj Booking) string {
+	var lines []string
+	if bookingObj.ClientName != "" || bookingObj.ClientEmail != "" {
+		lines = append(lines, strings.TrimSpace(fmt.Sprintf("Client: %s %s", bookingObj.ClientName, bookingObj.ClientEmail)))
+	}
+	if len(bookingObj.Skills) > 0 {
+		skillNames := make([]string, 0, len(bookingObj.Skills))
+		for _, bookedSkill := range bookingObj.Skills {
+			skillNames = append(skillNames, bookedSkill.Name)
+		}
+		lines = append(lines, "Skills: "+strings.Join(skillNames, ", "))
+	}
+	if bookingObj.MeetingLink != "" {
+		lines = append(lines, "Meeting link: "+bookingObj.MeetingLink)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func formatICalendarTime(t time.Time) string {
+	return t.UTC().Format(iCalendarTimeLayout)
+}
+
+// escapeICalendarText escapes a TEXT value as described in RFC 5545 section 3.3.11
+func escapeICalendarText(text string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+	return replacer.Replace(text)
+}
+
+// writeICalendarLine folds the line at the octet limit without splitting a UTF-8 character and ends it with CRLF
+func writeICalendarLine(builder *strings.Builder, line string) {
+	limit := iCalendarLineLimit
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isUTF8Start(line[cut]) {
+			cut--
+		}
+		builder.WriteString(line[:cut])
+		builder.WriteString("\r\n ")
+		line = line[cut:]
+		// continuation lines start with a space that counts towards the limit
+		limit = iCalendarLineLimit - 1
+	}
+	builder.WriteString(line)
+	builder.WriteString("\r\n")
+}
+
+func isUTF8Start(b byte) bool {
+	return b&0xC0 != 0x80
+}
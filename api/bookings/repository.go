@@ -1,6 +1,22 @@
 package bookings
 
+import "time"
+
 // BookingRepository Used to store and retrieve user bookings
 type BookingRepository interface {
-	//createCategories(jsonData []Category) error
+	createBooking(bookingObj *Booking, skillIDs []uint, answers []BookingAnswer) error
+	getBookingById(id uint) (Booking, error)
+	updateBooking(bookingObj Booking) error
+	cancelBookingById(id uint) error
+	fetchAllUserBookings(userID uint, status string, limit, offset int, orderBy string) ([]Booking, int64, error)
+	attachSkillsToBooking(bookingID uint, skillIDs []uint) error
+	detachSkillFromBooking(bookingID, skillID uint) error
+	recordBookingAnswers(bookingID uint, answers []BookingAnswer) error
+	getUserAvailability(userID uint) (UserAvailability, error)
+	saveUserAvailability(availability *UserAvailability) error
+	fetchActiveUserBookingsBetween(userID uint, from, to time.Time) ([]Booking, error)
+	getBookingIncludingCancelled(id uint) (Booking, error)
+	fetchUserCalendarBookings(userID uint, from time.Time) ([]Booking, error)
+	getCalendarFeedByToken(token string) (CalendarFeed, error)
+	saveCalendarFeed(feed *CalendarFeed) error
 }
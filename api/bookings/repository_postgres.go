@@ -1,22 +1,468 @@
 package bookings
 
 import (
+	"errors"
+	"fmt"
+	"github.com/Octek/resource-profile-management-backend.git/api/questions"
 	log "github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"time"
 )
 
+// bookingLockNamespace is the first key of the per user advisory lock taken while booking
+const bookingLockNamespace = 4201
+
 type bookingRepositoryPostgres struct {
 	db *gorm.DB
 }
 
 func NewBookingRepositoryPostgres(db *gorm.DB) BookingRepository {
-	err := db.AutoMigrate(&BookingQuestion{}, &BookingSkill{}, &Booking{})
-	if err != nil {
-		log.Fatal(err)
-	}
 	log.Print("Successfully connected to postgres in booking service!")
 
 	return &bookingRepositoryPostgres{
 		db: db,
 	}
 }
+
+// createBooking stores the booking with its skills, answers are stored when the client answered the questionnaire
+func (repo *bookingRepositoryPostgres) createBooking(bookingObj *Booking, skillIDs []uint, answers []BookingAnswer) error {
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := userExists(tx, bookingObj.UserID); err != nil {
+			return err
+		}
+		if err := ensureSlotIsBookable(tx, bookingObj); err != nil {
+			return err
+		}
+		if err := tx.Create(bookingObj).Error; err != nil {
+			return err
+		}
+		if err := addBookingSkills(tx, bookingObj.ID, skillIDs); err != nil {
+			return err
+		}
+		if answers != nil {
+			bookingQuestions, err := storeBookingAnswers(tx, bookingObj.ID, answers)
+			if err != nil {
+				return err
+			}
+			bookingObj.Answers = bookingQuestions
+		}
+		fmt.Println("Booking and BookingSkill objects have been stored")
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return loadBookedSkills(repo.db, []*Booking{bookingObj})
+}
+
+func (repo *bookingRepositoryPostgres) getBookingById(id uint) (Booking, error) {
+	var bookingObj Booking
+	if err := repo.db.Where("id = ?", id).Preload("QuestionOptions").Preload("Answers").First(&bookingObj).Error; err != nil {
+		return Booking{}, err
+	}
+	if err := loadBookedSkills(repo.db, []*Booking{&bookingObj}); err != nil {
+		return Booking{}, err
+	}
+
+	fmt.Printf("Booking by id %d has been fetched\n", id)
+	return bookingObj, nil
+}
+
+func (repo *bookingRepositoryPostgres) updateBooking(bookingObj Booking) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := ensureSlotIsBookable(tx, &bookingObj); err != nil {
+			return err
+		}
+		if err := tx.Omit("QuestionOptions", "Answers").Save(&bookingObj).Error; err != nil {
+			return err
+		}
+		fmt.Println("Booking has been updated")
+		return nil
+	})
+}
+
+func (repo *bookingRepositoryPostgres) cancelBookingById(id uint) error {
+	result := repo.db.Where("id = ?", id).Delete(&Booking{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	fmt.Printf("Booking by id %d has been cancelled\n", id)
+	return nil
+}
+
+func (repo *bookingRepositoryPostgres) fetchAllUserBookings(userID uint, status string, limit, offset int, orderBy string) ([]Booking, int64, error) {
+	var bookingList []Booking
+	var totalRecords int64
+
+	query := repo.db.Model(&Booking{}).Where("user_id = ?", userID)
+	switch status {
+	case BookingStatusUpcoming:
+		query = query.Where("booking_date_time >= ?", time.Now())
+	case BookingStatusPast:
+		query = query.Where("booking_date_time < ?", time.Now())
+	}
+
+	err := query.Count(&totalRecords).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	err = query.Order(orderBy).Limit(limit).Offset(offset).Preload("QuestionOptions").Preload("Answers").Find(&bookingList).Error
+	if err != nil {
+		return nil, totalRecords, err
+	}
+
+	bookingRefs := make([]*Booking, len(bookingList))
+	for i := range bookingList {
+		bookingRefs[i] = &bookingList[i]
+	}
+	if err := loadBookedSkills(repo.db, bookingRefs); err != nil {
+		return nil, totalRecords, err
+	}
+
+	return bookingList, totalRecords, nil
+}
+
+func (repo *bookingRepositoryPostgres) attachSkillsToBooking(bookingID uint, skillIDs []uint) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("id = ?", bookingID).First(&Booking{}).Error; err != nil {
+			return err
+		}
+		var attached int64
+		if err := tx.Model(&BookingSkill{}).Where("booking_id = ? AND skill_id IN (?)", bookingID, skillIDs).Count(&attached).Error; err != nil {
+			return err
+		}
+		if attached > 0 {
+			return ErrSkillAlreadyOnBooking
+		}
+		if err := addBookingSkills(tx, bookingID, skillIDs); err != nil {
+			return err
+		}
+		if err := touchBooking(tx, bookingID); err != nil {
+			return err
+		}
+		fmt.Printf("Skills have been attached to booking %d\n", bookingID)
+		return nil
+	})
+}
+
+func (repo *bookingRepositoryPostgres) detachSkillFromBooking(bookingID, skillID uint) error {
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("booking_id = ? AND skill_id = ?", bookingID, skillID).Delete(&BookingSkill{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return touchBooking(tx, bookingID)
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Skill %d has been detached from booking %d\n", skillID, bookingID)
+	return nil
+}
+
+func (repo *bookingRepositoryPostgres) recordBookingAnswers(bookingID uint, answers []BookingAnswer) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("id = ?", bookingID).First(&Booking{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("booking_id = ?", bookingID).Delete(&BookingQuestion{}).Error; err != nil {
+			return err
+		}
+		if _, err := storeBookingAnswers(tx, bookingID, answers); err != nil {
+			return err
+		}
+		fmt.Printf("Answers of booking %d have been recorded\n", bookingID)
+		return nil
+	})
+}
+
+// storeBookingAnswers checks the answers against the active questionnaire, every required question has to be
+// answered, and stores them
+func storeBookingAnswers(tx *gorm.DB, bookingID uint, answers []BookingAnswer) ([]BookingQuestion, error) {
+	var activeQuestions []questions.Question
+	if err := tx.Where("retired_at IS NULL").Preload("QuestionOptions").Find(&activeQuestions).Error; err != nil {
+		return nil, err
+	}
+	questionsByID := make(map[uint]*questions.Question, len(activeQuestions))
+	for i := range activeQuestions {
+		questionsByID[activeQuestions[i].ID] = &activeQuestions[i]
+	}
+
+	bookingQuestions := []BookingQuestion{}
+	answered := make(map[uint]bool, len(answers))
+	for _, answer := range answers {
+		question, ok := questionsByID[answer.QuestionID]
+		if !ok {
+			return nil, fmt.Errorf("%w: question %d is not part of the active questionnaire", questions.ErrInvalidAnswer, answer.QuestionID)
+		}
+		if answered[answer.QuestionID] {
+			return nil, fmt.Errorf("%w: question %d is answered twice", questions.ErrInvalidAnswer, answer.QuestionID)
+		}
+		answered[answer.QuestionID] = true
+		if err := question.ValidateAnswer(answer.OptionIDs, answer.Answer); err != nil {
+			return nil, err
+		}
+		if question.QuestionType == questions.QuestionTypeFreeText {
+			bookingQuestions = append(bookingQuestions, BookingQuestion{BookingID: bookingID, QuestionID: question.ID, Answer: answer.Answer})
+			continue
+		}
+		for _, optionID := range answer.OptionIDs {
+			optionID := optionID
+			bookingQuestions = append(bookingQuestions, BookingQuestion{BookingID: bookingID, QuestionID: question.ID, QuestionOptionID: &optionID})
+		}
+	}
+	for _, question := range activeQuestions {
+		if question.IsRequired && !answered[question.ID] {
+			return nil, fmt.Errorf("%w: question %d is required", questions.ErrInvalidAnswer, question.ID)
+		}
+	}
+
+	if len(bookingQuestions) > 0 {
+		if err := tx.Create(&bookingQuestions).Error; err != nil {
+			return nil, err
+		}
+	}
+	return bookingQuestions, nil
+}
+
+func (repo *bookingRepositoryPostgres) getUserAvailability(userID uint) (UserAvailability, error) {
+	var availability UserAvailability
+	err := repo.db.Where("user_id = ?", userID).Preload("WorkingHours", func(db *gorm.DB) *gorm.DB {
+		return db.Order("weekday asc, start_time asc")
+	}).Preload("BlackoutDates", func(db *gorm.DB) *gorm.DB {
+		return db.Order("date asc")
+	}).First(&availability).Error
+	if err != nil {
+		return UserAvailability{}, err
+	}
+	return availability, nil
+}
+
+func (repo *bookingRepositoryPostgres) saveUserAvailability(availability *UserAvailability) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := userExists(tx, availability.UserID); err != nil {
+			return err
+		}
+		var existing UserAvailability
+		err := tx.Where("user_id = ?", availability.UserID).First(&existing).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if err == nil {
+			availability.ID = existing.ID
+			availability.CreatedAt = existing.CreatedAt
+			if err := tx.Where("user_availability_id = ?", existing.ID).Delete(&AvailabilityWindow{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("user_availability_id = ?", existing.ID).Delete(&AvailabilityBlackout{}).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Save(availability).Error; err != nil {
+			return err
+		}
+		fmt.Printf("Availability of user %d has been stored\n", availability.UserID)
+		return nil
+	})
+}
+
+func (repo *bookingRepositoryPostgres) fetchActiveUserBookingsBetween(userID uint, from, to time.Time) ([]Booking, error) {
+	var bookingList []Booking
+	err := repo.db.Where("user_id = ? AND booking_date_time >= ? AND booking_date_time < ?", userID, from.Add(-MaxBookingDurationMinutes*time.Minute), to).
+		Find(&bookingList).Error
+	if err != nil {
+		return nil, err
+	}
+	return bookingList, nil
+}
+
+// getBookingIncludingCancelled also returns cancelled bookings so they can be exported as cancelled events
+func (repo *bookingRepositoryPostgres) getBookingIncludingCancelled(id uint) (Booking, error) {
+	var bookingObj Booking
+	if err := repo.db.Unscoped().Where("id = ?", id).First(&bookingObj).Error; err != nil {
+		return Booking{}, err
+	}
+	if err := loadBookedSkills(repo.db, []*Booking{&bookingObj}); err != nil {
+		return Booking{}, err
+	}
+	return bookingObj, nil
+}
+
+func (repo *bookingRepositoryPostgres) fetchUserCalendarBookings(userID uint, from time.Time) ([]Booking, error) {
+	var bookingList []Booking
+	err := repo.db.Unscoped().Where("user_id = ? AND booking_date_time >= ?", userID, from).
+		Order("booking_date_time asc").Find(&bookingList).Error
+	if err != nil {
+		return nil, err
+	}
+
+	bookingRefs := make([]*Booking, len(bookingList))
+	for i := range bookingList {
+		bookingRefs[i] = &bookingList[i]
+	}
+	if err := loadBookedSkills(repo.db, bookingRefs); err != nil {
+		return nil, err
+	}
+	fmt.Printf("Calendar bookings of user %d have been fetched\n", userID)
+	return bookingList, nil
+}
+
+func (repo *bookingRepositoryPostgres) getCalendarFeedByToken(token string) (CalendarFeed, error) {
+	var feed CalendarFeed
+	if err := repo.db.Where("token = ?", token).First(&feed).Error; err != nil {
+		return CalendarFeed{}, err
+	}
+	return feed, nil
+}
+
+// saveCalendarFeed creates the feed of the user or replaces its token, which revokes the old feed url
+func (repo *bookingRepositoryPostgres) saveCalendarFeed(feed *CalendarFeed) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := userExists(tx, feed.UserID); err != nil {
+			return err
+		}
+		var existing CalendarFeed
+		err := tx.Where("user_id = ?", feed.UserID).First(&existing).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if err == nil {
+			feed.ID = existing.ID
+			feed.CreatedAt = existing.CreatedAt
+		}
+		if err := tx.Save(feed).Error; err != nil {
+			return err
+		}
+		fmt.Printf("Calendar feed of user %d has been stored\n", feed.UserID)
+		return nil
+	})
+}
+
+// ensureSlotIsBookable checks the booking against the availability and the other bookings of the user.
+// The advisory lock is held until the transaction ends, so concurrent requests for the same user are
+// checked one after another and cannot both take the same slot.
+func ensureSlotIsBookable(tx *gorm.DB, bookingObj *Booking) error {
+	if err := tx.Exec("SELECT pg_advisory_xact_lock(?::int, ?::int)", bookingLockNamespace, bookingObj.UserID).Error; err != nil {
+		return err
+	}
+
+	var availability UserAvailability
+	err := tx.Where("user_id = ?", bookingObj.UserID).Preload("WorkingHours").Preload("BlackoutDates").First(&availability).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("%w: the user has no availability configured", ErrOutsideAvailability)
+	}
+	if err != nil {
+		return err
+	}
+	if bookingObj.DurationMinutes == 0 {
+		bookingObj.DurationMinutes = availability.SlotMinutes
+	}
+	covered, err := availability.Covers(bookingObj.BookingDateTime, time.Duration(bookingObj.DurationMinutes)*time.Minute)
+	if err != nil {
+		return err
+	}
+	if !covered {
+		return ErrOutsideAvailability
+	}
+
+	var candidates []Booking
+	query := tx.Where("user_id = ? AND booking_date_time < ? AND booking_date_time >= ?", bookingObj.UserID,
+		bookingObj.EndTime(), bookingObj.BookingDateTime.Add(-MaxBookingDurationMinutes*time.Minute))
+	if bookingObj.ID != 0 {
+		query = query.Where("id <> ?", bookingObj.ID)
+	}
+	if err := query.Find(&candidates).Error; err != nil {
+		return err
+	}
+	if isTaken(candidates, bookingObj.BookingDateTime, bookingObj.EndTime()) {
+		return ErrBookingConflict
+	}
+	return nil
+}
+
+// touchBooking bumps updated_at so exported calendar events pick up the change
+func touchBooking(tx *gorm.DB, bookingID uint) error {
+	return tx.Model(&Booking{}).Where("id = ?", bookingID).UpdateColumn("updated_at", time.Now()).Error
+}
+
+// addBookingSkills links the given skills to a booking after checking that all of them exist
+func addBookingSkills(tx *gorm.DB, bookingID uint, skillIDs []uint) error {
+	skillIDs = uniqueIDs(skillIDs)
+	if len(skillIDs) == 0 {
+		return nil
+	}
+	var found int64
+	if err := tx.Table("skills").Where("id IN (?) AND deleted_at IS NULL", skillIDs).Count(&found).Error; err != nil {
+		return err
+	}
+	if found != int64(len(skillIDs)) {
+		return fmt.Errorf("one or more skills do not exist: %w", gorm.ErrRecordNotFound)
+	}
+	bookingSkills := make([]BookingSkill, 0, len(skillIDs))
+	for _, skillID := range skillIDs {
+		bookingSkills = append(bookingSkills, BookingSkill{BookingID: bookingID, SkillID: skillID})
+	}
+	return tx.Create(&bookingSkills).Error
+}
+
+// loadBookedSkills fills Booking.Skills from booking_skills, the skills package cannot be imported here
+func loadBookedSkills(db *gorm.DB, bookingRefs []*Booking) error {
+	if len(bookingRefs) == 0 {
+		return nil
+	}
+	bookingIDs := make([]uint, 0, len(bookingRefs))
+	for _, bookingRef := range bookingRefs {
+		bookingIDs = append(bookingIDs, bookingRef.ID)
+	}
+	var bookedSkills []BookedSkill
+	err := db.Table("booking_skills").
+		Select("skills.id, booking_skills.booking_id, skills.name, skills.icon").
+		Joins("JOIN skills ON skills.id = booking_skills.skill_id AND skills.deleted_at IS NULL").
+		Where("booking_skills.booking_id IN (?)", bookingIDs).
+		Order("skills.name").
+		Scan(&bookedSkills).Error
+	if err != nil {
+		return err
+	}
+	skillsByBooking := make(map[uint][]BookedSkill)
+	for _, bookedSkill := range bookedSkills {
+		skillsByBooking[bookedSkill.BookingID] = append(skillsByBooking[bookedSkill.BookingID], bookedSkill)
+	}
+	for _, bookingRef := range bookingRefs {
+		bookingRef.Skills = skillsByBooking[bookingRef.ID]
+	}
+	return nil
+}
+
+// userExists checks the users table directly, the users package depends on this one
+func userExists(tx *gorm.DB, userID uint) error {
+	var count int64
+	if err := tx.Table("users").Where("id = ? AND deleted_at IS NULL", userID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("user with id %d: %w", userID, gorm.ErrRecordNotFound)
+	}
+	return nil
+}
+
+func uniqueIDs(ids []uint) []uint {
+	seen := make(map[uint]bool, len(ids))
+	unique := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if id == 0 || seen[id] {
+			continue
+		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+	return unique
+}
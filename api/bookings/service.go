@@ -1,5 +1,13 @@
 package bookings
 
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"github.com/Octek/resource-profile-management-backend.git/api/auth"
+	"github.com/gin-gonic/gin"
+	"time"
+)
+
 type BookingService struct {
 	bookingRepository BookingRepository
 }
@@ -7,3 +15,108 @@ type BookingService struct {
 func NewService(r BookingRepository) BookingService {
 	return BookingService{bookingRepository: r}
 }
+
+// CreateBooking stores the booking together with its skills and the answers to the questionnaire, nil answers
+// leave the questionnaire unanswered
+func (svc *BookingService) CreateBooking(bookingObj *Booking, skillIDs []uint, answers []BookingAnswer) error {
+	if !bookingObj.BookingDateTime.After(time.Now()) {
+		return ErrBookingInPast
+	}
+	return svc.bookingRepository.createBooking(bookingObj, skillIDs, answers)
+}
+func (svc *BookingService) GetBookingById(id uint) (Booking, error) {
+	return svc.bookingRepository.getBookingById(id)
+}
+func (svc *BookingService) RescheduleBooking(bookingObj Booking) error {
+	if !bookingObj.BookingDateTime.After(time.Now()) {
+		return ErrBookingInPast
+	}
+	return svc.bookingRepository.updateBooking(bookingObj)
+}
+func (svc *BookingService) CancelBookingById(id uint) error {
+	return svc.bookingRepository.cancelBookingById(id)
+}
+func (svc *BookingService) FetchAllUserBookings(userID uint, status string, limit, offset int, orderBy string) ([]Booking, int64, error) {
+	return svc.bookingRepository.fetchAllUserBookings(userID, status, limit, offset, orderBy)
+}
+func (svc *BookingService) AttachSkillsToBooking(bookingID uint, skillIDs []uint) error {
+	return svc.bookingRepository.attachSkillsToBooking(bookingID, skillIDs)
+}
+func (svc *BookingService) DetachSkillFromBooking(bookingID, skillID uint) error {
+	return svc.bookingRepository.detachSkillFromBooking(bookingID, skillID)
+}
+func (svc *BookingService) RecordBookingAnswers(bookingID uint, answers []BookingAnswer) error {
+	return svc.bookingRepository.recordBookingAnswers(bookingID, answers)
+}
+func (svc *BookingService) GetUserAvailability(userID uint) (UserAvailability, error) {
+	return svc.bookingRepository.getUserAvailability(userID)
+}
+func (svc *BookingService) SaveUserAvailability(availability *UserAvailability) error {
+	if err := availability.Validate(); err != nil {
+		return err
+	}
+	return svc.bookingRepository.saveUserAvailability(availability)
+}
+
+// GetFreeSlots lists the free slots of a user between two calendar dates, both inclusive
+func (svc *BookingService) GetFreeSlots(userID uint, fromDate, toDate time.Time) ([]TimeSlot, error) {
+	availability, err := svc.bookingRepository.getUserAvailability(userID)
+	if err != nil {
+		return nil, err
+	}
+	// a day in any time zone lies within a day either side of the UTC calendar dates
+	booked, err := svc.bookingRepository.fetchActiveUserBookingsBetween(userID, fromDate.AddDate(0, 0, -1), toDate.AddDate(0, 0, 2))
+	if err != nil {
+		return nil, err
+	}
+	return availability.FreeSlots(fromDate, toDate, booked, time.Now())
+}
+
+// GetBookingCalendar renders a single booking, a cancelled booking is rendered as a cancelled event
+func (svc *BookingService) GetBookingCalendar(id uint) (string, error) {
+	bookingObj, err := svc.bookingRepository.getBookingIncludingCancelled(id)
+	if err != nil {
+		return "", err
+	}
+	return RenderICalendar("", []Booking{bookingObj}), nil
+}
+
+// RotateCalendarFeed gives the user a new feed token, the previous feed url stops working
+func (svc *BookingService) RotateCalendarFeed(userID uint) (CalendarFeed, error) {
+	tokenBytes := make([]byte, 32)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return CalendarFeed{}, err
+	}
+	feed := CalendarFeed{UserID: userID, Token: hex.EncodeToString(tokenBytes)}
+	if err := svc.bookingRepository.saveCalendarFeed(&feed); err != nil {
+		return CalendarFeed{}, err
+	}
+	return feed, nil
+}
+
+// GetCalendarFeed renders the upcoming and recent bookings of the user the token belongs to
+func (svc *BookingService) GetCalendarFeed(token string) (string, error) {
+	feed, err := svc.bookingRepository.getCalendarFeedByToken(token)
+	if err != nil {
+		return "", err
+	}
+	bookingList, err := svc.bookingRepository.fetchUserCalendarBookings(feed.UserID, time.Now().AddDate(0, 0, -CalendarFeedPastDays))
+	if err != nil {
+		return "", err
+	}
+	return RenderICalendar("Bookings", bookingList), nil
+}
+
+// isBookingOwner is the owner check of the routes addressing a booking by its id, the booking must be
+// with the user. A missing booking is reported as not found.
+func (svc *BookingService) isBookingOwner(c *gin.Context, userID uint) (bool, error) {
+	bookingID, err := auth.ParamID(c, "id")
+	if err != nil {
+		return false, err
+	}
+	bookingObj, err := svc.bookingRepository.getBookingById(bookingID)
+	if err != nil {
+		return false, err
+	}
+	return bookingObj.UserID == userID, nil
+}
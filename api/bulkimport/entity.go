@@ -0,0 +1,201 @@
+package bulkimport
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Octek/resource-profile-management-backend.git/api/experience"
+)
+
+const (
+	RecordTypeUser       = "user"
+	RecordTypeEducation  = "education"
+	RecordTypeExperience = "experience"
+	RecordTypeSkill      = "skill"
+	RecordTypeProject    = "project"
+	FormatCSV            = "csv"
+	FormatXLSX           = "xlsx"
+	MaxImportRows        = 5000
+	MaxImportFileSize    = 10 << 20
+	importDateLayout     = "2006-01-02"
+	// separates the skills of an experience
+	listSeparator = ";"
+	// MaxXLSXPartSize caps the unzipped size of a part of an xlsx file, the upload cap only limits the zipped size
+	MaxXLSXPartSize = 32 << 20
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("the file has to be a csv or an xlsx file")
+	ErrEmptyImport       = errors.New("the file has no rows")
+	ErrTooManyRows       = errors.New("the file has too many rows")
+	ErrPartTooLarge      = fmt.Errorf("the parts of an xlsx file are at most %d MB unzipped", MaxXLSXPartSize>>20)
+	ErrMissingTypeColumn = errors.New("the header has no type column")
+	ErrInvalidRows       = errors.New("some rows are invalid")
+	// errDryRun rolls back the transaction of a dry run
+	errDryRun = errors.New("dry run")
+)
+
+// ImportReport tells what an import did or, for a dry run, would do. Nothing is written while there are errors.
+type ImportReport struct {
+	DryRun      bool         `json:"dry_run"`
+	Committed   bool         `json:"committed"`
+	Rows        int          `json:"rows"`
+	Users       RecordCounts `json:"users"`
+	Educations  RecordCounts `json:"educations"`
+	Experiences RecordCounts `json:"experiences"`
+	Skills      RecordCounts `json:"skills"`
+	Projects    RecordCounts `json:"projects"`
+	Errors      []RowError   `json:"errors"`
+	// Warnings are the parts of a JSON Resume document that are skipped, like skills missing from the catalogue
+	Warnings []RowError `json:"warnings"`
+}
+
+type RecordCounts struct {
+	Created int `json:"created"`
+	Updated int `json:"updated"`
+}
+
+// RowError is a problem with one row, Row is the line in the file counting the header as line 1. The errors of
+// a JSON Resume document have no row, their Field is the path of the value in the document.
+type RowError struct {
+	Row     int    `json:"row,omitempty"`
+	Type    string `json:"type"`
+	Email   string `json:"email"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// record is a row of the file with its cells by column name. The records of a JSON Resume document have
+// the path in the document of each column instead of a row.
+type record struct {
+	row    int
+	fields map[string]string
+	paths  map[string]string
+}
+
+// The rows of the file by type, they are validated like the requests creating the same records.
+// The json names are the columns, they name the fields of the row errors.
+
+type userRow struct {
+	Email          string `json:"email" validate:"required,email,max=255"`
+	FirstName      string `json:"first_name" validate:"required,max=100"`
+	LastName       string `json:"last_name" validate:"required,max=100"`
+	MobileNumber   string `json:"mobile_number" validate:"max=30"`
+	Bio            string `json:"bio" validate:"max=5000"`
+	JobTitle       string `json:"job_title" validate:"max=100"`
+	Location       string `json:"location" validate:"max=100"`
+	VideoUrl       string `json:"video_url" validate:"omitempty,url,max=500"`
+	Certifications string `json:"certifications" validate:"max=2000"`
+	UserCategoryID string `json:"user_category_id" validate:"omitempty,number"`
+}
+
+type educationRow struct {
+	Email           string `json:"email" validate:"required,email,max=255"`
+	InstitutionName string `json:"institution_name" validate:"required,max=200"`
+	Degree          string `json:"degree" validate:"max=200"`
+	FieldOfStudy    string `json:"field_of_study" validate:"max=200"`
+	Achievements    string `json:"achievements" validate:"max=2000"`
+	StartDate       string `json:"start_date" validate:"required,datetime=2006-01-02"`
+	EndDate         string `json:"end_date" validate:"omitempty,datetime=2006-01-02"`
+}
+
+type experienceRow struct {
+	Email              string `json:"email" validate:"required,email,max=255"`
+	Position           string `json:"position" validate:"required,max=200"`
+	Company            string `json:"company" validate:"required,max=200"`
+	Description        string `json:"description" validate:"max=5000"`
+	StartDate          string `json:"start_date" validate:"required,datetime=2006-01-02"`
+	EndDate            string `json:"end_date" validate:"omitempty,datetime=2006-01-02"`
+	IsCurrentlyWorking string `json:"is_currently_working" validate:"omitempty,boolean"`
+	Responsibilities   string `json:"responsibilities" validate:"max=50000"`
+	Skills             string `json:"skills" validate:"max=2000"`
+}
+
+type skillRow struct {
+	Email           string `json:"email" validate:"required,email,max=255"`
+	SkillName       string `json:"skill_name" validate:"required,max=100"`
+	SkillCategoryID string `json:"skill_category_id" validate:"omitempty,number"`
+	Proficiency     string `json:"proficiency" validate:"max=20"`
+	YearsOfUse      string `json:"years_of_use" validate:"omitempty,numeric"`
+	LastUsedOn      string `json:"last_used_on" validate:"omitempty,datetime=2006-01-02"`
+}
+
+type projectRow struct {
+	Email        string `json:"email" validate:"required,email,max=255"`
+	ProjectName  string `json:"project_name" validate:"required,max=200"`
+	Description  string `json:"description" validate:"max=5000"`
+	Link         string `json:"link" validate:"omitempty,url,max=500"`
+	Technologies string `json:"technologies" validate:"max=2000"`
+}
+
+// importBatch is the validated content of a file, ready to be written
+type importBatch struct {
+	users       []importUser
+	educations  []importEducation
+	experiences []importExperience
+	skills      []importSkill
+	projects    []importProject
+}
+
+type importUser struct {
+	row            int
+	email          string
+	firstName      string
+	lastName       string
+	mobileNumber   string
+	bio            string
+	jobTitle       string
+	location       string
+	videoUrl       string
+	certifications string
+	userCategoryID uint
+}
+
+type importEducation struct {
+	row             int
+	email           string
+	institutionName string
+	degree          string
+	fieldOfStudy    string
+	achievements    string
+	startDate       time.Time
+	endDate         time.Time
+}
+
+type importExperience struct {
+	row                int
+	email              string
+	position           string
+	company            string
+	description        string
+	startDate          time.Time
+	endDate            time.Time
+	isCurrentlyWorking bool
+	responsibilities   experience.Responsibilities
+	skillIDs           []uint
+}
+
+type importSkill struct {
+	row         int
+	email       string
+	skillID     uint
+	proficiency int
+	yearsOfUse  float64
+	lastUsedOn  *time.Time
+}
+
+type importProject struct {
+	row          int
+	email        string
+	name         string
+	description  string
+	link         string
+	technologies string
+}
+
+// skillMatch is a skill of the catalogue found by its name or one of its aliases
+type skillMatch struct {
+	ID              uint
+	SkillCategoryID uint
+}
@@ -0,0 +1,164 @@
+package bulkimport
+
+import (
+	"errors"
+	"fmt"
+	"github.com/Octek/resource-profile-management-backend.git/api/auth"
+	"github.com/Octek/resource-profile-management-backend.git/api/resume"
+	"github.com/Octek/resource-profile-management-backend.git/utils"
+	"github.com/gin-gonic/gin"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Routes Exports all routes handled by this service, every route declares who may call it
+func Routes(router gin.IRouter, importSvc ImportService) {
+	importRouter := router.Group("/import")
+	{
+		importRouter.POST("/profiles", auth.Authorize(auth.AllowAdmin), func(c *gin.Context) {
+			HandlerToImportProfiles(c, importSvc)
+		})
+		importRouter.POST("/json-resume", auth.Authorize(auth.AllowAdmin), func(c *gin.Context) {
+			HandlerToImportJSONResume(c, importSvc)
+		})
+	}
+}
+
+// HandlerToImportProfiles godoc
+// @Tags Import
+// @Summary Import profiles
+// @Description Imports users with their educations, experiences, skills and projects from a csv file or the first sheet of an xlsx file.
+// @Description Every row has a type column, one of user, education, experience, skill or project, and an email column naming the user. Users are matched by email,
+// @Description educations by institution, degree and start date, experiences by company, position and start date, projects by name. The other columns are
+// @Description user: first_name, last_name, mobile_number, bio, job_title, location, video_url, certifications, user_category_id;
+// @Description education: institution_name, degree, field_of_study, achievements, start_date, end_date;
+// @Description experience: position, company, description, start_date, end_date, is_currently_working, responsibilities (a JSON array or one per line), skills (names separated by ;);
+// @Description skill: skill_name, skill_category_id, proficiency (1-5 or a label), years_of_use, last_used_on;
+// @Description project: project_name, description, link, technologies. Dates are YYYY-MM-DD.
+// @Description Imports are dry runs unless dryRun is false, nothing is written while a row is invalid and everything is written in one transaction.
+// @ID import-profiles
+// @Security ApiAuthKey
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "csv or xlsx file"
+// @Param dryRun query bool false "Validate and report without writing, defaults to true"
+// @Param format query string false "csv or xlsx, defaults to the extension of the file"
+// @Success 200 {object} ImportReport
+// @Failure 400 {object} utils.ResponseMessage
+// @Failure 413 {object} utils.ResponseMessage
+// @Failure 422 {object} ImportReport
+// @Failure 500 {object} utils.ResponseMessage
+// @Router /import/profiles [post]
+func HandlerToImportProfiles(c *gin.Context, importSvc ImportService) {
+	fmt.Println("HandlerToImportProfiles")
+	dryRun := true
+	if value := c.Query("dryRun"); value != "" {
+		var err error
+		if dryRun, err = strconv.ParseBool(value); err != nil {
+			utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.InvalidBooleanValueMessage, "dryRun", err), err))
+			return
+		}
+	}
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, MaxImportFileSize+1<<20)
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		statusCode := http.StatusBadRequest
+		var maxBytesError *http.MaxBytesError
+		if errors.As(err, &maxBytesError) {
+			statusCode = http.StatusRequestEntityTooLarge
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.InvalidImportFile, err), err))
+		return
+	}
+	if fileHeader.Size > MaxImportFileSize {
+		utils.AbortWithError(c, utils.NewError(http.StatusRequestEntityTooLarge, fmt.Sprintf(utils.InvalidImportFile, fmt.Sprintf("files are at most %d MB", MaxImportFileSize>>20)), nil))
+		return
+	}
+	format := strings.ToLower(c.Query("format"))
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(fileHeader.Filename)), ".")
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.InvalidImportFile, err), err))
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.InvalidImportFile, err), err))
+		return
+	}
+
+	report, err := importSvc.ImportProfiles(format, data, dryRun)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, ErrUnsupportedFormat), errors.Is(err, ErrEmptyImport), errors.Is(err, ErrTooManyRows), errors.Is(err, ErrMissingTypeColumn):
+			statusCode = http.StatusBadRequest
+		case errors.Is(err, ErrPartTooLarge):
+			statusCode = http.StatusRequestEntityTooLarge
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileImportingProfiles, err), err))
+		return
+	}
+	if len(report.Errors) > 0 && !dryRun {
+		c.JSON(http.StatusUnprocessableEntity, utils.ResponseMessage{StatusCode: http.StatusUnprocessableEntity, Code: utils.ErrorCodeValidationFailed, Message: fmt.Sprintf(utils.SomethingWentWrongWhileImportingProfiles, ErrInvalidRows), Data: report})
+		return
+	}
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.Success, Data: report})
+}
+
+// HandlerToImportJSONResume godoc
+// @Tags Import
+// @Summary Import JSON Resume
+// @Description Creates or updates the profile of basics.email from a JSON Resume document (https://jsonresume.org/schema).
+// @Description work becomes experiences with the highlights as responsibilities, education becomes educations with studyType as degree and area as field of study,
+// @Description skills and their keywords are assigned from the skill catalogue with the level as proficiency, projects are attached to the user.
+// @Description Records are matched like the profile import, skills or levels the catalogue does not know are skipped and listed as warnings.
+// @Description Imports are dry runs unless dryRun is false, nothing is written while the document is invalid.
+// @ID import-json-resume
+// @Security ApiAuthKey
+// @Accept json
+// @Produce json
+// @Param JSONResume body resume.JSONResume true "JSON Resume document"
+// @Param dryRun query bool false "Validate and report without writing, defaults to true"
+// @Success 200 {object} ImportReport
+// @Failure 400 {object} utils.ResponseMessage
+// @Failure 422 {object} ImportReport
+// @Failure 500 {object} utils.ResponseMessage
+// @Router /import/json-resume [post]
+func HandlerToImportJSONResume(c *gin.Context, importSvc ImportService) {
+	fmt.Println("HandlerToImportJSONResume")
+	dryRun := true
+	if value := c.Query("dryRun"); value != "" {
+		var err error
+		if dryRun, err = strconv.ParseBool(value); err != nil {
+			utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.InvalidBooleanValueMessage, "dryRun", err), err))
+			return
+		}
+	}
+	var document resume.JSONResume
+	if err := c.ShouldBindJSON(&document); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.InvalidJsonBody, err), err))
+		return
+	}
+
+	report, err := importSvc.ImportJSONResume(document, dryRun)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, ErrTooManyRows) {
+			statusCode = http.StatusBadRequest
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileImportingJSONResume, err), err))
+		return
+	}
+	if len(report.Errors) > 0 && !dryRun {
+		c.JSON(http.StatusUnprocessableEntity, utils.ResponseMessage{StatusCode: http.StatusUnprocessableEntity, Code: utils.ErrorCodeValidationFailed, Message: fmt.Sprintf(utils.SomethingWentWrongWhileImportingJSONResume, ErrInvalidRows), Data: report})
+		return
+	}
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.Success, Data: report})
+}
@@ -0,0 +1,209 @@
+package bulkimport
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Octek/resource-profile-management-backend.git/api/resume"
+)
+
+// ImportJSONResume creates or updates the profile of the basics email from a JSON Resume document. The work,
+// education, skills and projects become the records of a file import and are matched and validated the
+// same way, skills and levels the catalogue does not know are skipped with a warning.
+func (svc *ImportService) ImportJSONResume(document resume.JSONResume, dryRun bool) (*ImportReport, error) {
+	records := jsonResumeRecords(document)
+	if len(records) > MaxImportRows {
+		return nil, ErrTooManyRows
+	}
+	return svc.importRecords(records, dryRun, true)
+}
+
+// jsonResumeRecords maps the document onto the records of a file import. The name is split at its last word,
+// the location parts are joined with commas, the certificates are one per line and a profile of the Video
+// network is the video url.
+func jsonResumeRecords(document resume.JSONResume) []record {
+	email := strings.TrimSpace(document.Basics.Email)
+	firstName, lastName := splitName(document.Basics.Name)
+	var certificates []string
+	for _, certificate := range document.Certificates {
+		if name := strings.TrimSpace(certificate.Name); name != "" {
+			certificates = append(certificates, name)
+		}
+	}
+	userRecord := record{
+		fields: map[string]string{
+			"type":           RecordTypeUser,
+			"email":          email,
+			"first_name":     firstName,
+			"last_name":      lastName,
+			"job_title":      strings.TrimSpace(document.Basics.Label),
+			"mobile_number":  strings.TrimSpace(document.Basics.Phone),
+			"bio":            strings.TrimSpace(document.Basics.Summary),
+			"certifications": strings.Join(certificates, "\n"),
+		},
+		paths: map[string]string{
+			"email":          "basics.email",
+			"first_name":     "basics.name",
+			"last_name":      "basics.name",
+			"job_title":      "basics.label",
+			"mobile_number":  "basics.phone",
+			"bio":            "basics.summary",
+			"location":       "basics.location",
+			"certifications": "certificates",
+		},
+	}
+	if location := document.Basics.Location; location != nil {
+		userRecord.fields["location"] = joinNonEmpty(", ", location.Address, location.City, location.Region, location.CountryCode)
+	}
+	for i, profile := range document.Basics.Profiles {
+		if strings.EqualFold(strings.TrimSpace(profile.Network), resume.VideoProfileNetwork) {
+			userRecord.fields["video_url"] = strings.TrimSpace(profile.URL)
+			userRecord.paths["video_url"] = fmt.Sprintf("basics.profiles[%d].url", i)
+			break
+		}
+	}
+	records := []record{userRecord}
+
+	for i, work := range document.Work {
+		path := fmt.Sprintf("work[%d]", i)
+		responsibilities, _ := json.Marshal(append([]string{}, work.Highlights...))
+		records = append(records, record{
+			fields: map[string]string{
+				"type":                 RecordTypeExperience,
+				"email":                email,
+				"position":             strings.TrimSpace(work.Position),
+				"company":              strings.TrimSpace(work.Name),
+				"description":          strings.TrimSpace(work.Summary),
+				"start_date":           expandJSONResumeDate(work.StartDate),
+				"end_date":             expandJSONResumeDate(work.EndDate),
+				"is_currently_working": strconv.FormatBool(strings.TrimSpace(work.EndDate) == ""),
+				"responsibilities":     string(responsibilities),
+			},
+			paths: map[string]string{
+				"email":            "basics.email",
+				"position":         path + ".position",
+				"company":          path + ".name",
+				"description":      path + ".summary",
+				"start_date":       path + ".startDate",
+				"end_date":         path + ".endDate",
+				"responsibilities": path + ".highlights",
+			},
+		})
+	}
+
+	for i, education := range document.Education {
+		path := fmt.Sprintf("education[%d]", i)
+		records = append(records, record{
+			fields: map[string]string{
+				"type":             RecordTypeEducation,
+				"email":            email,
+				"institution_name": strings.TrimSpace(education.Institution),
+				"degree":           strings.TrimSpace(education.StudyType),
+				"field_of_study":   strings.TrimSpace(education.Area),
+				"start_date":       expandJSONResumeDate(education.StartDate),
+				"end_date":         expandJSONResumeDate(education.EndDate),
+			},
+			paths: map[string]string{
+				"email":            "basics.email",
+				"institution_name": path + ".institution",
+				"degree":           path + ".studyType",
+				"field_of_study":   path + ".area",
+				"start_date":       path + ".startDate",
+				"end_date":         path + ".endDate",
+			},
+		})
+	}
+
+	// the keywords of a skill are often the skills it groups, they are imported with the level of the group
+	for i, skill := range document.Skills {
+		path := fmt.Sprintf("skills[%d]", i)
+		names := map[string]string{path + ".name": skill.Name}
+		order := []string{path + ".name"}
+		for j, keyword := range skill.Keywords {
+			keywordPath := fmt.Sprintf("%s.keywords[%d]", path, j)
+			names[keywordPath] = keyword
+			order = append(order, keywordPath)
+		}
+		for _, namePath := range order {
+			if strings.TrimSpace(names[namePath]) == "" {
+				continue
+			}
+			records = append(records, record{
+				fields: map[string]string{
+					"type":        RecordTypeSkill,
+					"email":       email,
+					"skill_name":  strings.TrimSpace(names[namePath]),
+					"proficiency": strings.TrimSpace(skill.Level),
+				},
+				paths: map[string]string{
+					"email":       "basics.email",
+					"skill_name":  namePath,
+					"proficiency": path + ".level",
+				},
+			})
+		}
+	}
+
+	for i, project := range document.Projects {
+		path := fmt.Sprintf("projects[%d]", i)
+		description := strings.TrimSpace(project.Description)
+		if description == "" {
+			description = joinNonEmpty("\n", project.Highlights...)
+		}
+		records = append(records, record{
+			fields: map[string]string{
+				"type":         RecordTypeProject,
+				"email":        email,
+				"project_name": strings.TrimSpace(project.Name),
+				"description":  description,
+				"link":         strings.TrimSpace(project.URL),
+				"technologies": joinNonEmpty(", ", project.Keywords...),
+			},
+			paths: map[string]string{
+				"email":        "basics.email",
+				"project_name": path + ".name",
+				"description":  path + ".description",
+				"link":         path + ".url",
+				"technologies": path + ".keywords",
+			},
+		})
+	}
+	return records
+}
+
+// splitName takes the last word of a name as the last name
+func splitName(name string) (string, string) {
+	words := strings.Fields(name)
+	if len(words) < 2 {
+		return strings.Join(words, " "), ""
+	}
+	return strings.Join(words[:len(words)-1], " "), words[len(words)-1]
+}
+
+// expandJSONResumeDate completes a year or a year and month to the first day, anything else is left for
+// the validation to reject
+func expandJSONResumeDate(date string) string {
+	date = strings.TrimSpace(date)
+	switch len(date) {
+	case len("2006"):
+		return date + "-01-01"
+	case len("2006-01"):
+		return date + "-01"
+	}
+	if len(date) > len(resume.JSONResumeDateLayout) && date[len(resume.JSONResumeDateLayout)] == 'T' {
+		return date[:len(resume.JSONResumeDateLayout)]
+	}
+	return date
+}
+
+func joinNonEmpty(separator string, values ...string) string {
+	var parts []string
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			parts = append(parts, value)
+		}
+	}
+	return strings.Join(parts, separator)
+}
@@ -0,0 +1,248 @@
+package bulkimport
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// excelEpoch is day 0 of the date serial numbers of spreadsheets
+var excelEpoch = time.Date(1899, time.December, 30, 0, 0, 0, 0, time.UTC)
+
+// readRecords reads the rows of a csv file or of the first sheet of an xlsx file, the first row is the header
+func readRecords(format string, data []byte) ([]record, error) {
+	var table [][]string
+	var err error
+	switch format {
+	case FormatCSV:
+		table, err = readCSV(data)
+	case FormatXLSX:
+		table, err = readXLSX(data)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(table) < 2 {
+		return nil, ErrEmptyImport
+	}
+	header := make([]string, len(table[0]))
+	hasType := false
+	for i, name := range table[0] {
+		header[i] = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), " ", "_")
+		hasType = hasType || header[i] == "type"
+	}
+	if !hasType {
+		return nil, ErrMissingTypeColumn
+	}
+
+	records := make([]record, 0, len(table)-1)
+	for i, cells := range table[1:] {
+		fields := make(map[string]string, len(header))
+		empty := true
+		for column, value := range cells {
+			if column >= len(header) || header[column] == "" {
+				continue
+			}
+			value = strings.TrimSpace(value)
+			fields[header[column]] = value
+			empty = empty && value == ""
+		}
+		if empty {
+			continue
+		}
+		records = append(records, record{row: i + 2, fields: fields})
+	}
+	if len(records) == 0 {
+		return nil, ErrEmptyImport
+	}
+	if len(records) > MaxImportRows {
+		return nil, fmt.Errorf("%w, at most %d rows are imported at once", ErrTooManyRows, MaxImportRows)
+	}
+	return records, nil
+}
+
+func readCSV(data []byte) ([][]string, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+	return reader.ReadAll()
+}
+
+type xlsxWorkbook struct {
+	Sheets []struct {
+		RelationshipID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type xlsxSharedStrings struct {
+	Items []xlsxText `xml:"si"`
+}
+
+// xlsxText is a plain or a rich text, rich texts are split into runs
+type xlsxText struct {
+	Text string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (text xlsxText) String() string {
+	if len(text.Runs) == 0 {
+		return text.Text
+	}
+	var builder strings.Builder
+	for _, run := range text.Runs {
+		builder.WriteString(run.Text)
+	}
+	return builder.String()
+}
+
+type xlsxSheet struct {
+	Rows []struct {
+		Cells []struct {
+			Reference string   `xml:"r,attr"`
+			Type      string   `xml:"t,attr"`
+			Value     string   `xml:"v"`
+			Inline    xlsxText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// readXLSX reads the cells of the first sheet of a workbook as text
+func readXLSX(data []byte) ([][]string, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedFormat, err)
+	}
+	files := make(map[string]*zip.File, len(archive.File))
+	for _, file := range archive.File {
+		files[file.Name] = file
+	}
+
+	var workbook xlsxWorkbook
+	if err := readXMLPart(files, "xl/workbook.xml", &workbook); err != nil {
+		return nil, err
+	}
+	if len(workbook.Sheets) == 0 {
+		return nil, ErrEmptyImport
+	}
+	var relationships xlsxRelationships
+	if err := readXMLPart(files, "xl/_rels/workbook.xml.rels", &relationships); err != nil {
+		return nil, err
+	}
+	sheetPath := ""
+	for _, relationship := range relationships.Relationships {
+		if relationship.ID == workbook.Sheets[0].RelationshipID {
+			sheetPath = strings.TrimPrefix(relationship.Target, "/")
+			if !strings.HasPrefix(sheetPath, "xl/") {
+				sheetPath = path.Join("xl", sheetPath)
+			}
+		}
+	}
+	var sharedStrings xlsxSharedStrings
+	if _, ok := files["xl/sharedStrings.xml"]; ok {
+		if err := readXMLPart(files, "xl/sharedStrings.xml", &sharedStrings); err != nil {
+			return nil, err
+		}
+	}
+	var sheet xlsxSheet
+	if err := readXMLPart(files, sheetPath, &sheet); err != nil {
+		return nil, err
+	}
+
+	table := make([][]string, 0, len(sheet.Rows))
+	for _, row := range sheet.Rows {
+		var cells []string
+		for position, cell := range row.Cells {
+			column := columnIndex(cell.Reference)
+			if column < 0 {
+				column = position
+			}
+			for len(cells) <= column {
+				cells = append(cells, "")
+			}
+			switch cell.Type {
+			case "s":
+				index, err := strconv.Atoi(cell.Value)
+				if err != nil || index < 0 || index >= len(sharedStrings.Items) {
+					return nil, fmt.Errorf("%w: cell %s refers to a missing text", ErrUnsupportedFormat, cell.Reference)
+				}
+				cells[column] = sharedStrings.Items[index].String()
+			case "inlineStr":
+				cells[column] = cell.Inline.String()
+			case "b":
+				cells[column] = strconv.FormatBool(cell.Value == "1")
+			default:
+				cells[column] = cell.Value
+			}
+		}
+		table = append(table, cells)
+	}
+	return table, nil
+}
+
+func readXMLPart(files map[string]*zip.File, name string, target interface{}) error {
+	file, ok := files[name]
+	if !ok {
+		return fmt.Errorf("%w: %s is missing", ErrUnsupportedFormat, name)
+	}
+	if file.UncompressedSize64 > MaxXLSXPartSize {
+		return fmt.Errorf("%w: %s", ErrPartTooLarge, name)
+	}
+	reader, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	// the header of the part can understate its size, the reader stops one byte past the cap
+	content, err := io.ReadAll(io.LimitReader(reader, MaxXLSXPartSize+1))
+	if err != nil {
+		return err
+	}
+	if len(content) > MaxXLSXPartSize {
+		return fmt.Errorf("%w: %s", ErrPartTooLarge, name)
+	}
+	return xml.Unmarshal(content, target)
+}
+
+// columnIndex turns the letters of a cell reference like "AB12" into a zero based column
+func columnIndex(reference string) int {
+	column := 0
+	letters := 0
+	for _, char := range strings.ToUpper(reference) {
+		if char < 'A' || char > 'Z' {
+			break
+		}
+		column = column*26 + int(char-'A'+1)
+		letters++
+	}
+	if letters == 0 {
+		return -1
+	}
+	return column - 1
+}
+
+// normalizeDate turns the date serial numbers of spreadsheets into YYYY-MM-DD, other values are kept
+func normalizeDate(value string) string {
+	serial, err := strconv.ParseFloat(value, 64)
+	if err != nil || serial <= 0 {
+		return value
+	}
+	return excelEpoch.AddDate(0, 0, int(math.Floor(serial))).Format(importDateLayout)
+}
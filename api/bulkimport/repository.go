@@ -0,0 +1,9 @@
+package bulkimport
+
+// ImportRepository Used to look up the records an import refers to and to write the imported records
+type ImportRepository interface {
+	findUserIDsByEmail(emails []string) (map[string]uint, error)
+	findUserCategoryIDs(ids []uint) (map[uint]bool, error)
+	findSkills(name string) ([]skillMatch, error)
+	importBatch(batch importBatch, dryRun bool) (*ImportReport, error)
+}
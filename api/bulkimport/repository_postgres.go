@@ -0,0 +1,311 @@
+package bulkimport
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Octek/resource-profile-management-backend.git/api/experience"
+	"github.com/Octek/resource-profile-management-backend.git/api/projects"
+	"github.com/Octek/resource-profile-management-backend.git/api/skills"
+	user "github.com/Octek/resource-profile-management-backend.git/api/users"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type importRepositoryPostgres struct {
+	db *gorm.DB
+}
+
+// NewImportRepositoryPostgres has no tables of its own, it writes the users, educations, experiences, skills and projects
+func NewImportRepositoryPostgres(db *gorm.DB) ImportRepository {
+	log.Print("Successfully connected to postgres in import service!")
+
+	return &importRepositoryPostgres{
+		db: db,
+	}
+}
+
+func (repo *importRepositoryPostgres) findUserIDsByEmail(emails []string) (map[string]uint, error) {
+	return userIDsByEmail(repo.db, emails)
+}
+
+// userIDsByEmail maps the lower case emails of the existing users to their ids
+func userIDsByEmail(db *gorm.DB, emails []string) (map[string]uint, error) {
+	userIDs := make(map[string]uint, len(emails))
+	if len(emails) == 0 {
+		return userIDs, nil
+	}
+	var users []struct {
+		ID    uint
+		Email string
+	}
+	err := db.Table("users").Select("id, LOWER(email) AS email").
+		Where("LOWER(email) IN ? AND deleted_at IS NULL", emails).
+		Order("id asc").Scan(&users).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, userObj := range users {
+		if _, found := userIDs[userObj.Email]; !found {
+			userIDs[userObj.Email] = userObj.ID
+		}
+	}
+	return userIDs, nil
+}
+
+func (repo *importRepositoryPostgres) findUserCategoryIDs(ids []uint) (map[uint]bool, error) {
+	existing := make(map[uint]bool, len(ids))
+	if len(ids) == 0 {
+		return existing, nil
+	}
+	var categoryIDs []uint
+	if err := repo.db.Model(&user.UserCategory{}).Where("id IN ?", ids).Pluck("id", &categoryIDs).Error; err != nil {
+		return nil, err
+	}
+	for _, id := range categoryIDs {
+		existing[id] = true
+	}
+	return existing, nil
+}
+
+// findSkills finds the skills of the catalogue named name or having it as an alias
+func (repo *importRepositoryPostgres) findSkills(name string) ([]skillMatch, error) {
+	var matches []skillMatch
+	normalizedName := skills.NormalizeSkillName(name)
+	err := repo.db.Model(&skills.Skill{}).Select("skills.id, skills.skill_category_id").
+		Where("skills.normalized_name = ? OR skills.id IN (?)", normalizedName,
+			repo.db.Model(&skills.SkillAlias{}).Select("skill_id").Where("normalized_alias = ?", normalizedName)).
+		Order("skills.id asc").Scan(&matches).Error
+	return matches, err
+}
+
+// importBatch writes the batch in one transaction, a dry run rolls it back once everything is written
+func (repo *importRepositoryPostgres) importBatch(batch importBatch, dryRun bool) (*ImportReport, error) {
+	report := &ImportReport{}
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		emails := make([]string, 0, len(batch.users))
+		for _, userObj := range batch.users {
+			emails = append(emails, userObj.email)
+		}
+		for _, educationObj := range batch.educations {
+			emails = append(emails, educationObj.email)
+		}
+		for _, experienceObj := range batch.experiences {
+			emails = append(emails, experienceObj.email)
+		}
+		for _, skillObj := range batch.skills {
+			emails = append(emails, skillObj.email)
+		}
+		for _, projectObj := range batch.projects {
+			emails = append(emails, projectObj.email)
+		}
+		userIDs, err := userIDsByEmail(tx, emails)
+		if err != nil {
+			return err
+		}
+
+		for _, userObj := range batch.users {
+			created, err := upsertUser(tx, userObj, userIDs)
+			if err != nil {
+				return fmt.Errorf("row %d: %w", userObj.row, err)
+			}
+			report.Users.count(created)
+		}
+		for _, educationObj := range batch.educations {
+			created, err := upsertEducation(tx, educationObj, userIDs[educationObj.email])
+			if err != nil {
+				return fmt.Errorf("row %d: %w", educationObj.row, err)
+			}
+			report.Educations.count(created)
+		}
+		for _, experienceObj := range batch.experiences {
+			created, err := upsertExperience(tx, experienceObj, userIDs[experienceObj.email])
+			if err != nil {
+				return fmt.Errorf("row %d: %w", experienceObj.row, err)
+			}
+			report.Experiences.count(created)
+		}
+		for _, skillObj := range batch.skills {
+			created, err := upsertUserSkill(tx, skillObj, userIDs[skillObj.email])
+			if err != nil {
+				return fmt.Errorf("row %d: %w", skillObj.row, err)
+			}
+			report.Skills.count(created)
+		}
+		for _, projectObj := range batch.projects {
+			created, err := upsertProject(tx, projectObj, userIDs[projectObj.email])
+			if err != nil {
+				return fmt.Errorf("row %d: %w", projectObj.row, err)
+			}
+			report.Projects.count(created)
+		}
+		if dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		return nil, err
+	}
+	return report, nil
+}
+
+// upsertUser updates the user with the email of the row or creates it, empty cells keep the current values
+func upsertUser(tx *gorm.DB, userObj importUser, userIDs map[string]uint) (bool, error) {
+	if userID, found := userIDs[userObj.email]; found {
+		updates := map[string]interface{}{}
+		for column, value := range map[string]string{
+			"first_name":     userObj.firstName,
+			"last_name":      userObj.lastName,
+			"mobile_number":  userObj.mobileNumber,
+			"bio":            userObj.bio,
+			"job_title":      userObj.jobTitle,
+			"location":       userObj.location,
+			"video_url":      userObj.videoUrl,
+			"certifications": userObj.certifications,
+		} {
+			if value != "" {
+				updates[column] = value
+			}
+		}
+		if userObj.userCategoryID != 0 {
+			updates["user_category_id"] = userObj.userCategoryID
+		}
+		return false, tx.Model(&user.User{ID: userID}).Updates(updates).Error
+	}
+	newUser := user.User{
+		FirstName:      userObj.firstName,
+		LastName:       userObj.lastName,
+		Email:          userObj.email,
+		MobileNumber:   userObj.mobileNumber,
+		Bio:            userObj.bio,
+		JobTitle:       userObj.jobTitle,
+		Location:       userObj.location,
+		VideoUrl:       userObj.videoUrl,
+		Certifications: userObj.certifications,
+		UserCategoryID: userObj.userCategoryID,
+	}
+	if err := tx.Create(&newUser).Error; err != nil {
+		return false, err
+	}
+	if err := user.AssignDefaultRole(tx, newUser.ID); err != nil {
+		return false, err
+	}
+	userIDs[userObj.email] = newUser.ID
+	return true, nil
+}
+
+// upsertEducation matches an education of the user by institution, degree and start date
+func upsertEducation(tx *gorm.DB, educationObj importEducation, userID uint) (bool, error) {
+	var education user.Education
+	err := tx.Where("user_id = ? AND LOWER(institution_name) = ? AND LOWER(degree) = ? AND start_date = ?", userID,
+		strings.ToLower(educationObj.institutionName), strings.ToLower(educationObj.degree), educationObj.startDate).
+		First(&education).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, err
+	}
+	created := education.ID == 0
+	education.UserID = userID
+	education.InstitutionName = educationObj.institutionName
+	education.Degree = educationObj.degree
+	education.FieldOfStudy = educationObj.fieldOfStudy
+	education.Achievements = educationObj.achievements
+	education.StartDate = educationObj.startDate
+	education.EndDate = educationObj.endDate
+	return created, tx.Save(&education).Error
+}
+
+// upsertExperience matches an experience of the user by company, position and start date, the skills
+// of the row are added to the ones the experience already has
+func upsertExperience(tx *gorm.DB, experienceObj importExperience, userID uint) (bool, error) {
+	var experienceRecord experience.Experience
+	err := tx.Joins("JOIN user_experiences ON user_experiences.experience_id = experiences.id").
+		Where("user_experiences.user_id = ? AND LOWER(experiences.company) = ? AND LOWER(experiences.position) = ? AND experiences.start_date = ?",
+			userID, strings.ToLower(experienceObj.company), strings.ToLower(experienceObj.position), experienceObj.startDate).
+		First(&experienceRecord).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, err
+	}
+	created := experienceRecord.ID == 0
+	experienceRecord.Position = experienceObj.position
+	experienceRecord.Company = experienceObj.company
+	experienceRecord.Description = experienceObj.description
+	experienceRecord.StartDate = experienceObj.startDate
+	experienceRecord.EndDate = experienceObj.endDate
+	experienceRecord.IsCurrentlyWorking = experienceObj.isCurrentlyWorking
+	experienceRecord.Responsibilities = experienceObj.responsibilities
+	if err := tx.Omit("Skills").Save(&experienceRecord).Error; err != nil {
+		return false, err
+	}
+	if created {
+		if err := tx.Create(&experience.UserExperience{UserID: userID, ExperienceID: experienceRecord.ID}).Error; err != nil {
+			return false, err
+		}
+	}
+	if len(experienceObj.skillIDs) == 0 {
+		return created, nil
+	}
+	experienceSkills := make([]experience.ExperienceSkill, 0, len(experienceObj.skillIDs))
+	for _, skillID := range experienceObj.skillIDs {
+		experienceSkills = append(experienceSkills, experience.ExperienceSkill{SkillID: skillID, ExperienceID: experienceRecord.ID})
+	}
+	return created, tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "experience_id"}, {Name: "skill_id"}},
+		DoNothing: true,
+	}).Create(&experienceSkills).Error
+}
+
+// upsertUserSkill assigns the skill to the user, a changed proficiency is self assessed again
+func upsertUserSkill(tx *gorm.DB, skillObj importSkill, userID uint) (bool, error) {
+	var userSkill skills.UserSkill
+	err := tx.Where("user_id = ? AND skill_id = ?", userID, skillObj.skillID).First(&userSkill).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, err
+	}
+	created := userSkill.ID == 0
+	if created || userSkill.Proficiency != skillObj.proficiency {
+		userSkill.Assessment = skills.AssessmentSelf
+		userSkill.VerifiedByUserID = nil
+		userSkill.VerifiedAt = nil
+	}
+	userSkill.UserID = userID
+	userSkill.SkillID = skillObj.skillID
+	userSkill.Proficiency = skillObj.proficiency
+	userSkill.SkillLevel = skills.ProficiencyLabel(skillObj.proficiency)
+	userSkill.YearsOfUse = skillObj.yearsOfUse
+	userSkill.LastUsedOn = skillObj.lastUsedOn
+	return created, tx.Save(&userSkill).Error
+}
+
+// upsertProject matches a project of the user by name, a new project is attached to the user
+func upsertProject(tx *gorm.DB, projectObj importProject, userID uint) (bool, error) {
+	var project projects.Project
+	err := tx.Joins("JOIN user_projects ON user_projects.project_id = projects.id").
+		Where("user_projects.user_id = ? AND LOWER(projects.name) = ?", userID, strings.ToLower(projectObj.name)).
+		First(&project).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, err
+	}
+	created := project.ID == 0
+	project.Name = projectObj.name
+	project.Description = projectObj.description
+	project.Link = projectObj.link
+	project.Technologies = projectObj.technologies
+	if err := tx.Save(&project).Error; err != nil {
+		return false, err
+	}
+	if !created {
+		return false, nil
+	}
+	return true, tx.Create(&projects.UserProject{UserID: userID, ProjectID: project.ID}).Error
+}
+
+func (counts *RecordCounts) count(created bool) {
+	if created {
+		counts.Created++
+		return
+	}
+	counts.Updated++
+}
@@ -0,0 +1,478 @@
+package bulkimport
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Octek/resource-profile-management-backend.git/api/experience"
+	"github.com/Octek/resource-profile-management-backend.git/api/skills"
+	"github.com/Octek/resource-profile-management-backend.git/utils"
+	"github.com/go-playground/validator/v10"
+)
+
+// validate names the fields by their column so the row errors point at the cell
+var validate = utils.NewValidator()
+
+type ImportService struct {
+	importRepository ImportRepository
+}
+
+func NewService(r ImportRepository) ImportService {
+	return ImportService{importRepository: r}
+}
+
+// ImportProfiles validates every row of the file and, when all rows are valid, upserts the users by email
+// with their educations, experiences and skills in one transaction. A dry run is rolled back at the end,
+// so its counts are the ones a real import would have.
+func (svc *ImportService) ImportProfiles(format string, data []byte, dryRun bool) (*ImportReport, error) {
+	records, err := readRecords(format, data)
+	if err != nil {
+		return nil, err
+	}
+	return svc.importRecords(records, dryRun, false)
+}
+
+// importRecords validates the records and writes them unless one is invalid, lenient records skip the
+// skills and levels the catalogue does not know with a warning instead of failing
+func (svc *ImportService) importRecords(records []record, dryRun, lenient bool) (*ImportReport, error) {
+	report := &ImportReport{DryRun: dryRun, Rows: len(records), Errors: []RowError{}, Warnings: []RowError{}}
+	batch, err := svc.validateRecords(records, report, lenient)
+	if err != nil {
+		return nil, err
+	}
+	if len(report.Errors) > 0 {
+		return report, nil
+	}
+	written, err := svc.importRepository.importBatch(*batch, dryRun)
+	if err != nil {
+		return nil, err
+	}
+	report.Users = written.Users
+	report.Educations = written.Educations
+	report.Experiences = written.Experiences
+	report.Skills = written.Skills
+	report.Projects = written.Projects
+	report.Committed = !dryRun
+	return report, nil
+}
+
+// rowValidation collects the errors of the rows while they are turned into a batch
+type rowValidation struct {
+	report     *ImportReport
+	skillCache map[string][]skillMatch
+	lenient    bool
+}
+
+func (validation *rowValidation) fail(rec record, field, message string) {
+	validation.report.Errors = append(validation.report.Errors, rowError(rec, field, message))
+}
+
+// skip fails a row or, for lenient records, only warns that it is skipped. Records sharing a value, like
+// the keywords of a JSON Resume skill sharing its level, warn about it once.
+func (validation *rowValidation) skip(rec record, field, message string) {
+	if !validation.lenient {
+		validation.fail(rec, field, message)
+		return
+	}
+	warning := rowError(rec, field, message)
+	for _, existing := range validation.report.Warnings {
+		if existing == warning {
+			return
+		}
+	}
+	validation.report.Warnings = append(validation.report.Warnings, warning)
+}
+
+// position names where a record is for the messages pointing at it
+func (rec record) position(field string) string {
+	if path, found := rec.paths[field]; found {
+		return path
+	}
+	return fmt.Sprintf("row %d", rec.row)
+}
+
+func rowError(rec record, field, message string) RowError {
+	if path, found := rec.paths[field]; found {
+		field = path
+	}
+	return RowError{
+		Row:     rec.row,
+		Type:    strings.ToLower(rec.fields["type"]),
+		Email:   rec.fields["email"],
+		Field:   field,
+		Message: message,
+	}
+}
+
+// check validates a row struct and reports every failed rule, it tells whether the row is valid
+func (validation *rowValidation) check(rec record, row interface{}) bool {
+	err := validate.Struct(row)
+	if err == nil {
+		return true
+	}
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		validation.fail(rec, "", err.Error())
+		return false
+	}
+	for _, fieldError := range utils.NewFieldErrors(validationErrors) {
+		validation.fail(rec, fieldError.Field, fieldError.Message)
+	}
+	return false
+}
+
+func (svc *ImportService) validateRecords(records []record, report *ImportReport, lenient bool) (*importBatch, error) {
+	validation := &rowValidation{report: report, skillCache: map[string][]skillMatch{}, lenient: lenient}
+	batch := &importBatch{}
+	userRows := map[string]int{}
+	userSkillRows := map[string]record{}
+	userProjectRows := map[string]record{}
+	var referencedEmails []string
+	categoryRows := map[uint][]record{}
+
+	for _, rec := range records {
+		email := strings.ToLower(rec.fields["email"])
+		switch strings.ToLower(rec.fields["type"]) {
+		case RecordTypeUser:
+			row := userRow{
+				Email:          rec.fields["email"],
+				FirstName:      rec.fields["first_name"],
+				LastName:       rec.fields["last_name"],
+				MobileNumber:   rec.fields["mobile_number"],
+				Bio:            rec.fields["bio"],
+				JobTitle:       rec.fields["job_title"],
+				Location:       rec.fields["location"],
+				VideoUrl:       rec.fields["video_url"],
+				Certifications: rec.fields["certifications"],
+				UserCategoryID: rec.fields["user_category_id"],
+			}
+			if !validation.check(rec, row) {
+				continue
+			}
+			if firstRow, found := userRows[email]; found {
+				validation.fail(rec, "email", fmt.Sprintf("the user is already in row %d", firstRow))
+				continue
+			}
+			userRows[email] = rec.row
+			userObj := importUser{
+				row:            rec.row,
+				email:          email,
+				firstName:      row.FirstName,
+				lastName:       row.LastName,
+				mobileNumber:   row.MobileNumber,
+				bio:            row.Bio,
+				jobTitle:       row.JobTitle,
+				location:       row.Location,
+				videoUrl:       row.VideoUrl,
+				certifications: row.Certifications,
+			}
+			if row.UserCategoryID != "" {
+				categoryID, err := strconv.ParseUint(row.UserCategoryID, 10, 64)
+				if err != nil {
+					validation.fail(rec, "user_category_id", err.Error())
+					continue
+				}
+				userObj.userCategoryID = uint(categoryID)
+				categoryRows[userObj.userCategoryID] = append(categoryRows[userObj.userCategoryID], rec)
+			}
+			batch.users = append(batch.users, userObj)
+
+		case RecordTypeEducation:
+			row := educationRow{
+				Email:           rec.fields["email"],
+				InstitutionName: rec.fields["institution_name"],
+				Degree:          rec.fields["degree"],
+				FieldOfStudy:    rec.fields["field_of_study"],
+				Achievements:    rec.fields["achievements"],
+				StartDate:       normalizeDate(rec.fields["start_date"]),
+				EndDate:         normalizeDate(rec.fields["end_date"]),
+			}
+			if !validation.check(rec, row) {
+				continue
+			}
+			startDate, endDate, ok := validation.period(rec, row.StartDate, row.EndDate)
+			if !ok {
+				continue
+			}
+			referencedEmails = append(referencedEmails, email)
+			batch.educations = append(batch.educations, importEducation{
+				row:             rec.row,
+				email:           email,
+				institutionName: row.InstitutionName,
+				degree:          row.Degree,
+				fieldOfStudy:    row.FieldOfStudy,
+				achievements:    row.Achievements,
+				startDate:       startDate,
+				endDate:         endDate,
+			})
+
+		case RecordTypeExperience:
+			row := experienceRow{
+				Email:              rec.fields["email"],
+				Position:           rec.fields["position"],
+				Company:            rec.fields["company"],
+				Description:        rec.fields["description"],
+				StartDate:          normalizeDate(rec.fields["start_date"]),
+				EndDate:            normalizeDate(rec.fields["end_date"]),
+				IsCurrentlyWorking: rec.fields["is_currently_working"],
+				Responsibilities:   rec.fields["responsibilities"],
+				Skills:             rec.fields["skills"],
+			}
+			if !validation.check(rec, row) {
+				continue
+			}
+			startDate, endDate, ok := validation.period(rec, row.StartDate, row.EndDate)
+			if !ok {
+				continue
+			}
+			isCurrentlyWorking := false
+			if row.IsCurrentlyWorking != "" {
+				isCurrentlyWorking, _ = strconv.ParseBool(row.IsCurrentlyWorking)
+			}
+			responsibilities, err := parseResponsibilities(row.Responsibilities)
+			if err != nil {
+				validation.fail(rec, "responsibilities", err.Error())
+				continue
+			}
+			if len(responsibilities) > experience.MaxResponsibilities {
+				validation.fail(rec, "responsibilities", experience.ErrTooManyResponsibilities.Error())
+				continue
+			}
+			skillIDs, ok, err := svc.resolveSkillList(validation, rec, row.Skills)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+			referencedEmails = append(referencedEmails, email)
+			batch.experiences = append(batch.experiences, importExperience{
+				row:                rec.row,
+				email:              email,
+				position:           row.Position,
+				company:            row.Company,
+				description:        row.Description,
+				startDate:          startDate,
+				endDate:            endDate,
+				isCurrentlyWorking: isCurrentlyWorking,
+				responsibilities:   responsibilities,
+				skillIDs:           skillIDs,
+			})
+
+		case RecordTypeSkill:
+			row := skillRow{
+				Email:           rec.fields["email"],
+				SkillName:       rec.fields["skill_name"],
+				SkillCategoryID: rec.fields["skill_category_id"],
+				Proficiency:     rec.fields["proficiency"],
+				YearsOfUse:      rec.fields["years_of_use"],
+				LastUsedOn:      normalizeDate(rec.fields["last_used_on"]),
+			}
+			if !validation.check(rec, row) {
+				continue
+			}
+			proficiency, levelErr := skills.ParseSkillLevel(row.Proficiency)
+			if levelErr != nil && !lenient {
+				validation.fail(rec, "proficiency", levelErr.Error())
+				continue
+			}
+			skillObj := importSkill{row: rec.row, email: email, proficiency: proficiency}
+			if row.YearsOfUse != "" {
+				skillObj.yearsOfUse, _ = strconv.ParseFloat(row.YearsOfUse, 64)
+				if skillObj.yearsOfUse < 0 || skillObj.yearsOfUse > 60 {
+					validation.fail(rec, "years_of_use", "has to be between 0 and 60")
+					continue
+				}
+			}
+			if row.LastUsedOn != "" {
+				lastUsedOn, _ := time.Parse(importDateLayout, row.LastUsedOn)
+				skillObj.lastUsedOn = &lastUsedOn
+			}
+			var categoryID uint64
+			var err error
+			if row.SkillCategoryID != "" {
+				if categoryID, err = strconv.ParseUint(row.SkillCategoryID, 10, 64); err != nil {
+					validation.fail(rec, "skill_category_id", err.Error())
+					continue
+				}
+			}
+			skillID, ok, err := svc.resolveSkill(validation, rec, "skill_name", row.SkillName, uint(categoryID))
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+			userSkillKey := fmt.Sprintf("%s/%d", email, skillID)
+			if first, found := userSkillRows[userSkillKey]; found {
+				validation.skip(rec, "skill_name", fmt.Sprintf("the skill is already assigned to the user in %s", first.position("skill_name")))
+				continue
+			}
+			userSkillRows[userSkillKey] = rec
+			if levelErr != nil {
+				validation.skip(rec, "proficiency", levelErr.Error()+", the skill is imported unrated")
+			}
+			skillObj.skillID = skillID
+			referencedEmails = append(referencedEmails, email)
+			batch.skills = append(batch.skills, skillObj)
+
+		case RecordTypeProject:
+			row := projectRow{
+				Email:        rec.fields["email"],
+				ProjectName:  rec.fields["project_name"],
+				Description:  rec.fields["description"],
+				Link:         rec.fields["link"],
+				Technologies: rec.fields["technologies"],
+			}
+			if !validation.check(rec, row) {
+				continue
+			}
+			userProjectKey := email + "/" + strings.ToLower(strings.TrimSpace(row.ProjectName))
+			if first, found := userProjectRows[userProjectKey]; found {
+				validation.fail(rec, "project_name", fmt.Sprintf("the project is already in %s", first.position("project_name")))
+				continue
+			}
+			userProjectRows[userProjectKey] = rec
+			referencedEmails = append(referencedEmails, email)
+			batch.projects = append(batch.projects, importProject{
+				row:          rec.row,
+				email:        email,
+				name:         strings.TrimSpace(row.ProjectName),
+				description:  row.Description,
+				link:         row.Link,
+				technologies: row.Technologies,
+			})
+
+		default:
+			validation.fail(rec, "type", fmt.Sprintf("expected one of %s, %s, %s, %s or %s",
+				RecordTypeUser, RecordTypeEducation, RecordTypeExperience, RecordTypeSkill, RecordTypeProject))
+		}
+	}
+
+	// the educations, experiences and skills belong to a user of the file or to an existing user
+	existingUserIDs, err := svc.importRepository.findUserIDsByEmail(referencedEmails)
+	if err != nil {
+		return nil, err
+	}
+	unknownUser := func(row int, email, recordType string) {
+		_, inFile := userRows[email]
+		_, exists := existingUserIDs[email]
+		if !inFile && !exists {
+			validation.report.Errors = append(validation.report.Errors, RowError{Row: row, Type: recordType, Email: email,
+				Field: "email", Message: "no user with this email in the file or in the profiles"})
+		}
+	}
+	for _, educationObj := range batch.educations {
+		unknownUser(educationObj.row, educationObj.email, RecordTypeEducation)
+	}
+	for _, experienceObj := range batch.experiences {
+		unknownUser(experienceObj.row, experienceObj.email, RecordTypeExperience)
+	}
+	for _, skillObj := range batch.skills {
+		unknownUser(skillObj.row, skillObj.email, RecordTypeSkill)
+	}
+	for _, projectObj := range batch.projects {
+		unknownUser(projectObj.row, projectObj.email, RecordTypeProject)
+	}
+
+	categoryIDs := make([]uint, 0, len(categoryRows))
+	for categoryID := range categoryRows {
+		categoryIDs = append(categoryIDs, categoryID)
+	}
+	existingCategories, err := svc.importRepository.findUserCategoryIDs(categoryIDs)
+	if err != nil {
+		return nil, err
+	}
+	for categoryID, recs := range categoryRows {
+		if existingCategories[categoryID] {
+			continue
+		}
+		for _, rec := range recs {
+			validation.fail(rec, "user_category_id", fmt.Sprintf("user category %d does not exist", categoryID))
+		}
+	}
+	sort.SliceStable(report.Errors, func(i, j int) bool { return report.Errors[i].Row < report.Errors[j].Row })
+	return batch, nil
+}
+
+// period parses the dates of a row, they are already known to be dates
+func (validation *rowValidation) period(rec record, start, end string) (time.Time, time.Time, bool) {
+	startDate, _ := time.Parse(importDateLayout, start)
+	var endDate time.Time
+	if end != "" {
+		endDate, _ = time.Parse(importDateLayout, end)
+		if endDate.Before(startDate) {
+			validation.fail(rec, "end_date", "cannot be before the start date")
+			return startDate, endDate, false
+		}
+	}
+	return startDate, endDate, true
+}
+
+// resolveSkill finds the skill of the catalogue a cell names, the category picks between skills of the same name
+func (svc *ImportService) resolveSkill(validation *rowValidation, rec record, field, name string, categoryID uint) (uint, bool, error) {
+	key := skills.NormalizeSkillName(name)
+	matches, found := validation.skillCache[key]
+	if !found {
+		var err error
+		if matches, err = svc.importRepository.findSkills(name); err != nil {
+			return 0, false, err
+		}
+		validation.skillCache[key] = matches
+	}
+	var candidates []skillMatch
+	for _, match := range matches {
+		if categoryID == 0 || match.SkillCategoryID == categoryID {
+			candidates = append(candidates, match)
+		}
+	}
+	switch len(candidates) {
+	case 0:
+		validation.skip(rec, field, fmt.Sprintf("skill %q is not in the catalogue", name))
+		return 0, false, nil
+	case 1:
+		return candidates[0].ID, true, nil
+	default:
+		message := fmt.Sprintf("skill %q is in several categories, set the skill_category_id", name)
+		if validation.lenient {
+			message = fmt.Sprintf("skill %q is in several categories, assign it on the profile", name)
+		}
+		validation.skip(rec, field, message)
+		return 0, false, nil
+	}
+}
+
+// resolveSkillList resolves the skills of an experience, separated by semicolons
+func (svc *ImportService) resolveSkillList(validation *rowValidation, rec record, list string) ([]uint, bool, error) {
+	var skillIDs []uint
+	valid := true
+	for _, name := range strings.Split(list, listSeparator) {
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+		skillID, ok, err := svc.resolveSkill(validation, rec, "skills", name, 0)
+		if err != nil {
+			return nil, false, err
+		}
+		valid = valid && ok
+		skillIDs = append(skillIDs, skillID)
+	}
+	return skillIDs, valid, nil
+}
+
+// parseResponsibilities reads a JSON array of strings or one responsibility per line, empty ones are dropped
+func parseResponsibilities(cell string) (experience.Responsibilities, error) {
+	if strings.HasPrefix(cell, "[") {
+		var responsibilities []string
+		if err := json.Unmarshal([]byte(cell), &responsibilities); err != nil {
+			return nil, fmt.Errorf("expected a JSON array of strings: %v", err)
+		}
+		return experience.NewResponsibilities(responsibilities), nil
+	}
+	return experience.NewResponsibilities(strings.Split(cell, "\n")), nil
+}
@@ -1,26 +1,28 @@
 package experience
 
 import (
+	"errors"
 	"github.com/Octek/resource-profile-management-backend.git/api/skills"
 	"gorm.io/gorm"
-	"strings"
 	"time"
 )
 
+// ErrUnknownSkill is returned when an experience references a skill that is not in the catalogue
+var ErrUnknownSkill = errors.New("skill does not exist")
+
 type Experience struct {
-	ID                 uint           `json:"id" gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
-	Position           string         `json:"position"`
-	Company            string         `json:"company"`
-	Description        string         `json:"description"`
-	StartDate          time.Time      `json:"start_date"`
-	EndDate            time.Time      `json:"end_date"`
-	IsCurrentlyWorking bool           `json:"is_currently_working"`
-	Responsibilities   string         `json:"-"`
-	Responsibility     []string       `json:"responsibilities" gorm:"-"`
-	Skills             []skills.Skill `json:"skills" gorm:"many2many:experience_skills;"`
-	DeletedAt          gorm.DeletedAt `json:"deleted_at"`
-	CreatedAt          time.Time      `json:"created_at"`
-	UpdatedAt          time.Time      `json:"updated_at"`
+	ID                 uint             `json:"id" gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
+	Position           string           `json:"position"`
+	Company            string           `json:"company"`
+	Description        string           `json:"description"`
+	StartDate          time.Time        `json:"start_date"`
+	EndDate            time.Time        `json:"end_date"`
+	IsCurrentlyWorking bool             `json:"is_currently_working"`
+	Responsibilities   Responsibilities `json:"responsibilities" gorm:"NOT NULL;default:'[]'"`
+	Skills             []skills.Skill   `json:"skills" gorm:"many2many:experience_skills;"`
+	DeletedAt          gorm.DeletedAt   `json:"deleted_at"`
+	CreatedAt          time.Time        `json:"created_at"`
+	UpdatedAt          time.Time        `json:"updated_at"`
 }
 
 type UserExperience struct {
@@ -38,8 +40,3 @@ type ExperienceSkill struct {
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
 }
-
-func (experience *Experience) ParseResponsibilities() {
-	responsibilities := strings.Split(experience.Responsibilities, "|")
-	experience.Responsibility = responsibilities
-}
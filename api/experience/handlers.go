@@ -1,37 +1,66 @@
 package experience
 
 import (
+	"errors"
 	"fmt"
+	"github.com/Octek/resource-profile-management-backend.git/api/auth"
 	"github.com/Octek/resource-profile-management-backend.git/utils"
 	"github.com/gin-gonic/gin"
-	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
 	"net/http"
 	"strconv"
 	"time"
 )
 
-var validate = validator.New()
+var validate = utils.NewValidator()
 
-// Routes Exports all routes handled by this service
-func Routes(router *gin.Engine, experienceSvc ExperienceService) {
+// experienceSortColumns are the columns the experience list can be ordered by
+var experienceSortColumns = []string{"id", "position", "company", "start_date", "end_date", "created_at", "updated_at"}
+
+// Routes Exports all routes handled by this service, every route declares who may call it
+func Routes(router gin.IRouter, experienceSvc ExperienceService) {
 	subRouter := router.Group("/experience")
 	{
-		subRouter.POST("", func(c *gin.Context) {
+		subRouter.POST("", auth.Authorize(auth.AllowAdmin, auth.AllowSelf(auth.UserIDFromJSONBody("user_id"))), func(c *gin.Context) {
 			AddUserExperienceHandler(experienceSvc, c)
 		})
-		subRouter.GET("/:id", func(c *gin.Context) {
+		subRouter.GET("/:id", auth.Authorize(auth.AllowAuthenticated), func(c *gin.Context) {
 			GetUserExperienceByIdHandler(experienceSvc, c)
 		})
-		subRouter.DELETE("/:id", func(c *gin.Context) {
+		subRouter.DELETE("/:id", auth.Authorize(auth.AllowAdmin, auth.AllowOwner(experienceSvc.isExperienceOwner)), func(c *gin.Context) {
 			DeleteUserExperienceByIdHandler(experienceSvc, c)
 		})
-		subRouter.PATCH("/:id", func(c *gin.Context) {
+		subRouter.PATCH("/:id", auth.Authorize(auth.AllowAdmin, auth.AllowOwner(experienceSvc.isExperienceOwner)), func(c *gin.Context) {
 			UpdateUserExperienceByIdHandler(experienceSvc, c)
 		})
-		subRouter.DELETE("/user/:id", func(c *gin.Context) {
+		subRouter.POST("/:id/skills", auth.Authorize(auth.AllowAdmin, auth.AllowOwner(experienceSvc.isExperienceOwner)), func(c *gin.Context) {
+			HandlerToAddExperienceSkills(experienceSvc, c)
+		})
+		subRouter.DELETE("/:id/skills/:skillId", auth.Authorize(auth.AllowAdmin, auth.AllowOwner(experienceSvc.isExperienceOwner)), func(c *gin.Context) {
+			HandlerToRemoveExperienceSkill(experienceSvc, c)
+		})
+		subRouter.GET("/:id/responsibilities", auth.Authorize(auth.AllowAuthenticated), func(c *gin.Context) {
+			HandlerToGetResponsibilities(experienceSvc, c)
+		})
+		subRouter.PUT("/:id/responsibilities", auth.Authorize(auth.AllowAdmin, auth.AllowOwner(experienceSvc.isExperienceOwner)), func(c *gin.Context) {
+			HandlerToSetResponsibilities(experienceSvc, c)
+		})
+		subRouter.POST("/:id/responsibilities", auth.Authorize(auth.AllowAdmin, auth.AllowOwner(experienceSvc.isExperienceOwner)), func(c *gin.Context) {
+			HandlerToAddResponsibility(experienceSvc, c)
+		})
+		subRouter.PUT("/:id/responsibilities/order", auth.Authorize(auth.AllowAdmin, auth.AllowOwner(experienceSvc.isExperienceOwner)), func(c *gin.Context) {
+			HandlerToReorderResponsibilities(experienceSvc, c)
+		})
+		subRouter.PATCH("/:id/responsibilities/:index", auth.Authorize(auth.AllowAdmin, auth.AllowOwner(experienceSvc.isExperienceOwner)), func(c *gin.Context) {
+			HandlerToUpdateResponsibility(experienceSvc, c)
+		})
+		subRouter.DELETE("/:id/responsibilities/:index", auth.Authorize(auth.AllowAdmin, auth.AllowOwner(experienceSvc.isExperienceOwner)), func(c *gin.Context) {
+			HandlerToDeleteResponsibility(experienceSvc, c)
+		})
+		subRouter.DELETE("/user/:id", auth.Authorize(auth.AllowAdmin, auth.AllowSelf(auth.UserIDFromParam("id"))), func(c *gin.Context) {
 			DeleteUserExperienceByUserIdHandler(experienceSvc, c)
 		})
-		subRouter.GET("/user/:id", func(c *gin.Context) {
+		subRouter.GET("/user/:id", auth.Authorize(auth.AllowAuthenticated), func(c *gin.Context) {
 			HandlerToGetAllUserExperience(experienceSvc, c)
 		})
 	}
@@ -39,11 +68,31 @@ func Routes(router *gin.Engine, experienceSvc ExperienceService) {
 }
 
 type AddUserExperienceRequest struct {
+	// SkillID is kept for older clients, it is added to SkillIDs
 	SkillID     uint       `json:"skill_id"`
+	SkillIDs    []uint     `json:"skill_ids" validate:"max=50,dive,gt=0"`
 	UserID      uint       `json:"user_id" validate:"required"`
 	Experiences ExpRequest `json:"experiences"`
 }
 
+type ResponsibilitiesRequest struct {
+	Responsibilities []string `json:"responsibilities" validate:"max=50,dive,max=1000"`
+}
+
+type ResponsibilityRequest struct {
+	Responsibility string `json:"responsibility" validate:"required,max=1000"`
+	// Position is zero based, responsibilities without one are appended
+	Position *int `json:"position" validate:"omitempty,min=0"`
+}
+
+type ResponsibilityOrderRequest struct {
+	Order []int `json:"order" validate:"required,dive,min=0"`
+}
+
+type ExperienceSkillsRequest struct {
+	SkillIDs []uint `json:"skill_ids" validate:"required,min=1,max=50,dive,gt=0"`
+}
+
 type ExpRequest struct {
 	Position           string    `json:"position" validate:"required"`
 	Company            string    `json:"company" validate:"required"`
@@ -51,14 +100,15 @@ type ExpRequest struct {
 	StartDate          time.Time `json:"start_date" validate:"required"`
 	EndDate            time.Time `json:"end_date"`
 	IsCurrentlyWorking bool      `json:"is_currently_working"`
-	Responsibilities   string    `json:"responsibilities"`
+	Responsibilities   []string  `json:"responsibilities" validate:"max=50,dive,max=1000"`
 }
 
 // AddUserExperienceHandler godoc
 // @Tags experience
 // @Summary Add experiences for user
-// @Description Adds new experiences for a given user ID
+// @Description Adds new experiences for a given user ID, linked to the skills in skill_ids. Every skill has to exist
 // @ID add-experience
+// @Security ApiAuthKey
 // @Accept json
 // @Produce json
 // @Param AddUserExperienceRequest body AddUserExperienceRequest true "AddUserExperienceRequest"
@@ -71,17 +121,17 @@ func AddUserExperienceHandler(experienceSvc ExperienceService, c *gin.Context) {
 	addUserExpReq := AddUserExperienceRequest{}
 
 	if err := c.ShouldBindJSON(&addUserExpReq); err != nil {
-		c.JSON(http.StatusBadRequest, utils.ResponseMessage{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf("Failed to bind experience request: %v", err), Data: nil})
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf("Failed to bind experience request: %v", err), err))
 		return
 	}
 
 	if err := validate.Struct(&addUserExpReq); err != nil {
-		c.JSON(http.StatusBadRequest, utils.ResponseMessage{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf("Validation failed: %v", err), Data: nil})
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf("Validation failed: %v", err), err))
 		return
 	}
 
 	if !addUserExpReq.Experiences.IsCurrentlyWorking && addUserExpReq.Experiences.EndDate.Before(addUserExpReq.Experiences.StartDate) {
-		c.JSON(http.StatusBadRequest, utils.ResponseMessage{StatusCode: http.StatusBadRequest, Message: "End date cannot be before the start date.", Data: nil})
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, "End date cannot be before the start date.", nil))
 		return
 	}
 
@@ -92,12 +142,20 @@ func AddUserExperienceHandler(experienceSvc ExperienceService, c *gin.Context) {
 		StartDate:          addUserExpReq.Experiences.StartDate,
 		EndDate:            addUserExpReq.Experiences.EndDate,
 		IsCurrentlyWorking: addUserExpReq.Experiences.IsCurrentlyWorking,
-		Responsibilities:   addUserExpReq.Experiences.Responsibilities,
+		Responsibilities:   NewResponsibilities(addUserExpReq.Experiences.Responsibilities),
 	}
 
-	createdExperiences, err := experienceSvc.AddExperienceWithUserAndSkills(addUserExpReq.UserID, addUserExpReq.SkillID, &experience)
+	skillIDs := addUserExpReq.SkillIDs
+	if addUserExpReq.SkillID != 0 {
+		skillIDs = append(skillIDs, addUserExpReq.SkillID)
+	}
+	createdExperiences, err := experienceSvc.AddExperienceWithUserAndSkills(addUserExpReq.UserID, skillIDs, &experience)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, utils.ResponseMessage{StatusCode: http.StatusInternalServerError, Message: fmt.Sprintf("Failed to add experiences: %v", err), Data: nil})
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, ErrUnknownSkill) {
+			statusCode = http.StatusBadRequest
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf("Failed to add experiences: %v", err), err))
 		return
 	}
 
@@ -111,64 +169,364 @@ type UpdateExpRequest struct {
 	StartDate          time.Time `json:"start_date" validate:"required"`
 	EndDate            time.Time `json:"end_date"`
 	IsCurrentlyWorking bool      `json:"is_currently_working"`
-	Responsibilities   string    `json:"responsibilities"`
+	// Responsibilities replace all responsibilities when they are sent, an empty array removes them
+	Responsibilities *[]string `json:"responsibilities" validate:"omitempty,max=50,dive,max=1000"`
 }
 
 // UpdateUserExperienceByIdHandler godoc
 // @Tags experience
 // @Summary Update experience
-// @Description Updates experience
+// @Description Updates experience, users can only update experiences linked to them
 // @ID update-experience
+// @Security ApiAuthKey
 // @Accept  json
 // @Produce  json
 // @Param id path uint true "id"
-// @Param userId query uint true "userId"
 // @Param UpdateExpRequest body UpdateExpRequest true "UpdateExpRequest"
 // @Success 200 {object} string
 // @Failure 400 {object} string
+// @Failure 403 {object} string
 // @Failure 404 {object} string
 // @Failure 500 {object} string
 // @Router /experience/{id} [patch]
 func UpdateUserExperienceByIdHandler(experienceSvc ExperienceService, c *gin.Context) {
-	userId := c.Request.URL.Query().Get("userId")
-	userIdInt, _ := strconv.Atoi(userId)
 	var updateExpRequest UpdateExpRequest
 
 	if err := c.ShouldBindJSON(&updateExpRequest); err != nil {
-		c.JSON(http.StatusBadRequest, utils.ResponseMessage{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf("Validation failed: %v", err), Data: nil})
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf("Validation failed: %v", err), err))
 		return
 	}
 
 	if err := validate.Struct(&updateExpRequest); err != nil {
-		c.JSON(http.StatusBadRequest, utils.ResponseMessage{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf("Validation failed: %v", err), Data: nil})
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf("Validation failed: %v", err), err))
+		return
+	}
+
+	experienceId, ok := utils.BindID(c, "id")
+	if !ok {
 		return
 	}
 
-	experienceId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	// the route policy has already checked the user_experiences link of the caller unless they are an admin
+	existingExperience, err := experienceSvc.GetExperienceById(experienceId)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, utils.ResponseMessage{StatusCode: http.StatusBadRequest, Message: "Invalid experience ID", Data: nil})
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf("Something went wrong while fetching the experience: %v", err), err))
+		return
+	}
+
+	_ = utils.UpdateEntity(existingExperience, updateExpRequest)
+	if updateExpRequest.Responsibilities != nil {
+		existingExperience.Responsibilities = NewResponsibilities(*updateExpRequest.Responsibilities)
+	}
+	if err = experienceSvc.UpdateExperience(existingExperience); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusInternalServerError, "Failed to update experience", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: "Experience updated successfully", Data: nil})
+}
+
+// HandlerToAddExperienceSkills godoc
+// @Tags experience
+// @Summary Add skills to experience
+// @Description Links skills to an experience, skills that are already linked are skipped. Returns all skills of the experience
+// @ID add-experience-skills
+// @Security ApiAuthKey
+// @Accept  json
+// @Produce  json
+// @Param id path int true "id"
+// @Param ExperienceSkillsRequest body ExperienceSkillsRequest true "ExperienceSkillsRequest"
+// @Success 200 {object} utils.ResponseMessage
+// @Failure 400 {object} utils.ResponseMessage
+// @Failure 403 {object} utils.ResponseMessage
+// @Failure 404 {object} utils.ResponseMessage
+// @Failure 500 {object} utils.ResponseMessage
+// @Router /experience/{id}/skills [post]
+func HandlerToAddExperienceSkills(experienceSvc ExperienceService, c *gin.Context) {
+	expId, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
+	var experienceSkillsRequest ExperienceSkillsRequest
+	if err := c.ShouldBindJSON(&experienceSkillsRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.InvalidJsonBody, err), err))
+		return
+	}
+	if err := validate.Struct(&experienceSkillsRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.RequestSchemaInvalid, err), err))
 		return
 	}
 
-	existingExperience, err := experienceSvc.GetExperienceById(uint(experienceId))
+	experienceSkills, err := experienceSvc.AddSkillsToExperience(expId, experienceSkillsRequest.SkillIDs)
 	if err != nil {
-		c.JSON(http.StatusNotFound, utils.ResponseMessage{StatusCode: http.StatusNotFound, Message: "Experience not found", Data: nil})
+		statusCode := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, ErrUnknownSkill):
+			statusCode = http.StatusBadRequest
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			statusCode = http.StatusNotFound
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileAddingExperienceSkills, err), err))
+		return
+	}
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.SuccessfullyAddedExperienceSkills, Data: experienceSkills})
+}
+
+// HandlerToRemoveExperienceSkill godoc
+// @Tags experience
+// @Summary Remove skill from experience
+// @Description Unlinks a skill from an experience, the skill stays in the catalogue
+// @ID remove-experience-skill
+// @Security ApiAuthKey
+// @Produce  json
+// @Param id path int true "id"
+// @Param skillId path int true "skillId"
+// @Success 200 {object} utils.ResponseMessage
+// @Failure 400 {object} utils.ResponseMessage
+// @Failure 403 {object} utils.ResponseMessage
+// @Failure 404 {object} utils.ResponseMessage
+// @Failure 500 {object} utils.ResponseMessage
+// @Router /experience/{id}/skills/{skillId} [delete]
+func HandlerToRemoveExperienceSkill(experienceSvc ExperienceService, c *gin.Context) {
+	expId, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
+	skillId, ok := utils.BindID(c, "skillId")
+	if !ok {
 		return
 	}
 
-	_, err = experienceSvc.GetUserExperienceByUserIdAndExperienceId(uint(userIdInt), uint(experienceId))
+	if err := experienceSvc.RemoveSkillFromExperience(expId, skillId); err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileRemovingExperienceSkill, err), err))
+		return
+	}
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.SuccessfullyRemovedExperienceSkill, Data: nil})
+}
+
+// HandlerToGetResponsibilities godoc
+// @Tags experience
+// @Summary Get responsibilities of experience
+// @Description Get the responsibilities of an experience in their order
+// @ID get-experience-responsibilities
+// @Security ApiAuthKey
+// @Produce  json
+// @Param id path int true "id"
+// @Success 200 {object} utils.ResponseMessage
+// @Failure 400 {object} utils.ResponseMessage
+// @Failure 404 {object} utils.ResponseMessage
+// @Failure 500 {object} utils.ResponseMessage
+// @Router /experience/{id}/responsibilities [get]
+func HandlerToGetResponsibilities(experienceSvc ExperienceService, c *gin.Context) {
+	expId, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
+	responsibilities, err := experienceSvc.GetResponsibilities(expId)
 	if err != nil {
-		c.JSON(http.StatusForbidden, utils.ResponseMessage{StatusCode: http.StatusForbidden, Message: "You are not authorized to update this experience", Data: nil})
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileGettingResponsibilities, err), err))
 		return
 	}
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.Success, Data: responsibilities})
+}
 
-	_ = utils.UpdateEntity(existingExperience, updateExpRequest)
-	if err = experienceSvc.UpdateExperience(existingExperience); err != nil {
-		c.JSON(http.StatusInternalServerError, utils.ResponseMessage{StatusCode: http.StatusInternalServerError, Message: "Failed to update experience", Data: nil})
+// HandlerToSetResponsibilities godoc
+// @Tags experience
+// @Summary Replace responsibilities of experience
+// @Description Replaces all responsibilities of an experience, in the order they are sent
+// @ID set-experience-responsibilities
+// @Security ApiAuthKey
+// @Accept  json
+// @Produce  json
+// @Param id path int true "id"
+// @Param ResponsibilitiesRequest body ResponsibilitiesRequest true "ResponsibilitiesRequest"
+// @Success 200 {object} utils.ResponseMessage
+// @Failure 400 {object} utils.ResponseMessage
+// @Failure 403 {object} utils.ResponseMessage
+// @Failure 404 {object} utils.ResponseMessage
+// @Failure 500 {object} utils.ResponseMessage
+// @Router /experience/{id}/responsibilities [put]
+func HandlerToSetResponsibilities(experienceSvc ExperienceService, c *gin.Context) {
+	expId, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
+	var responsibilitiesRequest ResponsibilitiesRequest
+	if err := c.ShouldBindJSON(&responsibilitiesRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.InvalidJsonBody, err), err))
+		return
+	}
+	if err := validate.Struct(&responsibilitiesRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.RequestSchemaInvalid, err), err))
 		return
 	}
+	responsibilities, err := experienceSvc.SetResponsibilities(expId, responsibilitiesRequest.Responsibilities)
+	respondWithResponsibilities(c, responsibilities, err)
+}
 
-	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: "Experience updated successfully", Data: nil})
+// HandlerToAddResponsibility godoc
+// @Tags experience
+// @Summary Add responsibility to experience
+// @Description Inserts a responsibility at the zero based position, without a position it is appended
+// @ID add-experience-responsibility
+// @Security ApiAuthKey
+// @Accept  json
+// @Produce  json
+// @Param id path int true "id"
+// @Param ResponsibilityRequest body ResponsibilityRequest true "ResponsibilityRequest"
+// @Success 200 {object} utils.ResponseMessage
+// @Failure 400 {object} utils.ResponseMessage
+// @Failure 403 {object} utils.ResponseMessage
+// @Failure 404 {object} utils.ResponseMessage
+// @Failure 500 {object} utils.ResponseMessage
+// @Router /experience/{id}/responsibilities [post]
+func HandlerToAddResponsibility(experienceSvc ExperienceService, c *gin.Context) {
+	expId, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
+	var responsibilityRequest ResponsibilityRequest
+	if err := c.ShouldBindJSON(&responsibilityRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.InvalidJsonBody, err), err))
+		return
+	}
+	if err := validate.Struct(&responsibilityRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.RequestSchemaInvalid, err), err))
+		return
+	}
+	responsibilities, err := experienceSvc.AddResponsibility(expId, responsibilityRequest.Position, responsibilityRequest.Responsibility)
+	respondWithResponsibilities(c, responsibilities, err)
+}
+
+// HandlerToUpdateResponsibility godoc
+// @Tags experience
+// @Summary Update responsibility of experience
+// @Description Replaces the text of the responsibility at the zero based index
+// @ID update-experience-responsibility
+// @Security ApiAuthKey
+// @Accept  json
+// @Produce  json
+// @Param id path int true "id"
+// @Param index path int true "index"
+// @Param ResponsibilityRequest body ResponsibilityRequest true "ResponsibilityRequest, the position is ignored"
+// @Success 200 {object} utils.ResponseMessage
+// @Failure 400 {object} utils.ResponseMessage
+// @Failure 403 {object} utils.ResponseMessage
+// @Failure 404 {object} utils.ResponseMessage
+// @Failure 500 {object} utils.ResponseMessage
+// @Router /experience/{id}/responsibilities/{index} [patch]
+func HandlerToUpdateResponsibility(experienceSvc ExperienceService, c *gin.Context) {
+	expId, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
+	index, err := strconv.Atoi(c.Param("index"))
+	if err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.InvalidIntegerValueMessage, "index", err), err))
+		return
+	}
+	var responsibilityRequest ResponsibilityRequest
+	if err := c.ShouldBindJSON(&responsibilityRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.InvalidJsonBody, err), err))
+		return
+	}
+	if err := validate.Struct(&responsibilityRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.RequestSchemaInvalid, err), err))
+		return
+	}
+	responsibilities, err := experienceSvc.UpdateResponsibility(expId, index, responsibilityRequest.Responsibility)
+	respondWithResponsibilities(c, responsibilities, err)
+}
+
+// HandlerToDeleteResponsibility godoc
+// @Tags experience
+// @Summary Delete responsibility of experience
+// @Description Deletes the responsibility at the zero based index, the ones after it move up
+// @ID delete-experience-responsibility
+// @Security ApiAuthKey
+// @Produce  json
+// @Param id path int true "id"
+// @Param index path int true "index"
+// @Success 200 {object} utils.ResponseMessage
+// @Failure 400 {object} utils.ResponseMessage
+// @Failure 403 {object} utils.ResponseMessage
+// @Failure 404 {object} utils.ResponseMessage
+// @Failure 500 {object} utils.ResponseMessage
+// @Router /experience/{id}/responsibilities/{index} [delete]
+func HandlerToDeleteResponsibility(experienceSvc ExperienceService, c *gin.Context) {
+	expId, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
+	index, err := strconv.Atoi(c.Param("index"))
+	if err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.InvalidIntegerValueMessage, "index", err), err))
+		return
+	}
+	responsibilities, err := experienceSvc.DeleteResponsibility(expId, index)
+	respondWithResponsibilities(c, responsibilities, err)
+}
+
+// HandlerToReorderResponsibilities godoc
+// @Tags experience
+// @Summary Reorder responsibilities of experience
+// @Description Orders the responsibilities by their current zero based indexes, [2,0,1] moves the third one first. Every index has to be listed once
+// @ID reorder-experience-responsibilities
+// @Security ApiAuthKey
+// @Accept  json
+// @Produce  json
+// @Param id path int true "id"
+// @Param ResponsibilityOrderRequest body ResponsibilityOrderRequest true "ResponsibilityOrderRequest"
+// @Success 200 {object} utils.ResponseMessage
+// @Failure 400 {object} utils.ResponseMessage
+// @Failure 403 {object} utils.ResponseMessage
+// @Failure 404 {object} utils.ResponseMessage
+// @Failure 500 {object} utils.ResponseMessage
+// @Router /experience/{id}/responsibilities/order [put]
+func HandlerToReorderResponsibilities(experienceSvc ExperienceService, c *gin.Context) {
+	expId, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
+	var orderRequest ResponsibilityOrderRequest
+	if err := c.ShouldBindJSON(&orderRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.InvalidJsonBody, err), err))
+		return
+	}
+	if err := validate.Struct(&orderRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.RequestSchemaInvalid, err), err))
+		return
+	}
+	responsibilities, err := experienceSvc.ReorderResponsibilities(expId, orderRequest.Order)
+	respondWithResponsibilities(c, responsibilities, err)
+}
+
+// respondWithResponsibilities answers the changes of the responsibilities with all of them in their new order
+func respondWithResponsibilities(c *gin.Context, responsibilities Responsibilities, err error) {
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound), errors.Is(err, ErrResponsibilityNotFound):
+			statusCode = http.StatusNotFound
+		case errors.Is(err, ErrInvalidOrder), errors.Is(err, ErrTooManyResponsibilities):
+			statusCode = http.StatusBadRequest
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileUpdatingResponsibilities, err), err))
+		return
+	}
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.SuccessfullyUpdatedResponsibilities, Data: responsibilities})
 }
 
 // GetUserExperienceByIdHandler godoc
@@ -176,6 +534,7 @@ func UpdateUserExperienceByIdHandler(experienceSvc ExperienceService, c *gin.Con
 // @Summary Get user experience details by id
 // @Description get user experience details by id
 // @ID get-user-experience-details-by-id
+// @Security ApiAuthKey
 // @Accept  json
 // @Produce  json
 // @Param id path uint true "id"
@@ -186,14 +545,18 @@ func UpdateUserExperienceByIdHandler(experienceSvc ExperienceService, c *gin.Con
 // @Failure 500 {object} string
 // @Router /experience/{id} [get]
 func GetUserExperienceByIdHandler(experienceSvc ExperienceService, c *gin.Context) {
-	expId := c.Param("id")
-	expIdInt, _ := strconv.Atoi(expId)
-	userId := c.Request.URL.Query().Get("userId")
-	userIdInt, _ := strconv.Atoi(userId)
+	expId, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
+	userId, ok := utils.BindQueryID(c, "userId")
+	if !ok {
+		return
+	}
 
-	expDetails, err := experienceSvc.GetAllUserExperienceList(uint(expIdInt), uint(userIdInt))
+	expDetails, err := experienceSvc.GetAllUserExperienceList(expId, userId)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, utils.ResponseMessage{StatusCode: http.StatusInternalServerError, Message: fmt.Sprintf("Cannot fetch user experience against provided ID:", err), Data: nil})
+		utils.AbortWithError(c, utils.NewError(http.StatusInternalServerError, fmt.Sprintf("Cannot fetch user experience against provided ID: %v", err), err))
 		return
 	}
 
@@ -203,23 +566,31 @@ func GetUserExperienceByIdHandler(experienceSvc ExperienceService, c *gin.Contex
 // DeleteUserExperienceByIdHandler godoc
 // @Tags experience
 // @Summary Delete user experience by id
-// @Description delete user experience by id
+// @Description delete user experience by id, users can only delete experiences linked to them
 // @ID delete-user-experience-by-id
+// @Security ApiAuthKey
 // @Accept  json
 // @Produce  json
 // @Param id path int true "id"
 // @Success 200 {object} string
 // @Failure 400 {object} string
+// @Failure 403 {object} string
 // @Failure 404 {object} string
 // @Failure 500 {object} string
 // @Router /experience/{id} [delete]
 func DeleteUserExperienceByIdHandler(experienceSvc ExperienceService, c *gin.Context) {
-	expId := c.Param("id")
-	expIdInt, _ := strconv.Atoi(expId)
+	expId, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
 
-	err := experienceSvc.DeleteUserExperienceByID(uint(expIdInt))
+	err := experienceSvc.DeleteUserExperienceByID(expId)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, utils.ResponseMessage{StatusCode: http.StatusInternalServerError, Message: fmt.Sprintf("Unable to Delete user experience against provided id:", err), Data: nil})
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf("Unable to Delete user experience against provided id: %v", err), err))
 		return
 	}
 
@@ -232,6 +603,7 @@ func DeleteUserExperienceByIdHandler(experienceSvc ExperienceService, c *gin.Con
 // @Summary Delete user experience by user id
 // @Description delete user experience by user id
 // @ID delete-user-experience-by-user-id
+// @Security ApiAuthKey
 // @Accept  json
 // @Produce  json
 // @Param id path int true "id"
@@ -241,12 +613,14 @@ func DeleteUserExperienceByIdHandler(experienceSvc ExperienceService, c *gin.Con
 // @Failure 500 {object} string
 // @Router /experience/user/{id} [delete]
 func DeleteUserExperienceByUserIdHandler(experienceSvc ExperienceService, c *gin.Context) {
-	userId := c.Param("id")
-	userIdInt, _ := strconv.Atoi(userId)
-	fmt.Println("userid", userIdInt)
-	err := experienceSvc.DeleteUserExperienceByUserID(uint(userIdInt))
+	userId, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
+	fmt.Println("userid", userId)
+	err := experienceSvc.DeleteUserExperienceByUserID(userId)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, utils.ResponseMessage{StatusCode: http.StatusInternalServerError, Message: fmt.Sprintf("Unable to Delete user experience against provided id:", err), Data: nil})
+		utils.AbortWithError(c, utils.NewError(http.StatusInternalServerError, fmt.Sprintf("Unable to Delete user experience against provided id: %v", err), err))
 		return
 	}
 
@@ -258,6 +632,7 @@ func DeleteUserExperienceByUserIdHandler(experienceSvc ExperienceService, c *gin
 // @Summary Get all user experience
 // @Description Get all user experience
 // @ID Get-all-user-experience
+// @Security ApiAuthKey
 // @Accept  json
 // @Produce  json
 // @Param   limit    query     int     false  "example - 50"     limit(int)
@@ -270,35 +645,18 @@ func DeleteUserExperienceByUserIdHandler(experienceSvc ExperienceService, c *gin
 // @Failure 500 {object} string
 // @Router /experience/user/{id} [get]
 func HandlerToGetAllUserExperience(expSvc ExperienceService, c *gin.Context) {
-	baseQuery := c.Request.URL.Query()
-	limit := baseQuery.Get("limit")
-	offset := baseQuery.Get("offset")
-	orderBy := baseQuery.Get("orderBy")
-	userId := c.Param("id")
-	userIdInt, _ := strconv.Atoi(userId)
-	if limit == "" {
-		limit = utils.DefaultLimit
-	}
-	if offset == "" {
-		offset = utils.DefaultOffset
-	}
-	if orderBy == "" {
-		orderBy = utils.DefaultOrderBy
-	}
-
-	limitInt, err := strconv.Atoi(limit)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, utils.ResponseMessage{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf(utils.InvalidIntegerValueLimitMessage, err), Data: nil})
+	userId, ok := utils.BindID(c, "id")
+	if !ok {
 		return
 	}
-	offsetInt, err := strconv.Atoi(offset)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, utils.ResponseMessage{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf(utils.InvalidIntegerValueOffsetMessage, err), Data: nil})
+
+	page, ok := utils.BindPage(c, utils.DefaultOrderBy, experienceSortColumns...)
+	if !ok {
 		return
 	}
-	expList, totalRecords, err := expSvc.GetAllUserExperience(uint(userIdInt), limitInt, offsetInt, orderBy)
+	expList, totalRecords, err := expSvc.GetAllUserExperience(userId, page.Limit, page.Offset, page.OrderBy)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, utils.ResponseMessage{StatusCode: http.StatusInternalServerError, Message: fmt.Sprintf(utils.SomethingWentWrongWhileGettingExperience, err), Data: nil})
+		utils.AbortWithError(c, utils.NewError(http.StatusInternalServerError, fmt.Sprintf(utils.SomethingWentWrongWhileGettingExperience, err), err))
 		return
 	}
 	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.Success, Data: utils.RecordsResponse{Total: int64(totalRecords), RecordsFiltered: len(expList), Data: expList}})
@@ -0,0 +1,60 @@
+package experience
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/Octek/resource-profile-management-backend.git/utils"
+	"github.com/Octek/resource-profile-management-backend.git/utils/apitest"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type userExperienceKey struct {
+	experienceID uint
+	userID       uint
+}
+
+// fakeExperienceRepository keeps the experiences of users in memory
+type fakeExperienceRepository struct {
+	ExperienceRepository
+	userExperiences map[userExperienceKey]Experience
+}
+
+func (repo *fakeExperienceRepository) GetAllUserExperienceList(expID, userID uint) (Experience, error) {
+	if experienceObj, found := repo.userExperiences[userExperienceKey{experienceID: expID, userID: userID}]; found {
+		return experienceObj, nil
+	}
+	return Experience{}, gorm.ErrRecordNotFound
+}
+
+func TestGetUserExperienceByIdHandler(t *testing.T) {
+	repo := &fakeExperienceRepository{userExperiences: map[userExperienceKey]Experience{
+		{experienceID: 3, userID: 1}: {ID: 3, Company: "Octek"},
+	}}
+	experienceSvc := NewService(repo)
+	router := apitest.NewRouter()
+	router.GET("/experience/:id", func(c *gin.Context) {
+		GetUserExperienceByIdHandler(experienceSvc, c)
+	})
+
+	tests := []struct {
+		path       string
+		statusCode int
+		code       string
+	}{
+		{path: "/experience/3?userId=1", statusCode: http.StatusOK},
+		{path: "/experience/4?userId=1", statusCode: http.StatusNotFound, code: utils.ErrorCodeNotFound},
+		{path: "/experience/3?userId=2", statusCode: http.StatusNotFound, code: utils.ErrorCodeNotFound},
+		{path: "/experience/abc?userId=1", statusCode: http.StatusBadRequest, code: utils.ErrorCodeBadRequest},
+		{path: "/experience/0?userId=1", statusCode: http.StatusBadRequest, code: utils.ErrorCodeBadRequest},
+		{path: "/experience/3", statusCode: http.StatusBadRequest, code: utils.ErrorCodeBadRequest},
+		{path: "/experience/3?userId=abc", statusCode: http.StatusBadRequest, code: utils.ErrorCodeBadRequest},
+	}
+	for _, test := range tests {
+		statusCode, response := apitest.Serve(t, router, http.MethodGet, test.path, "")
+		if statusCode != test.statusCode || response.StatusCode != test.statusCode || response.Code != test.code {
+			t.Errorf("GET %s = %d with code %q, want %d with code %q", test.path, statusCode, response.Code, test.statusCode, test.code)
+		}
+	}
+}
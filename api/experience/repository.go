@@ -1,8 +1,10 @@
 package experience
 
+import "github.com/Octek/resource-profile-management-backend.git/api/skills"
+
 // ExperienceRepository Used to store and retrieve user experince
 type ExperienceRepository interface {
-	AddExperienceWithUserAndSkills(userID, skillId uint, experience *Experience) (*Experience, error)
+	AddExperienceWithUserAndSkills(userID uint, skillIDs []uint, experience *Experience) (*Experience, error)
 	GetExperienceById(id uint) (*Experience, error)
 	GetUserExperienceByUserIdAndExperienceId(userId, experienceId uint) (*UserExperience, error)
 	UpdateExperience(experience *Experience) error
@@ -10,5 +12,8 @@ type ExperienceRepository interface {
 	DeleteUserExperienceByID(id uint) error
 	DeleteUserExperienceByUserID(id uint) error
 	GetAllUserExperience(userId uint, limit int, offset int, orderBy string) ([]Experience, uint, error)
+	addSkillsToExperience(experienceID uint, skillIDs []uint) ([]skills.Skill, error)
+	removeSkillFromExperience(experienceID, skillID uint) error
+	updateResponsibilities(experienceID uint, change func(Responsibilities) (Responsibilities, error)) (Responsibilities, error)
 	//createCategories(jsonData []Category) error
 }
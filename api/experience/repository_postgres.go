@@ -2,8 +2,10 @@ package experience
 
 import (
 	"fmt"
+	"github.com/Octek/resource-profile-management-backend.git/api/skills"
 	log "github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type experienceRepositoryPostgres struct {
@@ -11,10 +13,6 @@ type experienceRepositoryPostgres struct {
 }
 
 func NewExperienceRepositoryPostgres(db *gorm.DB) ExperienceRepository {
-	err := db.AutoMigrate(&UserExperience{}, &ExperienceSkill{}, &Experience{})
-	if err != nil {
-		log.Fatal(err)
-	}
 	log.Print("Successfully connected to postgres in experience service!")
 
 	return &experienceRepositoryPostgres{
@@ -22,14 +20,79 @@ func NewExperienceRepositoryPostgres(db *gorm.DB) ExperienceRepository {
 	}
 }
 
-func (repo *experienceRepositoryPostgres) AddExperienceWithUserAndSkills(userID, skillId uint, experience *Experience) (*Experience, error) {
+// MigrateExperienceSkills removes the links to skills that never existed, experiences created without a
+// skill used to be linked to skill 0, and the duplicate links before making the links unique
+func MigrateExperienceSkills(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`DELETE FROM experience_skills
+			WHERE NOT EXISTS (SELECT 1 FROM skills WHERE skills.id = experience_skills.skill_id)`).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(`DELETE FROM experience_skills WHERE ctid NOT IN (
+			SELECT DISTINCT ON (experience_id, skill_id) ctid FROM experience_skills ORDER BY experience_id, skill_id, id)`).Error; err != nil {
+			return err
+		}
+		return tx.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_experience_skills_experience_skill ON experience_skills (experience_id, skill_id)").Error
+	})
+}
+
+// checkSkillsExist fails with ErrUnknownSkill naming the skills that are not in the catalogue
+func checkSkillsExist(tx *gorm.DB, skillIDs []uint) error {
+	if len(skillIDs) == 0 {
+		return nil
+	}
+	var existingIDs []uint
+	if err := tx.Model(&skills.Skill{}).Where("id IN ?", skillIDs).Pluck("id", &existingIDs).Error; err != nil {
+		return err
+	}
+	existing := make(map[uint]bool, len(existingIDs))
+	for _, id := range existingIDs {
+		existing[id] = true
+	}
+	var missing []uint
+	for _, id := range skillIDs {
+		if !existing[id] {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %v", ErrUnknownSkill, missing)
+	}
+	return nil
+}
+
+// linkSkills links the skills to the experience, skills that are already linked are skipped
+func linkSkills(tx *gorm.DB, experienceID uint, skillIDs []uint) error {
+	if len(skillIDs) == 0 {
+		return nil
+	}
+	experienceSkills := make([]ExperienceSkill, 0, len(skillIDs))
+	for _, skillID := range skillIDs {
+		experienceSkills = append(experienceSkills, ExperienceSkill{SkillID: skillID, ExperienceID: experienceID})
+	}
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "experience_id"}, {Name: "skill_id"}},
+		DoNothing: true,
+	}).Create(&experienceSkills).Error
+}
+
+func findExperienceSkills(tx *gorm.DB, experienceID uint, experienceSkills *[]skills.Skill) error {
+	return tx.Joins("JOIN experience_skills ON experience_skills.skill_id = skills.id").
+		Where("experience_skills.experience_id = ?", experienceID).
+		Preload("SkillCategory").Order("skills.name asc").
+		Find(experienceSkills).Error
+}
+
+func (repo *experienceRepositoryPostgres) AddExperienceWithUserAndSkills(userID uint, skillIDs []uint, experience *Experience) (*Experience, error) {
 
 	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := checkSkillsExist(tx, skillIDs); err != nil {
+			return err
+		}
 		if err := tx.Create(&experience).Error; err != nil {
 			return err
 		}
 
-		experience.ParseResponsibilities()
 		userExperience := UserExperience{
 			UserID:       userID,
 			ExperienceID: experience.ID,
@@ -38,20 +101,50 @@ func (repo *experienceRepositoryPostgres) AddExperienceWithUserAndSkills(userID,
 			return err
 		}
 
-		experienceSkill := ExperienceSkill{
-			SkillID:      skillId,
-			ExperienceID: experience.ID,
-		}
-		if err := tx.Create(&experienceSkill).Error; err != nil {
+		if err := linkSkills(tx, experience.ID, skillIDs); err != nil {
 			return err
 		}
 		fmt.Println("Experience, UserExperience, and ExperienceSkill have been created successfully.")
-		return nil
+		return findExperienceSkills(tx, experience.ID, &experience.Skills)
 	})
 
 	return experience, err
 }
 
+// addSkillsToExperience links the skills to the experience and returns all skills of the experience
+func (repo *experienceRepositoryPostgres) addSkillsToExperience(experienceID uint, skillIDs []uint) ([]skills.Skill, error) {
+	var experienceSkills []skills.Skill
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&Experience{}, experienceID).Error; err != nil {
+			return err
+		}
+		if err := checkSkillsExist(tx, skillIDs); err != nil {
+			return err
+		}
+		if err := linkSkills(tx, experienceID, skillIDs); err != nil {
+			return err
+		}
+		return findExperienceSkills(tx, experienceID, &experienceSkills)
+	})
+	return experienceSkills, err
+}
+
+func (repo *experienceRepositoryPostgres) removeSkillFromExperience(experienceID, skillID uint) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&Experience{}, experienceID).Error; err != nil {
+			return err
+		}
+		result := tx.Where("experience_id = ? AND skill_id = ?", experienceID, skillID).Delete(&ExperienceSkill{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("skill %d is not linked to experience %d: %w", skillID, experienceID, gorm.ErrRecordNotFound)
+		}
+		return nil
+	})
+}
+
 func (repo *experienceRepositoryPostgres) GetExperienceById(id uint) (*Experience, error) {
 	var experience Experience
 	if err := repo.db.First(&experience, id).Error; err != nil {
@@ -83,8 +176,6 @@ func (repo *experienceRepositoryPostgres) GetAllUserExperienceList(expID, userID
 		First(&experience).
 		Error
 
-	experience.ParseResponsibilities()
-
 	return experience, err
 }
 
@@ -97,11 +188,13 @@ func (repo *experienceRepositoryPostgres) DeleteUserExperienceByID(id uint) erro
 			return err
 		}
 		result := tx.Delete(&Experience{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
 		if result.RowsAffected == 0 {
-			return fmt.Errorf("no experience record found for id: %d", id)
+			return fmt.Errorf("no experience record found for id %d: %w", id, gorm.ErrRecordNotFound)
 		}
-
-		return result.Error
+		return nil
 	})
 }
 
@@ -141,13 +234,34 @@ func (repo *experienceRepositoryPostgres) GetAllUserExperience(userId uint, limi
 
 		query := tx.Model(&Experience{}).Where("deleted_at IS NULL").Where("id IN (?)", experienceIDs)
 		err := query.Count(&total).Error
-		err = query.Order(orderBy).Limit(limit).Offset(offset).Find(&exp).Error
-		for i := range exp {
-			exp[i].ParseResponsibilities()
-		}
+		err = query.Order(orderBy).Limit(limit).Offset(offset).
+			Preload("Skills").Preload("Skills.SkillCategory").
+			Find(&exp).Error
 
 		return err
 	})
 
 	return exp, uint(total), err
 }
+
+// updateResponsibilities applies change to the responsibilities of the experience, the experience is
+// locked meanwhile so concurrent changes are not lost
+func (repo *experienceRepositoryPostgres) updateResponsibilities(experienceID uint, change func(Responsibilities) (Responsibilities, error)) (Responsibilities, error) {
+	var responsibilities Responsibilities
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		var experience Experience
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&experience, experienceID).Error; err != nil {
+			return err
+		}
+		updated, err := change(experience.Responsibilities)
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(&experience).Update("responsibilities", updated).Error; err != nil {
+			return err
+		}
+		responsibilities = updated
+		return nil
+	})
+	return responsibilities, err
+}
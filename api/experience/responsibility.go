@@ -0,0 +1,152 @@
+package experience
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+const (
+	MaxResponsibilities      = 50
+	responsibilitiesDataType = "jsonb"
+)
+
+var (
+	ErrResponsibilityNotFound  = errors.New("responsibility does not exist")
+	ErrInvalidOrder            = errors.New("the order has to list every responsibility exactly once")
+	ErrTooManyResponsibilities = fmt.Errorf("an experience has at most %d responsibilities", MaxResponsibilities)
+)
+
+// Responsibilities are the ordered responsibilities of an experience, stored as a JSON array of strings
+type Responsibilities []string
+
+func (responsibilities Responsibilities) Value() (driver.Value, error) {
+	if responsibilities == nil {
+		return "[]", nil
+	}
+	value, err := json.Marshal(responsibilities)
+	return string(value), err
+}
+
+func (responsibilities *Responsibilities) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*responsibilities = Responsibilities{}
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into responsibilities", value)
+	}
+	parsed := Responsibilities{}
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return err
+	}
+	*responsibilities = parsed
+	return nil
+}
+
+// GormDataType keeps the column a jsonb array when the table is migrated
+func (Responsibilities) GormDataType() string {
+	return responsibilitiesDataType
+}
+
+// NewResponsibilities trims the responsibilities and drops the empty ones
+func NewResponsibilities(items []string) Responsibilities {
+	responsibilities := make(Responsibilities, 0, len(items))
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			responsibilities = append(responsibilities, item)
+		}
+	}
+	return responsibilities
+}
+
+// insert puts the responsibility at position, a position past the end appends it
+func (responsibilities Responsibilities) insert(position int, responsibility string) (Responsibilities, error) {
+	if len(responsibilities) >= MaxResponsibilities {
+		return nil, ErrTooManyResponsibilities
+	}
+	if position < 0 || position > len(responsibilities) {
+		position = len(responsibilities)
+	}
+	updated := make(Responsibilities, 0, len(responsibilities)+1)
+	updated = append(updated, responsibilities[:position]...)
+	updated = append(updated, responsibility)
+	return append(updated, responsibilities[position:]...), nil
+}
+
+func (responsibilities Responsibilities) replace(index int, responsibility string) (Responsibilities, error) {
+	if index < 0 || index >= len(responsibilities) {
+		return nil, ErrResponsibilityNotFound
+	}
+	updated := append(Responsibilities{}, responsibilities...)
+	updated[index] = responsibility
+	return updated, nil
+}
+
+func (responsibilities Responsibilities) remove(index int) (Responsibilities, error) {
+	if index < 0 || index >= len(responsibilities) {
+		return nil, ErrResponsibilityNotFound
+	}
+	updated := make(Responsibilities, 0, len(responsibilities)-1)
+	updated = append(updated, responsibilities[:index]...)
+	return append(updated, responsibilities[index+1:]...), nil
+}
+
+// reorder puts the responsibilities in the order of their current indexes in order
+func (responsibilities Responsibilities) reorder(order []int) (Responsibilities, error) {
+	if len(order) != len(responsibilities) {
+		return nil, ErrInvalidOrder
+	}
+	seen := make([]bool, len(responsibilities))
+	updated := make(Responsibilities, 0, len(responsibilities))
+	for _, index := range order {
+		if index < 0 || index >= len(responsibilities) || seen[index] {
+			return nil, ErrInvalidOrder
+		}
+		seen[index] = true
+		updated = append(updated, responsibilities[index])
+	}
+	return updated, nil
+}
+
+// MigrateResponsibilities adds the responsibilities as a JSON array, or turns the pipe-joined responsibilities
+// into one. The search_vector generated from the old column is dropped with it, MigrateSearchVectors adds it back.
+func MigrateResponsibilities(db *gorm.DB) error {
+	var dataType string
+	err := db.Raw(`SELECT data_type FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name = 'experiences' AND column_name = 'responsibilities'`).
+		Scan(&dataType).Error
+	if err != nil || dataType == responsibilitiesDataType {
+		return err
+	}
+	if dataType == "" {
+		return db.Exec(`ALTER TABLE experiences ADD COLUMN responsibilities jsonb NOT NULL DEFAULT '[]'`).Error
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		statements := []string{
+			`ALTER TABLE experiences DROP COLUMN IF EXISTS search_vector`,
+			`ALTER TABLE experiences ADD COLUMN responsibility_list jsonb NOT NULL DEFAULT '[]'`,
+			`UPDATE experiences SET responsibility_list = (
+				SELECT coalesce(jsonb_agg(btrim(item) ORDER BY position), '[]') FROM unnest(string_to_array(experiences.responsibilities, '|'))
+				WITH ORDINALITY AS responsibility(item, position) WHERE btrim(item) <> '')
+				WHERE coalesce(experiences.responsibilities, '') <> ''`,
+			`ALTER TABLE experiences DROP COLUMN responsibilities`,
+			`ALTER TABLE experiences RENAME COLUMN responsibility_list TO responsibilities`,
+		}
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -1,5 +1,14 @@
 package experience
 
+import (
+	"errors"
+	"github.com/Octek/resource-profile-management-backend.git/api/auth"
+	"github.com/Octek/resource-profile-management-backend.git/api/skills"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"strings"
+)
+
 type ExperienceService struct {
 	experienceRepository ExperienceRepository
 }
@@ -12,8 +21,76 @@ func NewService(r ExperienceRepository) ExperienceService {
 //	return svc.userRepository.createCategories(jsonData)
 //}
 
-func (svc *ExperienceService) AddExperienceWithUserAndSkills(userID, skillId uint, experience *Experience) (*Experience, error) {
-	return svc.experienceRepository.AddExperienceWithUserAndSkills(userID, skillId, experience)
+// AddExperienceWithUserAndSkills creates the experience of the user linked to the skills, every skill has to exist
+func (svc *ExperienceService) AddExperienceWithUserAndSkills(userID uint, skillIDs []uint, experience *Experience) (*Experience, error) {
+	return svc.experienceRepository.AddExperienceWithUserAndSkills(userID, uniqueSkillIDs(skillIDs), experience)
+}
+
+// AddSkillsToExperience links more skills to an experience and returns all its skills
+func (svc *ExperienceService) AddSkillsToExperience(experienceID uint, skillIDs []uint) ([]skills.Skill, error) {
+	return svc.experienceRepository.addSkillsToExperience(experienceID, uniqueSkillIDs(skillIDs))
+}
+
+func (svc *ExperienceService) RemoveSkillFromExperience(experienceID, skillID uint) error {
+	return svc.experienceRepository.removeSkillFromExperience(experienceID, skillID)
+}
+
+func (svc *ExperienceService) GetResponsibilities(experienceID uint) (Responsibilities, error) {
+	experience, err := svc.experienceRepository.GetExperienceById(experienceID)
+	if err != nil {
+		return nil, err
+	}
+	return experience.Responsibilities, nil
+}
+
+// SetResponsibilities replaces all responsibilities of the experience, empty ones are dropped
+func (svc *ExperienceService) SetResponsibilities(experienceID uint, items []string) (Responsibilities, error) {
+	return svc.experienceRepository.updateResponsibilities(experienceID, func(Responsibilities) (Responsibilities, error) {
+		return NewResponsibilities(items), nil
+	})
+}
+
+// AddResponsibility inserts a responsibility at position, without a position it is appended
+func (svc *ExperienceService) AddResponsibility(experienceID uint, position *int, responsibility string) (Responsibilities, error) {
+	return svc.experienceRepository.updateResponsibilities(experienceID, func(responsibilities Responsibilities) (Responsibilities, error) {
+		if position == nil {
+			return responsibilities.insert(len(responsibilities), strings.TrimSpace(responsibility))
+		}
+		return responsibilities.insert(*position, strings.TrimSpace(responsibility))
+	})
+}
+
+func (svc *ExperienceService) UpdateResponsibility(experienceID uint, index int, responsibility string) (Responsibilities, error) {
+	return svc.experienceRepository.updateResponsibilities(experienceID, func(responsibilities Responsibilities) (Responsibilities, error) {
+		return responsibilities.replace(index, strings.TrimSpace(responsibility))
+	})
+}
+
+func (svc *ExperienceService) DeleteResponsibility(experienceID uint, index int) (Responsibilities, error) {
+	return svc.experienceRepository.updateResponsibilities(experienceID, func(responsibilities Responsibilities) (Responsibilities, error) {
+		return responsibilities.remove(index)
+	})
+}
+
+// ReorderResponsibilities orders the responsibilities by order, which lists all their current indexes
+func (svc *ExperienceService) ReorderResponsibilities(experienceID uint, order []int) (Responsibilities, error) {
+	return svc.experienceRepository.updateResponsibilities(experienceID, func(responsibilities Responsibilities) (Responsibilities, error) {
+		return responsibilities.reorder(order)
+	})
+}
+
+// uniqueSkillIDs drops the repeated and the zero skill ids
+func uniqueSkillIDs(skillIDs []uint) []uint {
+	seen := make(map[uint]bool, len(skillIDs))
+	unique := make([]uint, 0, len(skillIDs))
+	for _, skillID := range skillIDs {
+		if skillID == 0 || seen[skillID] {
+			continue
+		}
+		seen[skillID] = true
+		unique = append(unique, skillID)
+	}
+	return unique
 }
 
 func (svc *ExperienceService) GetExperienceById(id uint) (*Experience, error) {
@@ -42,3 +119,20 @@ func (svc *ExperienceService) DeleteUserExperienceByUserID(id uint) error {
 func (svc *ExperienceService) GetAllUserExperience(userId uint, limit int, offset int, orderBy string) ([]Experience, uint, error) {
 	return svc.experienceRepository.GetAllUserExperience(userId, limit, offset, orderBy)
 }
+
+// isExperienceOwner is the owner check of the routes addressing an experience by its id, the experience
+// must be linked to the user through user_experiences. A missing experience is reported as not found.
+func (svc *ExperienceService) isExperienceOwner(c *gin.Context, userId uint) (bool, error) {
+	experienceId, err := auth.ParamID(c, "id")
+	if err != nil {
+		return false, err
+	}
+	if _, err := svc.experienceRepository.GetExperienceById(experienceId); err != nil {
+		return false, err
+	}
+	_, err = svc.experienceRepository.GetUserExperienceByUserIdAndExperienceId(userId, experienceId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
@@ -0,0 +1,355 @@
+// Package baseline holds the schema of the services as it was when the versioned migrations were introduced.
+// The types are copies of the entities of that time and keep their names, gorm derives the names of the join
+// tables, their columns and the constraints from them. They are never changed, a change of the schema is a
+// new migration.
+package baseline
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Migrate creates the tables in the order the services used to create them when they were started
+func Migrate(tx *gorm.DB) error {
+	steps := [][]interface{}{
+		{&Credential{}},
+		{&UserSkill{}, &SkillCategory{}, &Skill{}, &SkillAlias{}, &SkillEndorsement{}},
+		{&UserExperience{}, &ExperienceSkill{}, &Experience{}},
+		{&Question{}, &QuestionOption{}},
+		{&UserProject{}, &Project{}},
+		{&BookingQuestion{}, &BookingSkill{}, &Booking{}, &UserAvailability{}, &AvailabilityWindow{}, &AvailabilityBlackout{}, &CalendarFeed{}},
+		{&Role{}, &UserRole{}, &UserCategory{}, &User{}, &Education{}},
+		{&RedactionProfile{}, &ShareLink{}, &ShareLinkUser{}, &ShareLinkAccess{}},
+	}
+	for _, models := range steps {
+		if err := tx.AutoMigrate(models...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// auth
+
+type Credential struct {
+	ID           uint   `gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
+	UserID       uint   `gorm:"NOT NULL;uniqueIndex:credential_user_id"`
+	PasswordHash string `gorm:"NOT NULL"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// skills
+
+type UserSkill struct {
+	ID               uint `gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
+	UserID           uint `gorm:"NOT NULL;index:user_id"`
+	SkillID          uint `gorm:"NOT NULL;index:skill_id"`
+	SkillLevel       string
+	Proficiency      int        `gorm:"NOT NULL;default:0"`
+	YearsOfUse       float64    `gorm:"NOT NULL;default:0"`
+	LastUsedOn       *time.Time `gorm:"type:date"`
+	Assessment       string     `gorm:"NOT NULL;default:self"`
+	VerifiedByUserID *uint
+	VerifiedAt       *time.Time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+type SkillCategory struct {
+	ID        uint `gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type Skill struct {
+	ID              uint `gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
+	Name            string
+	NormalizedName  string `gorm:"NOT NULL;default:''"`
+	Icon            string
+	SkillCategoryID uint           `gorm:"NOT NULL;index:skill_category_id"`
+	SkillCategory   *SkillCategory `gorm:"foreignKey:SkillCategoryID;references:ID"`
+	ParentID        *uint          `gorm:"index"`
+	Aliases         []SkillAlias   `gorm:"foreignKey:SkillID"`
+	Bookings        []Booking      `gorm:"many2many:booking_skills;"`
+	DeletedAt       gorm.DeletedAt
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+type SkillAlias struct {
+	ID              uint `gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
+	SkillID         uint `gorm:"NOT NULL;index"`
+	Alias           string
+	NormalizedAlias string `gorm:"NOT NULL;uniqueIndex"`
+	CreatedAt       time.Time
+}
+
+type SkillEndorsement struct {
+	ID             uint `gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
+	UserSkillID    uint `gorm:"NOT NULL;uniqueIndex:idx_skill_endorsements_user_skill_endorser"`
+	EndorserUserID uint `gorm:"NOT NULL;uniqueIndex:idx_skill_endorsements_user_skill_endorser;index"`
+	Comment        string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// experience, the responsibilities column is added by its own migration
+
+type UserExperience struct {
+	ID           uint `gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
+	UserID       uint `gorm:"NOT NULL;index:user_id"`
+	ExperienceID uint `gorm:"NOT NULL;index:skill_id"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type ExperienceSkill struct {
+	ID           uint `gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
+	SkillID      uint `gorm:"NOT NULL;index:user_id"`
+	ExperienceID uint `gorm:"NOT NULL;index:experience_id"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type Experience struct {
+	ID                 uint `gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
+	Position           string
+	Company            string
+	Description        string
+	StartDate          time.Time
+	EndDate            time.Time
+	IsCurrentlyWorking bool
+	Skills             []Skill `gorm:"many2many:experience_skills;"`
+	DeletedAt          gorm.DeletedAt
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+// questions
+
+type Question struct {
+	ID              uint `gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
+	Questions       string
+	QuestionType    string
+	Position        int
+	IsRequired      bool
+	RetiredAt       *time.Time
+	QuestionOptions []QuestionOption `gorm:"foreignKey:QuestionID"`
+	DeletedAt       gorm.DeletedAt
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+type QuestionOption struct {
+	ID         uint   `gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
+	QuestionID uint   `gorm:"NOT NULL;index:question_id"`
+	Name       string `gorm:"NOT NULL"`
+	Position   int
+	DeletedAt  gorm.DeletedAt
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// projects
+
+type UserProject struct {
+	ID        uint `gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
+	UserID    uint `gorm:"NOT NULL;index:user_id"`
+	ProjectID uint `gorm:"NOT NULL;index:project_id"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type Project struct {
+	ID           uint `gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
+	Name         string
+	Description  string
+	Link         string
+	Technologies string
+	DeletedAt    gorm.DeletedAt
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// bookings
+
+type BookingQuestion struct {
+	ID               uint  `gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
+	QuestionID       uint  `gorm:"index:booking_question_id"`
+	QuestionOptionID *uint `gorm:"index:question_option_id"`
+	Answer           string
+	BookingID        uint `gorm:"NOT NULL;index:booking_id"`
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+type BookingSkill struct {
+	ID        uint `gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
+	SkillID   uint `gorm:"NOT NULL;index:skill_id"`
+	BookingID uint `gorm:"NOT NULL;index:booking_id"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type Booking struct {
+	ID              uint `gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
+	UserID          uint `gorm:"NOT NULL;index:user_id"`
+	BookingDateTime time.Time
+	DurationMinutes int
+	MeetingLink     string
+	ClientName      string
+	ClientEmail     string
+	QuestionOptions []QuestionOption  `gorm:"many2many:booking_questions;"`
+	Answers         []BookingQuestion `gorm:"foreignKey:BookingID"`
+	DeletedAt       gorm.DeletedAt
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+type UserAvailability struct {
+	ID            uint   `gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
+	UserID        uint   `gorm:"NOT NULL;uniqueIndex:availability_user_id"`
+	TimeZone      string `gorm:"NOT NULL"`
+	SlotMinutes   int
+	WorkingHours  []AvailabilityWindow   `gorm:"foreignKey:UserAvailabilityID"`
+	BlackoutDates []AvailabilityBlackout `gorm:"foreignKey:UserAvailabilityID"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+type AvailabilityWindow struct {
+	ID                 uint `gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
+	UserAvailabilityID uint `gorm:"NOT NULL;index:availability_window_availability_id"`
+	Weekday            int
+	StartTime          string
+	EndTime            string
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+type AvailabilityBlackout struct {
+	ID                 uint `gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
+	UserAvailabilityID uint `gorm:"NOT NULL;index:availability_blackout_availability_id"`
+	Date               string
+	Reason             string
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+type CalendarFeed struct {
+	ID        uint   `gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
+	UserID    uint   `gorm:"NOT NULL;uniqueIndex:calendar_feed_user_id"`
+	Token     string `gorm:"NOT NULL;uniqueIndex:calendar_feed_token"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// users
+
+type Role struct {
+	ID        uint `gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type UserRole struct {
+	ID        uint `gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
+	UserID    uint `gorm:"NOT NULL;index:user_id"`
+	RoleID    uint `gorm:"NOT NULL;index:role_id"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type UserCategory struct {
+	ID        uint `gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type User struct {
+	ID             uint `gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
+	FirstName      string
+	LastName       string
+	Email          string `gorm:"NOT NULL"`
+	MobileNumber   string
+	Bio            string
+	JobTitle       string
+	Location       string
+	VideoUrl       string
+	Certifications string
+	UserCategoryID uint          `gorm:"NOT NULL;index:user_category_id"`
+	UserCategory   *UserCategory `gorm:"foreignKey:UserCategoryID;references:ID"`
+	Educations     []Education   `gorm:"foreignKey:UserID"`
+	Bookings       []Booking     `gorm:"foreignKey:UserID"`
+	Roles          []Role        `gorm:"many2many:user_roles;"`
+	Skills         []Skill       `gorm:"many2many:user_skills;"`
+	Experiences    []Experience  `gorm:"many2many:user_experiences;"`
+	Projects       []Project     `gorm:"many2many:user_projects;"`
+	DeletedAt      gorm.DeletedAt
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+type Education struct {
+	ID              uint `gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
+	UserID          uint `gorm:"NOT NULL;index"`
+	InstitutionName string
+	Degree          string
+	FieldOfStudy    string
+	Achievements    string
+	StartDate       time.Time
+	EndDate         time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// sharing
+
+type RedactionProfile struct {
+	ID               uint   `gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
+	Name             string `gorm:"NOT NULL;uniqueIndex:redaction_profile_name"`
+	HideLastName     bool
+	HideEmail        bool
+	HideMobileNumber bool
+	HideCompany      bool
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+type ShareLink struct {
+	ID                 uint   `gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
+	Token              string `gorm:"NOT NULL;uniqueIndex:share_link_token"`
+	Label              string
+	RedactionProfileID uint              `gorm:"NOT NULL;index:redaction_profile_id"`
+	RedactionProfile   *RedactionProfile `gorm:"foreignKey:RedactionProfileID;references:ID"`
+	CreatedByUserID    uint              `gorm:"NOT NULL;index:created_by_user_id"`
+	ExpiresAt          time.Time         `gorm:"NOT NULL"`
+	RevokedAt          *time.Time
+	Users              []ShareLinkUser `gorm:"foreignKey:ShareLinkID"`
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+type ShareLinkUser struct {
+	ID          uint `gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
+	ShareLinkID uint `gorm:"NOT NULL;index:share_link_id"`
+	UserID      uint `gorm:"NOT NULL;index:user_id"`
+	Position    int
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type ShareLinkAccess struct {
+	ID          uint `gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
+	ShareLinkID uint `gorm:"NOT NULL;index:share_link_id"`
+	Granted     bool
+	IPAddress   string
+	UserAgent   string
+	Referer     string
+	CreatedAt   time.Time
+}
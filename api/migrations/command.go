@@ -0,0 +1,80 @@
+package migrations
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	CommandUp     = "up"
+	CommandDown   = "down"
+	CommandStatus = "status"
+)
+
+// RunCommand runs the migrate subcommand of the binary:
+//
+//	migrate [up [version]]   applies the pending migrations, up to version when given
+//	migrate down [steps]     reverts the last steps migrations, one by default
+//	migrate status           lists the migrations and when they were applied
+func RunCommand(db *gorm.DB, args []string, out io.Writer) error {
+	command := CommandUp
+	if len(args) > 0 {
+		command = args[0]
+		args = args[1:]
+	}
+	if len(args) > 1 {
+		return fmt.Errorf("too many arguments for migrate %s: %v", command, args)
+	}
+	number := 0
+	if len(args) == 1 {
+		var err error
+		if number, err = strconv.Atoi(args[0]); err != nil || number < 1 {
+			return fmt.Errorf("migrate %s expects a positive number, got %q", command, args[0])
+		}
+	}
+
+	switch command {
+	case CommandUp:
+		applied, err := Up(db, uint(number))
+		for _, migration := range applied {
+			fmt.Fprintf(out, "applied %d %s\n", migration.Version, migration.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			fmt.Fprintln(out, "the schema is up to date")
+		}
+		return nil
+	case CommandDown:
+		if number == 0 {
+			number = 1
+		}
+		reverted, err := Down(db, number)
+		for _, migration := range reverted {
+			fmt.Fprintf(out, "reverted %d %s\n", migration.Version, migration.Name)
+		}
+		return err
+	case CommandStatus:
+		statuses, err := Status(db)
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			state := "pending"
+			if status.AppliedAt != nil {
+				state = "applied " + status.AppliedAt.Format(time.RFC3339)
+			}
+			if !status.Known {
+				state += " (unknown to this version of the service)"
+			}
+			fmt.Fprintf(out, "%d\t%s\t%s\n", status.Version, status.Name, state)
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown migrate command %q, expected %s, %s or %s", command, CommandUp, CommandDown, CommandStatus)
+}
@@ -0,0 +1,209 @@
+package migrations
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// migrationLockKey serializes the migrations of concurrent migrate commands, the number is arbitrary
+const migrationLockKey = 72093341
+
+var (
+	ErrPendingMigrations     = errors.New("the database schema is not migrated")
+	ErrIrreversibleMigration = errors.New("the migration cannot be reverted")
+	ErrUnknownVersion        = errors.New("no migration has this version")
+)
+
+// Migration is a versioned change of the schema. Up and Down run in a transaction together with the bookkeeping
+// in schema_migrations, a migration without Down cannot be reverted.
+type Migration struct {
+	Version uint
+	Name    string
+	Up      func(tx *gorm.DB) error
+	Down    func(tx *gorm.DB) error
+}
+
+// SchemaMigration is a migration applied to the database
+type SchemaMigration struct {
+	Version   uint      `json:"version" gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `json:"name" gorm:"NOT NULL"`
+	AppliedAt time.Time `json:"applied_at" gorm:"NOT NULL"`
+}
+
+// MigrationStatus is a migration of the binary or of the database, Known is false for a migration applied by a newer binary
+type MigrationStatus struct {
+	Version   uint
+	Name      string
+	Known     bool
+	AppliedAt *time.Time
+}
+
+// LatestVersion is the version the binary needs the schema to be at
+func LatestVersion() uint {
+	if len(migrations) == 0 {
+		return 0
+	}
+	return migrations[len(migrations)-1].Version
+}
+
+// Up applies the pending migrations up to the target version, 0 applies all of them. It returns the applied migrations.
+func Up(db *gorm.DB, target uint) ([]Migration, error) {
+	if target != 0 && findMigration(target) == nil {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownVersion, target)
+	}
+	if err := createSchemaMigrations(db); err != nil {
+		return nil, err
+	}
+	var applied []Migration
+	for _, migration := range migrations {
+		if target != 0 && migration.Version > target {
+			break
+		}
+		done, err := apply(db, migration)
+		if err != nil {
+			return applied, fmt.Errorf("migration %d %s: %w", migration.Version, migration.Name, err)
+		}
+		if done {
+			applied = append(applied, migration)
+		}
+	}
+	return applied, nil
+}
+
+// Down reverts the last steps applied migrations, newest first. It returns the reverted migrations.
+func Down(db *gorm.DB, steps int) ([]Migration, error) {
+	if err := createSchemaMigrations(db); err != nil {
+		return nil, err
+	}
+	var appliedVersions []uint
+	if err := db.Model(&SchemaMigration{}).Order("version desc").Limit(steps).Pluck("version", &appliedVersions).Error; err != nil {
+		return nil, err
+	}
+	var reverted []Migration
+	for _, version := range appliedVersions {
+		migration := findMigration(version)
+		if migration == nil {
+			return reverted, fmt.Errorf("%w: %d was applied by a newer version of the service", ErrUnknownVersion, version)
+		}
+		if migration.Down == nil {
+			return reverted, fmt.Errorf("migration %d %s: %w", migration.Version, migration.Name, ErrIrreversibleMigration)
+		}
+		if err := revert(db, *migration); err != nil {
+			return reverted, fmt.Errorf("migration %d %s: %w", migration.Version, migration.Name, err)
+		}
+		reverted = append(reverted, *migration)
+	}
+	return reverted, nil
+}
+
+// Status lists the migrations of the binary and the ones applied by a newer binary, ordered by version
+func Status(db *gorm.DB) ([]MigrationStatus, error) {
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]MigrationStatus, 0, len(migrations))
+	for _, migration := range migrations {
+		status := MigrationStatus{Version: migration.Version, Name: migration.Name, Known: true}
+		if schemaMigration, found := applied[migration.Version]; found {
+			status.AppliedAt = &schemaMigration.AppliedAt
+			delete(applied, migration.Version)
+		}
+		statuses = append(statuses, status)
+	}
+	for _, schemaMigration := range applied {
+		appliedAt := schemaMigration.AppliedAt
+		statuses = append(statuses, MigrationStatus{Version: schemaMigration.Version, Name: schemaMigration.Name, AppliedAt: &appliedAt})
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+	return statuses, nil
+}
+
+// RequireCurrent fails with ErrPendingMigrations unless every migration of the binary is applied. Migrations
+// applied by a newer binary are accepted, they have to keep the schema compatible with the previous release.
+func RequireCurrent(db *gorm.DB) error {
+	statuses, err := Status(db)
+	if err != nil {
+		return err
+	}
+	var pending []uint
+	for _, status := range statuses {
+		if status.Known && status.AppliedAt == nil {
+			pending = append(pending, status.Version)
+		}
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("%w: versions %v are pending, run the migrate command first", ErrPendingMigrations, pending)
+	}
+	return nil
+}
+
+func findMigration(version uint) *Migration {
+	for i := range migrations {
+		if migrations[i].Version == version {
+			return &migrations[i]
+		}
+	}
+	return nil
+}
+
+func createSchemaMigrations(db *gorm.DB) error {
+	return db.AutoMigrate(&SchemaMigration{})
+}
+
+// appliedMigrations reads schema_migrations, a database without the table has no migration applied
+func appliedMigrations(db *gorm.DB) (map[uint]SchemaMigration, error) {
+	applied := map[uint]SchemaMigration{}
+	if !db.Migrator().HasTable(&SchemaMigration{}) {
+		return applied, nil
+	}
+	var schemaMigrations []SchemaMigration
+	if err := db.Find(&schemaMigrations).Error; err != nil {
+		return nil, err
+	}
+	for _, schemaMigration := range schemaMigrations {
+		applied[schemaMigration.Version] = schemaMigration
+	}
+	return applied, nil
+}
+
+// apply runs a migration unless it is already applied, the lock makes a concurrent migrate command wait
+// and see the migration as applied once it gets the lock
+func apply(db *gorm.DB, migration Migration) (bool, error) {
+	done := false
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", migrationLockKey).Error; err != nil {
+			return err
+		}
+		var count int64
+		if err := tx.Model(&SchemaMigration{}).Where("version = ?", migration.Version).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+		if err := migration.Up(tx); err != nil {
+			return err
+		}
+		done = true
+		return tx.Create(&SchemaMigration{Version: migration.Version, Name: migration.Name, AppliedAt: time.Now()}).Error
+	})
+	return done && err == nil, err
+}
+
+func revert(db *gorm.DB, migration Migration) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", migrationLockKey).Error; err != nil {
+			return err
+		}
+		result := tx.Where("version = ?", migration.Version).Delete(&SchemaMigration{})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return migration.Down(tx)
+	})
+}
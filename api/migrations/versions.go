@@ -0,0 +1,31 @@
+package migrations
+
+import (
+	"github.com/Octek/resource-profile-management-backend.git/api/experience"
+	"github.com/Octek/resource-profile-management-backend.git/api/migrations/baseline"
+	"github.com/Octek/resource-profile-management-backend.git/api/search"
+	"github.com/Octek/resource-profile-management-backend.git/api/skills"
+	user "github.com/Octek/resource-profile-management-backend.git/api/users"
+	"gorm.io/gorm"
+)
+
+// migrations are applied in the order of their version. A released migration is never changed, a change of
+// the schema is a new migration with the next version.
+//
+// The baseline creates the schema the services used to migrate themselves when they were started, from the frozen
+// entities of package baseline. It is safe on a database migrated that way, so the later migrations guard their
+// changes (IF NOT EXISTS, IF EXISTS) to also apply on a schema that already has them.
+var migrations = []Migration{
+	{Version: 1, Name: "baseline", Up: baseline.Migrate},
+	{Version: 2, Name: "unique_user_email", Up: user.MigrateUniqueEmail, Down: dropUniqueUserEmail},
+	{Version: 3, Name: "skill_levels", Up: skills.MigrateSkillLevels},
+	{Version: 4, Name: "skill_catalogue", Up: skills.MigrateSkillCatalogue},
+	{Version: 5, Name: "unique_experience_skills", Up: experience.MigrateExperienceSkills},
+	{Version: 6, Name: "experience_responsibilities", Up: experience.MigrateResponsibilities},
+	{Version: 7, Name: "search_vectors", Up: search.MigrateSearchVectors},
+}
+
+// dropUniqueUserEmail only drops the index, the emails trimmed by the migration stay trimmed
+func dropUniqueUserEmail(tx *gorm.DB) error {
+	return tx.Exec("DROP INDEX IF EXISTS " + user.UserEmailIndex).Error
+}
@@ -1,6 +1,7 @@
 package projects
 
 import (
+	"errors"
 	"gorm.io/gorm"
 	"time"
 )
@@ -23,3 +24,5 @@ type UserProject struct {
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
+
+var ErrProjectAlreadyAttached = errors.New("project is already attached to the user")
@@ -1,13 +1,371 @@
 package projects
 
 import (
+	"errors"
+	"fmt"
+	"github.com/Octek/resource-profile-management-backend.git/api/auth"
+	"github.com/Octek/resource-profile-management-backend.git/utils"
 	"github.com/gin-gonic/gin"
-	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+	"net/http"
 )
 
-var validate = validator.New()
+var validate = utils.NewValidator()
+
+// projectSortColumns are the columns the project lists can be ordered by
+var projectSortColumns = []string{"id", "name", "created_at", "updated_at"}
 
 // Routes Exports all routes handled by this service
-func Routes(router *gin.Engine, projectSvc ProjectService) {
+func Routes(router gin.IRouter, projectSvc ProjectService) {
+	projectsRouter := router.Group("/projects")
+	{
+		projectsRouter.POST("", auth.Authorize(auth.AllowAdmin), func(c *gin.Context) {
+			HandlerToCreateProject(c, projectSvc)
+		})
+		projectsRouter.PATCH("/:id", auth.Authorize(auth.AllowAdmin), func(c *gin.Context) {
+			HandlerToUpdateProjectByID(c, projectSvc)
+		})
+		projectsRouter.GET("", auth.Authorize(auth.AllowAuthenticated), func(c *gin.Context) {
+			HandlerToGetAllProjects(c, projectSvc)
+		})
+		projectsRouter.GET("/:id", auth.Authorize(auth.AllowAuthenticated), func(c *gin.Context) {
+			HandlerToGetProjectByID(c, projectSvc)
+		})
+		projectsRouter.DELETE("/:id", auth.Authorize(auth.AllowAdmin), func(c *gin.Context) {
+			HandlerToDeleteProjectByID(c, projectSvc)
+		})
+		projectsRouter.POST("/:id/user/:userId", auth.Authorize(auth.AllowAdmin, auth.AllowSelf(auth.UserIDFromParam("userId"))), func(c *gin.Context) {
+			HandlerToAttachProjectToUser(c, projectSvc)
+		})
+		projectsRouter.DELETE("/:id/user/:userId", auth.Authorize(auth.AllowAdmin, auth.AllowSelf(auth.UserIDFromParam("userId"))), func(c *gin.Context) {
+			HandlerToDetachProjectFromUser(c, projectSvc)
+		})
+		projectsRouter.GET("/user/:id", auth.Authorize(auth.AllowAuthenticated), func(c *gin.Context) {
+			HandlerToGetAllUserProjects(c, projectSvc)
+		})
+	}
+}
+
+// HandlerToCreateProject godoc
+// @Tags Projects
+// @Summary Create project
+// @Description Create project, optionally attaching it to a user
+// @ID create-project
+// @Security ApiAuthKey
+// @Accept json
+// @Produce json
+// @Param CreateProjectRequest body CreateProjectRequest true "Project"
+// @Success 200 {object} utils.ResponseMessage
+// @Failure 400 {object} utils.ResponseMessage
+// @Failure 404 {object} utils.ResponseMessage
+// @Failure 500 {object} utils.ResponseMessage
+// @Router /projects [post]
+func HandlerToCreateProject(c *gin.Context, projectSvc ProjectService) {
+	fmt.Println("HandlerToCreateProject")
+	var createProjectRequest CreateProjectRequest
+	if err := c.ShouldBind(&createProjectRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.InvalidJsonBody, err), err))
+		return
+	}
+
+	if err := validate.Struct(createProjectRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.RequestSchemaInvalid, err), err))
+		return
+	}
+	projectObj := Project{
+		Name:         createProjectRequest.Name,
+		Description:  createProjectRequest.Description,
+		Link:         createProjectRequest.Link,
+		Technologies: createProjectRequest.Technologies,
+	}
+	err := projectSvc.CreateProject(&projectObj, createProjectRequest.UserID)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileCreatingProject, err), err))
+		return
+	}
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.SuccessfullyCreatedProject, Data: projectObj})
+}
+
+// HandlerToGetAllProjects godoc
+// @Tags Projects
+// @Summary Get all projects
+// @Description Get all projects
+// @ID get-projects
+// @Security ApiAuthKey
+// @Accept  json
+// @Produce  json
+// @Param   limit    query     int     false  "example - 50"     limit(int)
+// @Param   offset     query     int     false  "example - 0"     offset(int)
+// @Param   orderBy     query     string     false  "example - created_at desc,updated_at desc"    orderBy(string)
+// @Param   keyword   query   string  false  "Search for a keyword in project names and technologies"
+// @Success 200 {object} string
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /projects [get]
+func HandlerToGetAllProjects(c *gin.Context, projectSvc ProjectService) {
+	fmt.Println("HandlerToGetAllProjects")
+	baseQuery := c.Request.URL.Query()
+	keyword := baseQuery.Get("keyword")
+
+	page, ok := utils.BindPage(c, utils.DefaultOrderBy, projectSortColumns...)
+	if !ok {
+		return
+	}
+	projectList, totalRecords, err := projectSvc.FetchAllProjects(page.Limit, page.Offset, page.OrderBy, keyword)
+	if err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusInternalServerError, fmt.Sprintf(utils.SomethingWentWrongWhileGettingProject, err), err))
+		return
+	}
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.Success, Data: utils.RecordsResponse{Total: totalRecords, RecordsFiltered: len(projectList), Data: projectList}})
+}
+
+// HandlerToGetProjectByID godoc
+// @Tags Projects
+// @Summary Get project
+// @Description Get project
+// @ID get-project
+// @Security ApiAuthKey
+// @Accept  json
+// @Param id path int true "Project ID"
+// @Success 200 {object} string
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /projects/{id} [get]
+func HandlerToGetProjectByID(c *gin.Context, projectSvc ProjectService) {
+	fmt.Println("HandlerToGetProjectByID")
+	projectID, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
+	fetchedProject, err := projectSvc.GetProjectById(projectID)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileGettingProject, err), err))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.Success, Data: fetchedProject})
+}
+
+// HandlerToUpdateProjectByID godoc
+// @Tags Projects
+// @Summary Update project
+// @Description Update project
+// @ID update-project
+// @Security ApiAuthKey
+// @Accept json
+// @Param id path int true "Project ID"
+// @Param ProjectRequest body ProjectRequest true "Project"
+// @Success 200 {object} string
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /projects/{id} [patch]
+func HandlerToUpdateProjectByID(c *gin.Context, projectSvc ProjectService) {
+	fmt.Println("HandlerToUpdateProjectByID")
+	projectID, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
+
+	var updateProjectRequest ProjectRequest
+	if err := c.ShouldBind(&updateProjectRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.InvalidJsonBody, err), err))
+		return
+	}
+	if err := validate.Struct(updateProjectRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.RequestSchemaInvalid, err), err))
+		return
+	}
+	fetchedProject, err := projectSvc.GetProjectById(projectID)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileGettingProject, err), err))
+		return
+	}
+	utils.UpdateEntity(&fetchedProject, updateProjectRequest)
+	err = projectSvc.UpdateProject(fetchedProject)
+	if err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusInternalServerError, fmt.Sprintf(utils.SomethingWentWrongWhileUpdatingProject, err), err))
+		return
+	}
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.SuccessfullyUpdatedProject, Data: fetchedProject})
+}
+
+// HandlerToDeleteProjectByID godoc
+// @Tags Projects
+// @Summary Delete project
+// @Description Soft deletes a project
+// @ID delete-project
+// @Security ApiAuthKey
+// @Accept  json
+// @Param id path int true "Project ID"
+// @Success 200 {object} string
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /projects/{id} [delete]
+func HandlerToDeleteProjectByID(c *gin.Context, projectSvc ProjectService) {
+	fmt.Println("HandlerToDeleteProjectByID")
+	projectID, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
+	err := projectSvc.DeleteProjectById(projectID)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileDeletingProject, err), err))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.SuccessfullyDeletedProject, Data: nil})
+}
+
+// HandlerToAttachProjectToUser godoc
+// @Tags Projects
+// @Summary Attach project to user
+// @Description Attach an existing project to a user
+// @ID attach-project-to-user
+// @Security ApiAuthKey
+// @Accept  json
+// @Param id path int true "Project ID"
+// @Param userId path int true "User ID"
+// @Success 200 {object} string
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Router /projects/{id}/user/{userId} [post]
+func HandlerToAttachProjectToUser(c *gin.Context, projectSvc ProjectService) {
+	fmt.Println("HandlerToAttachProjectToUser")
+	projectID, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
+	userID, ok := utils.BindID(c, "userId")
+	if !ok {
+		return
+	}
+	err := projectSvc.AttachProjectToUser(projectID, userID)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		if errors.Is(err, ErrProjectAlreadyAttached) {
+			statusCode = http.StatusConflict
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileAttachingProject, err), err))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.SuccessfullyAttachedProject, Data: nil})
+}
+
+// HandlerToDetachProjectFromUser godoc
+// @Tags Projects
+// @Summary Detach project from user
+// @Description Remove the link between a project and a user
+// @ID detach-project-from-user
+// @Security ApiAuthKey
+// @Accept  json
+// @Param id path int true "Project ID"
+// @Param userId path int true "User ID"
+// @Success 200 {object} string
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /projects/{id}/user/{userId} [delete]
+func HandlerToDetachProjectFromUser(c *gin.Context, projectSvc ProjectService) {
+	fmt.Println("HandlerToDetachProjectFromUser")
+	projectID, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
+	userID, ok := utils.BindID(c, "userId")
+	if !ok {
+		return
+	}
+	err := projectSvc.DetachProjectFromUser(projectID, userID)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileDetachingProject, err), err))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.SuccessfullyDetachedProject, Data: nil})
+}
+
+// HandlerToGetAllUserProjects godoc
+// @Tags Projects
+// @Summary Get all projects of a user
+// @Description Get all projects attached to a user
+// @ID get-all-user-projects
+// @Security ApiAuthKey
+// @Accept  json
+// @Produce  json
+// @Param id path int true "User ID"
+// @Param   limit    query     int     false  "example - 50"     limit(int)
+// @Param   offset     query     int     false  "example - 0"     offset(int)
+// @Param   orderBy     query     string     false  "example - created_at desc,updated_at desc"    orderBy(string)
+// @Param   keyword   query   string  false  "Search for a keyword in project names and technologies"
+// @Success 200 {object} string
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /projects/user/{id} [get]
+func HandlerToGetAllUserProjects(c *gin.Context, projectSvc ProjectService) {
+	fmt.Println("HandlerToGetAllUserProjects")
+	userID, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
+	baseQuery := c.Request.URL.Query()
+	keyword := baseQuery.Get("keyword")
+
+	page, ok := utils.BindPage(c, utils.DefaultOrderBy, projectSortColumns...)
+	if !ok {
+		return
+	}
+	projectList, totalRecords, err := projectSvc.FetchAllUserProjects(userID, page.Limit, page.Offset, page.OrderBy, keyword)
+	if err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusInternalServerError, fmt.Sprintf(utils.SomethingWentWrongWhileGettingProject, err), err))
+		return
+	}
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.Success, Data: utils.RecordsResponse{Total: totalRecords, RecordsFiltered: len(projectList), Data: projectList}})
+}
+
+// All requested and response structs
+
+type CreateProjectRequest struct {
+	Name         string `json:"name" validate:"required"`
+	Description  string `json:"description"`
+	Link         string `json:"link" validate:"omitempty,url"`
+	Technologies string `json:"technologies"`
+	UserID       uint   `json:"user_id"`
+}
 
+type ProjectRequest struct {
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	Link         string `json:"link" validate:"omitempty,url"`
+	Technologies string `json:"technologies"`
 }
@@ -2,5 +2,12 @@ package projects
 
 // ProjectRepository Used to store and retrieve projects
 type ProjectRepository interface {
-	//createCategories(jsonData []Category) error
+	createProject(projectObj *Project, userID uint) error
+	getProjectById(id uint) (Project, error)
+	updateProject(projectObj Project) error
+	deleteProjectById(id uint) error
+	fetchAllProjects(limit, offset int, orderBy, keyword string) ([]Project, int64, error)
+	attachProjectToUser(projectID, userID uint) error
+	detachProjectFromUser(projectID, userID uint) error
+	fetchAllUserProjects(userID uint, limit, offset int, orderBy, keyword string) ([]Project, int64, error)
 }
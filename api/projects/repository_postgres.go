@@ -1,8 +1,10 @@
 package projects
 
 import (
+	"fmt"
 	log "github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"strings"
 )
 
 type projectRepositoryPostgres struct {
@@ -10,13 +12,162 @@ type projectRepositoryPostgres struct {
 }
 
 func NewProjectRepositoryPostgres(db *gorm.DB) ProjectRepository {
-	err := db.AutoMigrate(&UserProject{}, &Project{})
-	if err != nil {
-		log.Fatal(err)
-	}
 	log.Print("Successfully connected to postgres in projects service!")
 
 	return &projectRepositoryPostgres{
 		db: db,
 	}
 }
+
+func (repo *projectRepositoryPostgres) createProject(projectObj *Project, userID uint) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(projectObj).Error; err != nil {
+			return err
+		}
+		if userID == 0 {
+			fmt.Println("Project has been stored")
+			return nil
+		}
+		if err := userExists(tx, userID); err != nil {
+			return err
+		}
+		userProjectObj := UserProject{
+			UserID:    userID,
+			ProjectID: projectObj.ID,
+		}
+		if err := tx.Create(&userProjectObj).Error; err != nil {
+			return err
+		}
+		fmt.Println("Project and UserProject objects have been stored")
+		return nil
+	})
+}
+
+func (repo *projectRepositoryPostgres) getProjectById(id uint) (Project, error) {
+	var projectObj Project
+	if err := repo.db.Where("id = ?", id).First(&projectObj).Error; err != nil {
+		return Project{}, err
+	}
+
+	fmt.Printf("Project by id %d has been fetched\n", id)
+	return projectObj, nil
+}
+
+func (repo *projectRepositoryPostgres) updateProject(projectObj Project) error {
+	if err := repo.db.Save(&projectObj).Error; err != nil {
+		return err
+	}
+	fmt.Println("Project has been updated")
+	return nil
+}
+
+func (repo *projectRepositoryPostgres) deleteProjectById(id uint) error {
+	result := repo.db.Where("id = ?", id).Delete(&Project{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	fmt.Printf("Project by id %d has been deleted\n", id)
+	return nil
+}
+
+func (repo *projectRepositoryPostgres) fetchAllProjects(limit, offset int, orderBy, keyword string) ([]Project, int64, error) {
+	var projectList []Project
+	var totalRecords int64
+
+	query := filterProjectsByKeyword(repo.db.Model(&Project{}), keyword)
+
+	err := query.Count(&totalRecords).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	err = query.Order(orderBy).Limit(limit).Offset(offset).Find(&projectList).Error
+	if err != nil {
+		return nil, totalRecords, err
+	}
+
+	return projectList, totalRecords, nil
+}
+
+func (repo *projectRepositoryPostgres) attachProjectToUser(projectID, userID uint) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("id = ?", projectID).First(&Project{}).Error; err != nil {
+			return err
+		}
+		if err := userExists(tx, userID); err != nil {
+			return err
+		}
+		var linked int64
+		if err := tx.Model(&UserProject{}).Where("user_id = ? AND project_id = ?", userID, projectID).Count(&linked).Error; err != nil {
+			return err
+		}
+		if linked > 0 {
+			return ErrProjectAlreadyAttached
+		}
+		userProjectObj := UserProject{
+			UserID:    userID,
+			ProjectID: projectID,
+		}
+		if err := tx.Create(&userProjectObj).Error; err != nil {
+			return err
+		}
+		fmt.Printf("Project %d has been attached to user %d\n", projectID, userID)
+		return nil
+	})
+}
+
+func (repo *projectRepositoryPostgres) detachProjectFromUser(projectID, userID uint) error {
+	result := repo.db.Where("user_id = ? AND project_id = ?", userID, projectID).Delete(&UserProject{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	fmt.Printf("Project %d has been detached from user %d\n", projectID, userID)
+	return nil
+}
+
+func (repo *projectRepositoryPostgres) fetchAllUserProjects(userID uint, limit, offset int, orderBy, keyword string) ([]Project, int64, error) {
+	var projectList []Project
+	var totalRecords int64
+
+	userProjectIDs := repo.db.Model(&UserProject{}).Select("project_id").Where("user_id = ?", userID)
+	query := filterProjectsByKeyword(repo.db.Model(&Project{}).Where("id IN (?)", userProjectIDs), keyword)
+
+	err := query.Count(&totalRecords).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	err = query.Order(orderBy).Limit(limit).Offset(offset).Find(&projectList).Error
+	if err != nil {
+		return nil, totalRecords, err
+	}
+
+	return projectList, totalRecords, nil
+}
+
+// filterProjectsByKeyword matches the keyword against project names and technologies
+func filterProjectsByKeyword(query *gorm.DB, keyword string) *gorm.DB {
+	if keyword == "" {
+		return query
+	}
+	keyword = "%" + strings.ToLower(keyword) + "%"
+	return query.Where("LOWER(name) LIKE ? OR LOWER(technologies) LIKE ?", keyword, keyword)
+}
+
+// userExists checks the users table directly, the users package depends on this one
+func userExists(tx *gorm.DB, userID uint) error {
+	var count int64
+	if err := tx.Table("users").Where("id = ? AND deleted_at IS NULL", userID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -7,3 +7,28 @@ type ProjectService struct {
 func NewService(r ProjectRepository) ProjectService {
 	return ProjectService{projectRepository: r}
 }
+
+func (svc *ProjectService) CreateProject(projectObj *Project, userID uint) error {
+	return svc.projectRepository.createProject(projectObj, userID)
+}
+func (svc *ProjectService) GetProjectById(id uint) (Project, error) {
+	return svc.projectRepository.getProjectById(id)
+}
+func (svc *ProjectService) UpdateProject(projectObj Project) error {
+	return svc.projectRepository.updateProject(projectObj)
+}
+func (svc *ProjectService) DeleteProjectById(id uint) error {
+	return svc.projectRepository.deleteProjectById(id)
+}
+func (svc *ProjectService) FetchAllProjects(limit, offset int, orderBy, keyword string) ([]Project, int64, error) {
+	return svc.projectRepository.fetchAllProjects(limit, offset, orderBy, keyword)
+}
+func (svc *ProjectService) AttachProjectToUser(projectID, userID uint) error {
+	return svc.projectRepository.attachProjectToUser(projectID, userID)
+}
+func (svc *ProjectService) DetachProjectFromUser(projectID, userID uint) error {
+	return svc.projectRepository.detachProjectFromUser(projectID, userID)
+}
+func (svc *ProjectService) FetchAllUserProjects(userID uint, limit, offset int, orderBy, keyword string) ([]Project, int64, error) {
+	return svc.projectRepository.fetchAllUserProjects(userID, limit, offset, orderBy, keyword)
+}
@@ -1,14 +1,31 @@
 package questions
 
 import (
+	"errors"
+	"fmt"
 	"gorm.io/gorm"
+	"strings"
 	"time"
 )
 
+const (
+	QuestionTypeSingleChoice   = "single_choice"
+	QuestionTypeMultipleChoice = "multiple_choice"
+	QuestionTypeFreeText       = "free_text"
+)
+
+var (
+	ErrInvalidQuestionOptions = errors.New("invalid question options")
+	ErrInvalidAnswer          = errors.New("invalid answer")
+)
+
 type Question struct {
 	ID              uint             `json:"id" gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
 	Questions       string           `json:"questions"`
 	QuestionType    string           `json:"question_type"`
+	Position        int              `json:"position"`
+	IsRequired      bool             `json:"is_required"`
+	RetiredAt       *time.Time       `json:"retired_at"`
 	QuestionOptions []QuestionOption `json:"question_options"  gorm:"foreignKey:QuestionID"`
 	DeletedAt       gorm.DeletedAt   `json:"deleted_at"`
 	CreatedAt       time.Time        `json:"created_at"`
@@ -16,9 +33,81 @@ type Question struct {
 }
 
 type QuestionOption struct {
-	ID         uint      `json:"id" gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
-	QuestionID uint      `json:"question_id" gorm:"NOT NULL;index:question_id"`
-	Name       string    `json:"name" gorm:"NOT NULL"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
+	ID         uint           `json:"id" gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
+	QuestionID uint           `json:"question_id" gorm:"NOT NULL;index:question_id"`
+	Name       string         `json:"name" gorm:"NOT NULL"`
+	Position   int            `json:"position"`
+	DeletedAt  gorm.DeletedAt `json:"-"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+}
+
+// ValidateOptions checks that choice questions have at least two distinct options and free text questions have none
+func (question *Question) ValidateOptions() error {
+	switch question.QuestionType {
+	case QuestionTypeFreeText:
+		if len(question.QuestionOptions) > 0 {
+			return fmt.Errorf("%w: free text questions cannot have options", ErrInvalidQuestionOptions)
+		}
+		return nil
+	case QuestionTypeSingleChoice, QuestionTypeMultipleChoice:
+		if len(question.QuestionOptions) < 2 {
+			return fmt.Errorf("%w: %s questions need at least two options", ErrInvalidQuestionOptions, question.QuestionType)
+		}
+	default:
+		return fmt.Errorf("%w: unknown question type %q", ErrInvalidQuestionOptions, question.QuestionType)
+	}
+
+	names := make(map[string]bool, len(question.QuestionOptions))
+	for _, option := range question.QuestionOptions {
+		name := strings.ToLower(strings.TrimSpace(option.Name))
+		if name == "" {
+			return fmt.Errorf("%w: option names cannot be empty", ErrInvalidQuestionOptions)
+		}
+		if names[name] {
+			return fmt.Errorf("%w: duplicate option %q", ErrInvalidQuestionOptions, option.Name)
+		}
+		names[name] = true
+	}
+	return nil
+}
+
+// ValidateAnswer checks a booking answer against the question type and its options
+func (question *Question) ValidateAnswer(optionIDs []uint, answer string) error {
+	switch question.QuestionType {
+	case QuestionTypeFreeText:
+		if len(optionIDs) > 0 {
+			return fmt.Errorf("%w: question %d takes a free text answer", ErrInvalidAnswer, question.ID)
+		}
+		if question.IsRequired && strings.TrimSpace(answer) == "" {
+			return fmt.Errorf("%w: question %d is required", ErrInvalidAnswer, question.ID)
+		}
+		return nil
+	case QuestionTypeSingleChoice:
+		if len(optionIDs) > 1 {
+			return fmt.Errorf("%w: question %d accepts a single option", ErrInvalidAnswer, question.ID)
+		}
+	}
+	if answer != "" {
+		return fmt.Errorf("%w: question %d takes options, not free text", ErrInvalidAnswer, question.ID)
+	}
+	if question.IsRequired && len(optionIDs) == 0 {
+		return fmt.Errorf("%w: question %d is required", ErrInvalidAnswer, question.ID)
+	}
+
+	validOptions := make(map[uint]bool, len(question.QuestionOptions))
+	for _, option := range question.QuestionOptions {
+		validOptions[option.ID] = true
+	}
+	seen := make(map[uint]bool, len(optionIDs))
+	for _, optionID := range optionIDs {
+		if !validOptions[optionID] {
+			return fmt.Errorf("%w: option %d does not belong to question %d", ErrInvalidAnswer, optionID, question.ID)
+		}
+		if seen[optionID] {
+			return fmt.Errorf("%w: option %d is selected twice", ErrInvalidAnswer, optionID)
+		}
+		seen[optionID] = true
+	}
+	return nil
 }
@@ -1,13 +1,354 @@
 package questions
 
 import (
+	"errors"
+	"fmt"
+	"github.com/Octek/resource-profile-management-backend.git/api/auth"
+	"github.com/Octek/resource-profile-management-backend.git/utils"
 	"github.com/gin-gonic/gin"
-	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+	"net/http"
+	"strconv"
 )
 
-var validate = validator.New()
+var validate = utils.NewValidator()
 
-// Routes Exports all routes handled by this service
-func Routes(router *gin.Engine, projectSvc QuestionService) {
+// questionSortColumns are the columns the question list can be ordered by
+var questionSortColumns = []string{"id", "questions", "question_type", "position", "retired_at", "created_at", "updated_at"}
 
+// Routes Exports all routes handled by this service, the questionnaire is public as clients answer it when they book
+func Routes(router gin.IRouter, authenticatedRouter gin.IRouter, questionSvc QuestionService) {
+	questionsRouter := authenticatedRouter.Group("/questions")
+	{
+		questionsRouter.POST("", auth.Authorize(auth.AllowAdmin), func(c *gin.Context) {
+			HandlerToCreateQuestion(c, questionSvc)
+		})
+		questionsRouter.GET("", auth.Authorize(auth.AllowAuthenticated), func(c *gin.Context) {
+			HandlerToGetAllQuestions(c, questionSvc)
+		})
+		questionsRouter.GET("/:id", auth.Authorize(auth.AllowAuthenticated), func(c *gin.Context) {
+			HandlerToGetQuestionByID(c, questionSvc)
+		})
+		questionsRouter.PATCH("/:id", auth.Authorize(auth.AllowAdmin), func(c *gin.Context) {
+			HandlerToUpdateQuestionByID(c, questionSvc)
+		})
+		questionsRouter.POST("/:id/retire", auth.Authorize(auth.AllowAdmin), func(c *gin.Context) {
+			HandlerToRetireQuestionByID(c, questionSvc)
+		})
+		questionsRouter.POST("/:id/activate", auth.Authorize(auth.AllowAdmin), func(c *gin.Context) {
+			HandlerToActivateQuestionByID(c, questionSvc)
+		})
+	}
+	router.GET("/questions/questionnaire", func(c *gin.Context) {
+		HandlerToGetQuestionnaire(c, questionSvc)
+	})
+}
+
+// HandlerToCreateQuestion godoc
+// @Tags Questions
+// @Summary Create question
+// @Description Create an intake question with its options in the given order
+// @ID create-question
+// @Security ApiAuthKey
+// @Accept json
+// @Produce json
+// @Param CreateQuestionRequest body CreateQuestionRequest true "Question"
+// @Success 200 {object} utils.ResponseMessage
+// @Failure 400 {object} utils.ResponseMessage
+// @Failure 500 {object} utils.ResponseMessage
+// @Router /questions [post]
+func HandlerToCreateQuestion(c *gin.Context, questionSvc QuestionService) {
+	fmt.Println("HandlerToCreateQuestion")
+	var createQuestionRequest CreateQuestionRequest
+	if err := c.ShouldBind(&createQuestionRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.InvalidJsonBody, err), err))
+		return
+	}
+
+	if err := validate.Struct(createQuestionRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.RequestSchemaInvalid, err), err))
+		return
+	}
+	questionObj := Question{
+		Questions:       createQuestionRequest.Questions,
+		QuestionType:    createQuestionRequest.QuestionType,
+		Position:        createQuestionRequest.Position,
+		IsRequired:      createQuestionRequest.IsRequired,
+		QuestionOptions: buildQuestionOptions(createQuestionRequest.Options),
+	}
+	err := questionSvc.CreateQuestion(&questionObj)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, ErrInvalidQuestionOptions) {
+			statusCode = http.StatusBadRequest
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileCreatingQuestion, err), err))
+		return
+	}
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.SuccessfullyCreatedQuestion, Data: questionObj})
+}
+
+// HandlerToGetAllQuestions godoc
+// @Tags Questions
+// @Summary Get all questions
+// @Description Get all questions of the question bank
+// @ID get-questions
+// @Security ApiAuthKey
+// @Accept  json
+// @Produce  json
+// @Param   limit    query     int     false  "example - 50"     limit(int)
+// @Param   offset     query     int     false  "example - 0"     offset(int)
+// @Param   orderBy     query     string     false  "example - position asc"    orderBy(string)
+// @Param   keyword   query   string  false  "Search for a keyword in questions"
+// @Param   includeRetired   query   bool  false  "Include retired questions"
+// @Success 200 {object} string
+// @Failure 400 {object} string
+// @Failure 500 {object} string
+// @Router /questions [get]
+func HandlerToGetAllQuestions(c *gin.Context, questionSvc QuestionService) {
+	fmt.Println("HandlerToGetAllQuestions")
+	baseQuery := c.Request.URL.Query()
+	keyword := baseQuery.Get("keyword")
+	includeRetired := baseQuery.Get("includeRetired")
+
+	if includeRetired == "" {
+		includeRetired = "false"
+	}
+
+	page, ok := utils.BindPage(c, utils.DefaultOrderBy, questionSortColumns...)
+	if !ok {
+		return
+	}
+	includeRetiredBool, err := strconv.ParseBool(includeRetired)
+	if err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.InvalidBooleanValueMessage, "includeRetired", err), err))
+		return
+	}
+	questionList, totalRecords, err := questionSvc.FetchAllQuestions(page.Limit, page.Offset, page.OrderBy, keyword, includeRetiredBool)
+	if err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusInternalServerError, fmt.Sprintf(utils.SomethingWentWrongWhileGettingQuestion, err), err))
+		return
+	}
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.Success, Data: utils.RecordsResponse{Total: totalRecords, RecordsFiltered: len(questionList), Data: questionList}})
+}
+
+// HandlerToGetQuestionnaire godoc
+// @Tags Questions
+// @Summary Get intake questionnaire
+// @Description Get the active intake questionnaire with ordered questions and options
+// @ID get-questionnaire
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} string
+// @Failure 500 {object} string
+// @Router /questions/questionnaire [get]
+func HandlerToGetQuestionnaire(c *gin.Context, questionSvc QuestionService) {
+	fmt.Println("HandlerToGetQuestionnaire")
+	questionList, err := questionSvc.FetchActiveQuestionnaire()
+	if err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusInternalServerError, fmt.Sprintf(utils.SomethingWentWrongWhileGettingQuestion, err), err))
+		return
+	}
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.Success, Data: questionList})
+}
+
+// HandlerToGetQuestionByID godoc
+// @Tags Questions
+// @Summary Get question
+// @Description Get question
+// @ID get-question
+// @Security ApiAuthKey
+// @Accept  json
+// @Param id path int true "Question ID"
+// @Success 200 {object} string
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /questions/{id} [get]
+func HandlerToGetQuestionByID(c *gin.Context, questionSvc QuestionService) {
+	fmt.Println("HandlerToGetQuestionByID")
+	questionID, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
+	fetchedQuestion, err := questionSvc.GetQuestionById(questionID)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileGettingQuestion, err), err))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.Success, Data: fetchedQuestion})
+}
+
+// HandlerToUpdateQuestionByID godoc
+// @Tags Questions
+// @Summary Update question
+// @Description Update a question; when options are sent they replace the current ones in the given order, existing options are kept by id
+// @ID update-question
+// @Security ApiAuthKey
+// @Accept json
+// @Param id path int true "Question ID"
+// @Param UpdateQuestionRequest body UpdateQuestionRequest true "Question"
+// @Success 200 {object} string
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /questions/{id} [patch]
+func HandlerToUpdateQuestionByID(c *gin.Context, questionSvc QuestionService) {
+	fmt.Println("HandlerToUpdateQuestionByID")
+	questionID, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
+
+	var updateQuestionRequest UpdateQuestionRequest
+	if err := c.ShouldBind(&updateQuestionRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.InvalidJsonBody, err), err))
+		return
+	}
+	if err := validate.Struct(updateQuestionRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.RequestSchemaInvalid, err), err))
+		return
+	}
+	fetchedQuestion, err := questionSvc.GetQuestionById(questionID)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileGettingQuestion, err), err))
+		return
+	}
+
+	if updateQuestionRequest.Questions != "" {
+		fetchedQuestion.Questions = updateQuestionRequest.Questions
+	}
+	if updateQuestionRequest.QuestionType != "" {
+		fetchedQuestion.QuestionType = updateQuestionRequest.QuestionType
+	}
+	if updateQuestionRequest.Position != nil {
+		fetchedQuestion.Position = *updateQuestionRequest.Position
+	}
+	if updateQuestionRequest.IsRequired != nil {
+		fetchedQuestion.IsRequired = *updateQuestionRequest.IsRequired
+	}
+	replaceOptions := updateQuestionRequest.Options != nil
+	if replaceOptions {
+		fetchedQuestion.QuestionOptions = buildQuestionOptions(*updateQuestionRequest.Options)
+	}
+
+	err = questionSvc.UpdateQuestion(fetchedQuestion, replaceOptions)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, ErrInvalidQuestionOptions) {
+			statusCode = http.StatusBadRequest
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileUpdatingQuestion, err), err))
+		return
+	}
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.SuccessfullyUpdatedQuestion, Data: nil})
+}
+
+// HandlerToRetireQuestionByID godoc
+// @Tags Questions
+// @Summary Retire question
+// @Description Remove a question from the intake questionnaire while keeping recorded answers
+// @ID retire-question
+// @Security ApiAuthKey
+// @Accept  json
+// @Param id path int true "Question ID"
+// @Success 200 {object} string
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /questions/{id}/retire [post]
+func HandlerToRetireQuestionByID(c *gin.Context, questionSvc QuestionService) {
+	fmt.Println("HandlerToRetireQuestionByID")
+	questionID, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
+	err := questionSvc.RetireQuestion(questionID)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileUpdatingQuestion, err), err))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.SuccessfullyRetiredQuestion, Data: nil})
+}
+
+// HandlerToActivateQuestionByID godoc
+// @Tags Questions
+// @Summary Activate question
+// @Description Put a retired question back on the intake questionnaire
+// @ID activate-question
+// @Security ApiAuthKey
+// @Accept  json
+// @Param id path int true "Question ID"
+// @Success 200 {object} string
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /questions/{id}/activate [post]
+func HandlerToActivateQuestionByID(c *gin.Context, questionSvc QuestionService) {
+	fmt.Println("HandlerToActivateQuestionByID")
+	questionID, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
+	err := questionSvc.ActivateQuestion(questionID)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileUpdatingQuestion, err), err))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.SuccessfullyActivatedQuestion, Data: nil})
+}
+
+// buildQuestionOptions keeps the request order as the option position
+func buildQuestionOptions(optionRequests []QuestionOptionRequest) []QuestionOption {
+	questionOptions := make([]QuestionOption, 0, len(optionRequests))
+	for i, optionRequest := range optionRequests {
+		questionOptions = append(questionOptions, QuestionOption{
+			ID:       optionRequest.ID,
+			Name:     optionRequest.Name,
+			Position: i + 1,
+		})
+	}
+	return questionOptions
+}
+
+// All requested and response structs
+
+type QuestionOptionRequest struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name" validate:"required"`
+}
+
+type CreateQuestionRequest struct {
+	Questions    string                  `json:"questions" validate:"required"`
+	QuestionType string                  `json:"question_type" validate:"required,oneof=single_choice multiple_choice free_text"`
+	Position     int                     `json:"position"`
+	IsRequired   bool                    `json:"is_required"`
+	Options      []QuestionOptionRequest `json:"options" validate:"dive"`
+}
+
+type UpdateQuestionRequest struct {
+	Questions    string                   `json:"questions"`
+	QuestionType string                   `json:"question_type" validate:"omitempty,oneof=single_choice multiple_choice free_text"`
+	Position     *int                     `json:"position"`
+	IsRequired   *bool                    `json:"is_required"`
+	Options      *[]QuestionOptionRequest `json:"options" validate:"omitempty,dive"`
 }
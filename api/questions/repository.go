@@ -1,6 +1,13 @@
 package questions
 
+import "time"
+
 // QuestionRepository Used to store and retrieve questions
 type QuestionRepository interface {
-	//createCategories(jsonData []Category) error
+	createQuestion(questionObj *Question) error
+	getQuestionById(id uint) (Question, error)
+	updateQuestion(questionObj Question, replaceOptions bool) error
+	setQuestionRetiredAt(id uint, retiredAt *time.Time) error
+	fetchAllQuestions(limit, offset int, orderBy, keyword string, includeRetired bool) ([]Question, int64, error)
+	fetchActiveQuestionnaire() ([]Question, error)
 }
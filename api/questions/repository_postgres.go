@@ -1,8 +1,11 @@
 package questions
 
 import (
+	"fmt"
 	log "github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"strings"
+	"time"
 )
 
 type questionRepositoryPostgres struct {
@@ -10,13 +13,121 @@ type questionRepositoryPostgres struct {
 }
 
 func NewQuestionRepositoryPostgres(db *gorm.DB) QuestionRepository {
-	err := db.AutoMigrate(&Question{}, &QuestionOption{})
-	if err != nil {
-		log.Fatal(err)
-	}
 	log.Print("Successfully connected to postgres in questions service!")
 
 	return &questionRepositoryPostgres{
 		db: db,
 	}
 }
+
+func (repo *questionRepositoryPostgres) createQuestion(questionObj *Question) error {
+	if err := repo.db.Create(questionObj).Error; err != nil {
+		return err
+	}
+	fmt.Println("Question and QuestionOption objects have been stored")
+	return nil
+}
+
+func (repo *questionRepositoryPostgres) getQuestionById(id uint) (Question, error) {
+	var questionObj Question
+	if err := repo.db.Where("id = ?", id).Preload("QuestionOptions", orderOptions).First(&questionObj).Error; err != nil {
+		return Question{}, err
+	}
+
+	fmt.Printf("Question by id %d has been fetched\n", id)
+	return questionObj, nil
+}
+
+func (repo *questionRepositoryPostgres) updateQuestion(questionObj Question, replaceOptions bool) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("QuestionOptions").Save(&questionObj).Error; err != nil {
+			return err
+		}
+		if !replaceOptions {
+			fmt.Println("Question has been updated")
+			return nil
+		}
+
+		keptOptionIDs := make([]uint, 0, len(questionObj.QuestionOptions))
+		for _, option := range questionObj.QuestionOptions {
+			option.QuestionID = questionObj.ID
+			if option.ID == 0 {
+				if err := tx.Create(&option).Error; err != nil {
+					return err
+				}
+				keptOptionIDs = append(keptOptionIDs, option.ID)
+				continue
+			}
+			result := tx.Model(&QuestionOption{}).Where("id = ? AND question_id = ?", option.ID, questionObj.ID).
+				Updates(map[string]interface{}{"name": option.Name, "position": option.Position})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return fmt.Errorf("%w: option %d does not belong to question %d", ErrInvalidQuestionOptions, option.ID, questionObj.ID)
+			}
+			keptOptionIDs = append(keptOptionIDs, option.ID)
+		}
+
+		removedOptions := tx.Where("question_id = ?", questionObj.ID)
+		if len(keptOptionIDs) > 0 {
+			removedOptions = removedOptions.Where("id NOT IN (?)", keptOptionIDs)
+		}
+		if err := removedOptions.Delete(&QuestionOption{}).Error; err != nil {
+			return err
+		}
+		fmt.Println("Question and its options have been updated")
+		return nil
+	})
+}
+
+func (repo *questionRepositoryPostgres) setQuestionRetiredAt(id uint, retiredAt *time.Time) error {
+	result := repo.db.Model(&Question{}).Where("id = ?", id).Update("retired_at", retiredAt)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	fmt.Printf("Question by id %d has been updated\n", id)
+	return nil
+}
+
+func (repo *questionRepositoryPostgres) fetchAllQuestions(limit, offset int, orderBy, keyword string, includeRetired bool) ([]Question, int64, error) {
+	var questionList []Question
+	var totalRecords int64
+
+	query := repo.db.Model(&Question{})
+	if !includeRetired {
+		query = query.Where("retired_at IS NULL")
+	}
+	if keyword != "" {
+		query = query.Where("LOWER(questions) LIKE ?", "%"+strings.ToLower(keyword)+"%")
+	}
+
+	err := query.Count(&totalRecords).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	err = query.Order(orderBy).Limit(limit).Offset(offset).Preload("QuestionOptions", orderOptions).Find(&questionList).Error
+	if err != nil {
+		return nil, totalRecords, err
+	}
+
+	return questionList, totalRecords, nil
+}
+
+func (repo *questionRepositoryPostgres) fetchActiveQuestionnaire() ([]Question, error) {
+	var questionList []Question
+	err := repo.db.Where("retired_at IS NULL").Order("position asc, id asc").
+		Preload("QuestionOptions", orderOptions).Find(&questionList).Error
+	if err != nil {
+		return nil, err
+	}
+	return questionList, nil
+}
+
+func orderOptions(db *gorm.DB) *gorm.DB {
+	return db.Order("position asc, id asc")
+}
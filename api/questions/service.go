@@ -1,5 +1,7 @@
 package questions
 
+import "time"
+
 type QuestionService struct {
 	questionRepository QuestionRepository
 }
@@ -7,3 +9,32 @@ type QuestionService struct {
 func NewService(r QuestionRepository) QuestionService {
 	return QuestionService{questionRepository: r}
 }
+
+func (svc *QuestionService) CreateQuestion(questionObj *Question) error {
+	if err := questionObj.ValidateOptions(); err != nil {
+		return err
+	}
+	return svc.questionRepository.createQuestion(questionObj)
+}
+func (svc *QuestionService) GetQuestionById(id uint) (Question, error) {
+	return svc.questionRepository.getQuestionById(id)
+}
+func (svc *QuestionService) UpdateQuestion(questionObj Question, replaceOptions bool) error {
+	if err := questionObj.ValidateOptions(); err != nil {
+		return err
+	}
+	return svc.questionRepository.updateQuestion(questionObj, replaceOptions)
+}
+func (svc *QuestionService) RetireQuestion(id uint) error {
+	retiredAt := time.Now()
+	return svc.questionRepository.setQuestionRetiredAt(id, &retiredAt)
+}
+func (svc *QuestionService) ActivateQuestion(id uint) error {
+	return svc.questionRepository.setQuestionRetiredAt(id, nil)
+}
+func (svc *QuestionService) FetchAllQuestions(limit, offset int, orderBy, keyword string, includeRetired bool) ([]Question, int64, error) {
+	return svc.questionRepository.fetchAllQuestions(limit, offset, orderBy, keyword, includeRetired)
+}
+func (svc *QuestionService) FetchActiveQuestionnaire() ([]Question, error) {
+	return svc.questionRepository.fetchActiveQuestionnaire()
+}
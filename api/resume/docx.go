@@ -0,0 +1,295 @@
+package resume
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	docxContentType = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+	// font sizes in WordprocessingML are half points
+	docxNameSize     = 44
+	docxSubtitleSize = 24
+	docxHeadingSize  = 26
+	docxTitleSize    = 22
+	docxBodySize     = 21
+	docxFooterSize   = 16
+)
+
+const docxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/>
+<Override PartName="/word/footer1.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.footer+xml"/>
+<Override PartName="/docProps/core.xml" ContentType="application/vnd.openxmlformats-package.core-properties+xml"/>
+</Types>`
+
+const docxPackageRelationships = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/>
+<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/package/2006/relationships/metadata/core-properties" Target="docProps/core.xml"/>
+</Relationships>`
+
+const docxDocumentRelationships = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rIdFooter" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/footer" Target="footer1.xml"/>
+</Relationships>`
+
+const docxNamespaces = `xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"`
+
+type docxWriter struct {
+	body     strings.Builder
+	template Template
+}
+
+type docxRun struct {
+	text string
+	// tab starts the run with a tab, literal tab characters are not reliable inside w:t
+	tab    bool
+	bold   bool
+	italic bool
+	size   int
+	color  string
+}
+
+// renderDOCX writes a minimal WordprocessingML package by hand so no office suite is needed
+func renderDOCX(resumeObj Resume, template Template) ([]byte, error) {
+	writer := &docxWriter{template: template}
+	writer.header(resumeObj)
+	for _, section := range template.sections() {
+		switch section {
+		case sectionSummary:
+			writer.summary(resumeObj)
+		case sectionSkills:
+			writer.skills(resumeObj)
+		case sectionExperience:
+			writer.experiences(resumeObj)
+		case sectionProjects:
+			writer.projects(resumeObj)
+		case sectionEducation:
+			writer.educations(resumeObj)
+		case sectionCertifications:
+			writer.certifications(resumeObj)
+		}
+	}
+
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", docxContentTypes},
+		{"_rels/.rels", docxPackageRelationships},
+		{"word/_rels/document.xml.rels", docxDocumentRelationships},
+		{"word/document.xml", writer.document()},
+		{"word/footer1.xml", writer.footer()},
+		{"docProps/core.xml", docxCoreProperties(resumeObj)},
+	}
+	var buffer bytes.Buffer
+	archive := zip.NewWriter(&buffer)
+	for _, part := range parts {
+		file, err := archive.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := file.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func (writer *docxWriter) document() string {
+	// A4 page with 18mm margins, measured in twentieths of a point
+	return `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:document ` + docxNamespaces + `><w:body>` + writer.body.String() +
+		`<w:sectPr><w:footerReference w:type="default" r:id="rIdFooter"/><w:pgSz w:w="11906" w:h="16838"/>` +
+		`<w:pgMar w:top="1020" w:right="1020" w:bottom="1020" w:left="1020" w:header="567" w:footer="567" w:gutter="0"/></w:sectPr>` +
+		`</w:body></w:document>`
+}
+
+func (writer *docxWriter) footer() string {
+	return `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:ftr ` + docxNamespaces + `><w:p><w:pPr><w:jc w:val="center"/></w:pPr>` +
+		writer.run(docxRun{text: brandName + " consultant profile", italic: true, size: docxFooterSize, color: pdfMutedColor.Hex()}) +
+		`</w:p></w:ftr>`
+}
+
+func docxCoreProperties(resumeObj Resume) string {
+	now := time.Now().UTC().Format(time.RFC3339)
+	return `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:dcterms="http://purl.org/dc/terms/" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">` +
+		`<dc:title>` + escapeXML(resumeObj.Name+" - CV") + `</dc:title>` +
+		`<dc:creator>` + brandName + `</dc:creator>` +
+		`<dcterms:created xsi:type="dcterms:W3CDTF">` + now + `</dcterms:created>` +
+		`<dcterms:modified xsi:type="dcterms:W3CDTF">` + now + `</dcterms:modified>` +
+		`</cp:coreProperties>`
+}
+
+func (writer *docxWriter) run(run docxRun) string {
+	var properties strings.Builder
+	fmt.Fprintf(&properties, `<w:rFonts w:ascii="%[1]s" w:hAnsi="%[1]s" w:cs="%[1]s"/>`, writer.template.DOCXFont)
+	if run.bold {
+		properties.WriteString(`<w:b/>`)
+	}
+	if run.italic {
+		properties.WriteString(`<w:i/>`)
+	}
+	if run.color != "" {
+		fmt.Fprintf(&properties, `<w:color w:val="%s"/>`, run.color)
+	}
+	size := run.size
+	if size == 0 {
+		size = docxBodySize
+	}
+	fmt.Fprintf(&properties, `<w:sz w:val="%d"/><w:szCs w:val="%d"/>`, size, size)
+	tab := ""
+	if run.tab {
+		tab = `<w:tab/>`
+	}
+	return `<w:r><w:rPr>` + properties.String() + `</w:rPr>` + tab + `<w:t xml:space="preserve">` + escapeXML(run.text) + `</w:t></w:r>`
+}
+
+// paragraph writes one paragraph, paragraphProperties is raw w:pPr content
+func (writer *docxWriter) paragraph(paragraphProperties string, runs ...docxRun) {
+	writer.body.WriteString(`<w:p><w:pPr>` + paragraphProperties + `</w:pPr>`)
+	for _, run := range runs {
+		writer.body.WriteString(writer.run(run))
+	}
+	writer.body.WriteString(`</w:p>`)
+}
+
+func (writer *docxWriter) header(resumeObj Resume) {
+	subtitle := strings.Join(nonEmpty([]string{resumeObj.JobTitle, resumeObj.Category, resumeObj.Location}), "  |  ")
+	accent := writer.template.AccentColor.Hex()
+	if writer.template.HeaderBand {
+		shading := fmt.Sprintf(`<w:shd w:val="clear" w:color="auto" w:fill="%s"/>`, accent)
+		writer.paragraph(shading+`<w:spacing w:after="0"/><w:ind w:left="113" w:right="113"/>`,
+			docxRun{text: resumeObj.Name, bold: true, size: docxNameSize, color: "FFFFFF"})
+		writer.paragraph(shading+`<w:spacing w:after="240"/><w:ind w:left="113" w:right="113"/>`,
+			docxRun{text: subtitle, size: docxSubtitleSize, color: "FFFFFF"})
+		return
+	}
+	writer.paragraph(`<w:spacing w:after="0"/>`, docxRun{text: resumeObj.Name, bold: true, size: docxNameSize, color: accent})
+	writer.paragraph(fmt.Sprintf(`<w:pBdr><w:bottom w:val="single" w:sz="12" w:space="4" w:color="%s"/></w:pBdr><w:spacing w:after="240"/>`, accent),
+		docxRun{text: subtitle, size: docxSubtitleSize, color: pdfMutedColor.Hex()})
+}
+
+func (writer *docxWriter) heading(title string) {
+	accent := writer.template.AccentColor.Hex()
+	writer.paragraph(fmt.Sprintf(`<w:keepNext/><w:pBdr><w:bottom w:val="single" w:sz="4" w:space="1" w:color="%s"/></w:pBdr><w:spacing w:before="240" w:after="120"/>`, accent),
+		docxRun{text: strings.ToUpper(title), bold: true, size: docxHeadingSize, color: accent})
+}
+
+func (writer *docxWriter) text(text string) {
+	if text == "" {
+		return
+	}
+	for _, line := range strings.Split(text, "\n") {
+		writer.paragraph(`<w:spacing w:after="60"/>`, docxRun{text: line})
+	}
+}
+
+// entryTitle writes a bold title with the period on a right aligned tab stop
+func (writer *docxWriter) entryTitle(title, period string) {
+	runs := []docxRun{{text: title, bold: true, size: docxTitleSize}}
+	if period != "" {
+		writer.paragraph(`<w:keepNext/><w:tabs><w:tab w:val="right" w:pos="9866"/></w:tabs><w:spacing w:before="120" w:after="0"/>`,
+			append(runs, docxRun{text: period, tab: true, color: pdfMutedColor.Hex()})...)
+		return
+	}
+	writer.paragraph(`<w:keepNext/><w:spacing w:before="120" w:after="0"/>`, runs...)
+}
+
+func (writer *docxWriter) subtitle(text string) {
+	if text == "" {
+		return
+	}
+	writer.paragraph(`<w:spacing w:after="60"/>`, docxRun{text: text, italic: true})
+}
+
+func (writer *docxWriter) bullet(text string) {
+	writer.paragraph(`<w:ind w:left="360" w:hanging="220"/><w:spacing w:after="40"/>`, docxRun{text: "•"}, docxRun{text: text, tab: true})
+}
+
+func (writer *docxWriter) summary(resumeObj Resume) {
+	if resumeObj.Bio == "" {
+		return
+	}
+	writer.heading(sectionSummary)
+	writer.text(resumeObj.Bio)
+}
+
+func (writer *docxWriter) skills(resumeObj Resume) {
+	if len(resumeObj.SkillGroups) == 0 {
+		return
+	}
+	writer.heading(sectionSkills)
+	for _, group := range resumeObj.SkillGroups {
+		writer.paragraph(`<w:spacing w:after="60"/>`,
+			docxRun{text: group.Category + ": ", bold: true},
+			docxRun{text: strings.Join(group.Skills, ", ")})
+	}
+}
+
+func (writer *docxWriter) experiences(resumeObj Resume) {
+	if len(resumeObj.Experiences) == 0 {
+		return
+	}
+	writer.heading(sectionExperience)
+	for _, experienceObj := range resumeObj.Experiences {
+		writer.entryTitle(experienceObj.Position, experienceObj.Period)
+		writer.subtitle(experienceObj.Company)
+		writer.text(experienceObj.Description)
+		for _, responsibility := range experienceObj.Responsibilities {
+			writer.bullet(responsibility)
+		}
+	}
+}
+
+func (writer *docxWriter) projects(resumeObj Resume) {
+	if len(resumeObj.Projects) == 0 {
+		return
+	}
+	writer.heading(sectionProjects)
+	for _, projectObj := range resumeObj.Projects {
+		writer.entryTitle(projectObj.Name, "")
+		writer.subtitle(projectObj.Technologies)
+		writer.text(projectObj.Description)
+		writer.text(projectObj.Link)
+	}
+}
+
+func (writer *docxWriter) educations(resumeObj Resume) {
+	if len(resumeObj.Educations) == 0 {
+		return
+	}
+	writer.heading(sectionEducation)
+	for _, educationObj := range resumeObj.Educations {
+		writer.entryTitle(educationObj.Degree, educationObj.Period)
+		writer.subtitle(educationObj.Institution)
+		writer.text(educationObj.Achievements)
+	}
+}
+
+func (writer *docxWriter) certifications(resumeObj Resume) {
+	if resumeObj.Certifications == "" {
+		return
+	}
+	writer.heading(sectionCertifications)
+	writer.text(resumeObj.Certifications)
+}
+
+// escapeXML also replaces the control characters that are not allowed in XML 1.0
+func escapeXML(text string) string {
+	var buffer bytes.Buffer
+	_ = xml.EscapeText(&buffer, []byte(text))
+	return buffer.String()
+}
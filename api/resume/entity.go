@@ -0,0 +1,142 @@
+package resume
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	FormatPDF  = "pdf"
+	FormatDOCX = "docx"
+
+	TemplateClassic = "classic"
+	TemplateModern  = "modern"
+
+	DefaultFormat   = FormatPDF
+	DefaultTemplate = TemplateClassic
+
+	brandName = "Octek"
+)
+
+var (
+	ErrUnknownFormat   = errors.New("unknown resume format")
+	ErrUnknownTemplate = errors.New("unknown resume template")
+)
+
+type Color struct {
+	R, G, B int
+}
+
+func (color Color) Hex() string {
+	return fmt.Sprintf("%02X%02X%02X", color.R, color.G, color.B)
+}
+
+// Template is the look of a generated CV, both the PDF and the DOCX renderer follow it
+type Template struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	AccentColor Color  `json:"-"`
+	// PDFFont is one of the core PDF fonts so generation needs no font files
+	PDFFont  string `json:"-"`
+	DOCXFont string `json:"-"`
+	// HeaderBand draws the name on a full width accent band instead of accent coloured text
+	HeaderBand bool `json:"-"`
+	// SkillsFirst lists the skills before the experience
+	SkillsFirst bool `json:"-"`
+}
+
+var templates = []Template{
+	{
+		Name:        TemplateClassic,
+		Description: "Serif layout with the experience first, suited for formal client submissions",
+		AccentColor: Color{R: 31, G: 56, B: 100},
+		PDFFont:     "Times",
+		DOCXFont:    "Times New Roman",
+	},
+	{
+		Name:        TemplateModern,
+		Description: "Sans serif layout with a coloured header band and the skills first",
+		AccentColor: Color{R: 0, G: 122, B: 135},
+		PDFFont:     "Helvetica",
+		DOCXFont:    "Arial",
+		HeaderBand:  true,
+		SkillsFirst: true,
+	},
+}
+
+func GetTemplates() []Template {
+	return templates
+}
+
+func GetTemplate(name string) (Template, error) {
+	for _, template := range templates {
+		if template.Name == name {
+			return template, nil
+		}
+	}
+	return Template{}, fmt.Errorf("%w: %q", ErrUnknownTemplate, name)
+}
+
+// Resume is the profile of a user prepared for rendering
+type Resume struct {
+	Name           string
+	JobTitle       string
+	Category       string
+	Location       string
+	Bio            string
+	Certifications string
+	SkillGroups    []SkillGroup
+	Experiences    []ResumeExperience
+	Educations     []ResumeEducation
+	Projects       []ResumeProject
+}
+
+type SkillGroup struct {
+	Category string
+	Skills   []string
+}
+
+type ResumeExperience struct {
+	Position         string
+	Company          string
+	Period           string
+	Description      string
+	Responsibilities []string
+}
+
+type ResumeEducation struct {
+	Degree       string
+	Institution  string
+	Period       string
+	Achievements string
+}
+
+type ResumeProject struct {
+	Name         string
+	Description  string
+	Technologies string
+	Link         string
+}
+
+// Document is a rendered CV ready to be downloaded
+type Document struct {
+	Content     []byte
+	ContentType string
+	FileName    string
+}
+
+const (
+	sectionSummary        = "Summary"
+	sectionSkills         = "Skills"
+	sectionExperience     = "Experience"
+	sectionProjects       = "Projects"
+	sectionEducation      = "Education"
+	sectionCertifications = "Certifications"
+)
+
+func (template Template) sections() []string {
+	if template.SkillsFirst {
+		return []string{sectionSummary, sectionSkills, sectionExperience, sectionProjects, sectionEducation, sectionCertifications}
+	}
+	return []string{sectionSummary, sectionExperience, sectionSkills, sectionProjects, sectionEducation, sectionCertifications}
+}
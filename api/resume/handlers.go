@@ -0,0 +1,117 @@
+package resume
+
+import (
+	"errors"
+	"fmt"
+	"github.com/Octek/resource-profile-management-backend.git/api/auth"
+	"github.com/Octek/resource-profile-management-backend.git/utils"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"net/http"
+)
+
+// Routes Exports all routes handled by this service, every route declares who may call it
+func Routes(router gin.IRouter, resumeSvc ResumeService) {
+	resumeRouter := router.Group("/resume")
+	{
+		resumeRouter.GET("/templates", auth.Authorize(auth.AllowAuthenticated), func(c *gin.Context) {
+			HandlerToGetResumeTemplates(c)
+		})
+		resumeRouter.GET("/user/:id", auth.Authorize(auth.AllowAuthenticated), func(c *gin.Context) {
+			HandlerToGetUserResume(c, resumeSvc)
+		})
+		resumeRouter.GET("/user/:id/json-resume", auth.Authorize(auth.AllowAdmin, auth.AllowSelf(auth.UserIDFromParam("id"))), func(c *gin.Context) {
+			HandlerToGetUserJSONResume(c, resumeSvc)
+		})
+	}
+}
+
+// HandlerToGetResumeTemplates godoc
+// @Tags Resume
+// @Summary Get resume templates
+// @Description List the templates a CV can be rendered with
+// @ID get-resume-templates
+// @Security ApiAuthKey
+// @Produce json
+// @Success 200 {object} []Template
+// @Failure 401 {object} string
+// @Router /resume/templates [get]
+func HandlerToGetResumeTemplates(c *gin.Context) {
+	fmt.Println("HandlerToGetResumeTemplates")
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.Success, Data: GetTemplates()})
+}
+
+// HandlerToGetUserResume godoc
+// @Tags Resume
+// @Summary Download user CV
+// @Description Download the profile of a user as a branded CV. Contact details are not included
+// @ID get-user-resume
+// @Security ApiAuthKey
+// @Produce application/pdf
+// @Produce application/vnd.openxmlformats-officedocument.wordprocessingml.document
+// @Param id path int true "User ID"
+// @Param format query string false "pdf or docx, defaults to pdf"
+// @Param template query string false "classic or modern, defaults to classic"
+// @Success 200 {file} file
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /resume/user/{id} [get]
+func HandlerToGetUserResume(c *gin.Context, resumeSvc ResumeService) {
+	fmt.Println("HandlerToGetUserResume")
+	userID, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
+	format := c.DefaultQuery("format", DefaultFormat)
+	templateName := c.DefaultQuery("template", DefaultTemplate)
+
+	document, err := resumeSvc.GenerateResume(userID, format, templateName)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, ErrUnknownFormat), errors.Is(err, ErrUnknownTemplate):
+			statusCode = http.StatusBadRequest
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			statusCode = http.StatusNotFound
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileGeneratingResume, err), err))
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", document.FileName))
+	c.Data(http.StatusOK, document.ContentType, document.Content)
+}
+
+// HandlerToGetUserJSONResume godoc
+// @Tags Resume
+// @Summary Export user profile as JSON Resume
+// @Description Export the profile of a user with its work, education, skills and projects as a JSON Resume document (https://jsonresume.org/schema).
+// @Description The document includes the contact details, so only the user and the admins can export it
+// @ID get-user-json-resume
+// @Security ApiAuthKey
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} JSONResume
+// @Failure 400 {object} utils.ResponseMessage
+// @Failure 403 {object} utils.ResponseMessage
+// @Failure 404 {object} utils.ResponseMessage
+// @Failure 500 {object} utils.ResponseMessage
+// @Router /resume/user/{id}/json-resume [get]
+func HandlerToGetUserJSONResume(c *gin.Context, resumeSvc ResumeService) {
+	fmt.Println("HandlerToGetUserJSONResume")
+	userID, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
+	document, err := resumeSvc.ExportJSONResume(userID)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileExportingJSONResume, err), err))
+		return
+	}
+	c.JSON(http.StatusOK, document)
+}
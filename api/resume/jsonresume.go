@@ -0,0 +1,205 @@
+package resume
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Octek/resource-profile-management-backend.git/api/skills"
+	user "github.com/Octek/resource-profile-management-backend.git/api/users"
+)
+
+const (
+	JSONResumeSchema  = "https://raw.githubusercontent.com/jsonresume/resume-schema/v1.0.0/schema.json"
+	JSONResumeVersion = "v1.0.0"
+	// JSONResumeDateLayout is the full ISO 8601 date, the schema also allows a year and month or a year
+	JSONResumeDateLayout = "2006-01-02"
+	// VideoProfileNetwork names the profile carrying the video url of a user
+	VideoProfileNetwork = "Video"
+	// technologiesSeparator separates the technologies of a project
+	technologiesSeparator = ","
+)
+
+// JSONResume is a document of the JSON Resume standard (https://jsonresume.org/schema), the sections
+// without a counterpart in the profiles are left out
+type JSONResume struct {
+	Schema       string                  `json:"$schema,omitempty"`
+	Basics       JSONResumeBasics        `json:"basics"`
+	Work         []JSONResumeWork        `json:"work"`
+	Education    []JSONResumeEducation   `json:"education"`
+	Skills       []JSONResumeSkill       `json:"skills"`
+	Projects     []JSONResumeProject     `json:"projects"`
+	Certificates []JSONResumeCertificate `json:"certificates"`
+	Meta         *JSONResumeMeta         `json:"meta,omitempty"`
+}
+
+type JSONResumeBasics struct {
+	Name     string              `json:"name"`
+	Label    string              `json:"label,omitempty"`
+	Email    string              `json:"email"`
+	Phone    string              `json:"phone,omitempty"`
+	URL      string              `json:"url,omitempty"`
+	Summary  string              `json:"summary,omitempty"`
+	Location *JSONResumeLocation `json:"location,omitempty"`
+	Profiles []JSONResumeProfile `json:"profiles,omitempty"`
+}
+
+type JSONResumeLocation struct {
+	Address     string `json:"address,omitempty"`
+	PostalCode  string `json:"postalCode,omitempty"`
+	City        string `json:"city,omitempty"`
+	CountryCode string `json:"countryCode,omitempty"`
+	Region      string `json:"region,omitempty"`
+}
+
+type JSONResumeProfile struct {
+	Network  string `json:"network"`
+	Username string `json:"username,omitempty"`
+	URL      string `json:"url,omitempty"`
+}
+
+// JSONResumeWork is an experience, an experience without an end date is the current one
+type JSONResumeWork struct {
+	Name       string   `json:"name"`
+	Position   string   `json:"position"`
+	URL        string   `json:"url,omitempty"`
+	StartDate  string   `json:"startDate"`
+	EndDate    string   `json:"endDate,omitempty"`
+	Summary    string   `json:"summary,omitempty"`
+	Highlights []string `json:"highlights"`
+}
+
+type JSONResumeEducation struct {
+	Institution string   `json:"institution"`
+	URL         string   `json:"url,omitempty"`
+	Area        string   `json:"area,omitempty"`
+	StudyType   string   `json:"studyType,omitempty"`
+	StartDate   string   `json:"startDate"`
+	EndDate     string   `json:"endDate,omitempty"`
+	Score       string   `json:"score,omitempty"`
+	Courses     []string `json:"courses,omitempty"`
+}
+
+// JSONResumeSkill is a skill with the label of its proficiency, other tools often list related skills as keywords
+type JSONResumeSkill struct {
+	Name     string   `json:"name"`
+	Level    string   `json:"level,omitempty"`
+	Keywords []string `json:"keywords,omitempty"`
+}
+
+type JSONResumeProject struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Highlights  []string `json:"highlights,omitempty"`
+	Keywords    []string `json:"keywords,omitempty"`
+	StartDate   string   `json:"startDate,omitempty"`
+	EndDate     string   `json:"endDate,omitempty"`
+	URL         string   `json:"url,omitempty"`
+}
+
+type JSONResumeCertificate struct {
+	Name   string `json:"name"`
+	Date   string `json:"date,omitempty"`
+	Issuer string `json:"issuer,omitempty"`
+	URL    string `json:"url,omitempty"`
+}
+
+type JSONResumeMeta struct {
+	Canonical    string `json:"canonical,omitempty"`
+	Version      string `json:"version,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+}
+
+// NewJSONResume maps a profile onto the JSON Resume schema. Unlike the CV it keeps the contact details,
+// the document moves a profile between tools. The location goes into the city, the certifications are
+// one per line and the technologies of a project are separated by commas.
+func NewJSONResume(userObj user.User, userSkills []skills.UserSkillProficiency) JSONResume {
+	document := JSONResume{
+		Schema: JSONResumeSchema,
+		Basics: JSONResumeBasics{
+			Name:    strings.TrimSpace(userObj.FirstName + " " + userObj.LastName),
+			Label:   strings.TrimSpace(userObj.JobTitle),
+			Email:   strings.TrimSpace(userObj.Email),
+			Phone:   strings.TrimSpace(userObj.MobileNumber),
+			Summary: strings.TrimSpace(userObj.Bio),
+		},
+		Work:         []JSONResumeWork{},
+		Education:    []JSONResumeEducation{},
+		Skills:       []JSONResumeSkill{},
+		Projects:     []JSONResumeProject{},
+		Certificates: []JSONResumeCertificate{},
+		Meta: &JSONResumeMeta{
+			Version:      JSONResumeVersion,
+			LastModified: userObj.UpdatedAt.UTC().Format(time.RFC3339),
+		},
+	}
+	if location := strings.TrimSpace(userObj.Location); location != "" {
+		document.Basics.Location = &JSONResumeLocation{City: location}
+	}
+	if videoUrl := strings.TrimSpace(userObj.VideoUrl); videoUrl != "" {
+		document.Basics.Profiles = []JSONResumeProfile{{Network: VideoProfileNetwork, URL: videoUrl}}
+	}
+
+	experiences := userObj.Experiences
+	sort.SliceStable(experiences, func(i, j int) bool {
+		return experiences[i].StartDate.After(experiences[j].StartDate)
+	})
+	for _, experienceObj := range experiences {
+		work := JSONResumeWork{
+			Name:       strings.TrimSpace(experienceObj.Company),
+			Position:   strings.TrimSpace(experienceObj.Position),
+			StartDate:  formatJSONResumeDate(experienceObj.StartDate),
+			Summary:    strings.TrimSpace(experienceObj.Description),
+			Highlights: append([]string{}, nonEmpty(experienceObj.Responsibilities)...),
+		}
+		if !experienceObj.IsCurrentlyWorking {
+			work.EndDate = formatJSONResumeDate(experienceObj.EndDate)
+		}
+		document.Work = append(document.Work, work)
+	}
+
+	educations := userObj.Educations
+	sort.SliceStable(educations, func(i, j int) bool {
+		return educations[i].StartDate.After(educations[j].StartDate)
+	})
+	for _, educationObj := range educations {
+		document.Education = append(document.Education, JSONResumeEducation{
+			Institution: strings.TrimSpace(educationObj.InstitutionName),
+			Area:        strings.TrimSpace(educationObj.FieldOfStudy),
+			StudyType:   strings.TrimSpace(educationObj.Degree),
+			StartDate:   formatJSONResumeDate(educationObj.StartDate),
+			EndDate:     formatJSONResumeDate(educationObj.EndDate),
+		})
+	}
+
+	for _, userSkill := range userSkills {
+		document.Skills = append(document.Skills, JSONResumeSkill{
+			Name:  strings.TrimSpace(userSkill.Skill.Name),
+			Level: skills.ProficiencyLabel(userSkill.UserSkill.Proficiency),
+		})
+	}
+	sort.SliceStable(document.Skills, func(i, j int) bool {
+		return strings.ToLower(document.Skills[i].Name) < strings.ToLower(document.Skills[j].Name)
+	})
+
+	for _, projectObj := range userObj.Projects {
+		document.Projects = append(document.Projects, JSONResumeProject{
+			Name:        strings.TrimSpace(projectObj.Name),
+			Description: strings.TrimSpace(projectObj.Description),
+			Keywords:    nonEmpty(strings.Split(projectObj.Technologies, technologiesSeparator)),
+			URL:         strings.TrimSpace(projectObj.Link),
+		})
+	}
+
+	for _, certification := range nonEmpty(strings.Split(userObj.Certifications, "\n")) {
+		document.Certificates = append(document.Certificates, JSONResumeCertificate{Name: certification})
+	}
+	return document
+}
+
+func formatJSONResumeDate(date time.Time) string {
+	if date.IsZero() {
+		return ""
+	}
+	return date.Format(JSONResumeDateLayout)
+}
@@ -0,0 +1,240 @@
+package resume
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/go-pdf/fpdf"
+)
+
+const (
+	pdfContentType = "application/pdf"
+	pdfMargin      = 18.0
+	pdfLineHeight  = 5.0
+)
+
+var pdfMutedColor = Color{R: 90, G: 90, B: 90}
+
+type pdfWriter struct {
+	pdf       *fpdf.Fpdf
+	template  Template
+	translate func(string) string
+	width     float64
+}
+
+// renderPDF uses the core fonts only, so it runs offline and needs no font files.
+// Text is translated to cp1252 which covers the western european languages.
+func renderPDF(resumeObj Resume, template Template) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pageWidth, _ := pdf.GetPageSize()
+	writer := pdfWriter{
+		pdf:       pdf,
+		template:  template,
+		translate: pdf.UnicodeTranslatorFromDescriptor(""),
+		width:     pageWidth - 2*pdfMargin,
+	}
+	pdf.SetTitle(resumeObj.Name+" - CV", true)
+	pdf.SetAuthor(brandName, true)
+	pdf.SetCreator(brandName+" Resource Profile Management", true)
+	pdf.SetMargins(pdfMargin, pdfMargin, pdfMargin)
+	pdf.SetAutoPageBreak(true, pdfMargin)
+	pdf.SetFooterFunc(writer.footer)
+	pdf.AddPage()
+
+	writer.header(resumeObj)
+	for _, section := range template.sections() {
+		switch section {
+		case sectionSummary:
+			writer.summary(resumeObj)
+		case sectionSkills:
+			writer.skills(resumeObj)
+		case sectionExperience:
+			writer.experiences(resumeObj)
+		case sectionProjects:
+			writer.projects(resumeObj)
+		case sectionEducation:
+			writer.educations(resumeObj)
+		case sectionCertifications:
+			writer.certifications(resumeObj)
+		}
+	}
+
+	var buffer bytes.Buffer
+	if err := pdf.Output(&buffer); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func (writer pdfWriter) header(resumeObj Resume) {
+	pdf := writer.pdf
+	accent := writer.template.AccentColor
+	subtitle := strings.Join(nonEmpty([]string{resumeObj.JobTitle, resumeObj.Category, resumeObj.Location}), "  |  ")
+	if writer.template.HeaderBand {
+		pageWidth, _ := pdf.GetPageSize()
+		pdf.SetFillColor(accent.R, accent.G, accent.B)
+		pdf.Rect(0, 0, pageWidth, 40, "F")
+		pdf.SetTextColor(255, 255, 255)
+		pdf.SetY(12)
+	} else {
+		pdf.SetTextColor(accent.R, accent.G, accent.B)
+	}
+	pdf.SetFont(writer.template.PDFFont, "B", 22)
+	pdf.CellFormat(writer.width, 10, writer.translate(resumeObj.Name), "", 1, "L", false, 0, "")
+	if subtitle != "" {
+		if !writer.template.HeaderBand {
+			pdf.SetTextColor(pdfMutedColor.R, pdfMutedColor.G, pdfMutedColor.B)
+		}
+		pdf.SetFont(writer.template.PDFFont, "", 12)
+		pdf.CellFormat(writer.width, 7, writer.translate(subtitle), "", 1, "L", false, 0, "")
+	}
+	if writer.template.HeaderBand {
+		pdf.SetY(46)
+	} else {
+		pdf.SetDrawColor(accent.R, accent.G, accent.B)
+		pdf.SetLineWidth(0.6)
+		pdf.Line(pdfMargin, pdf.GetY()+2, pdfMargin+writer.width, pdf.GetY()+2)
+		pdf.Ln(6)
+	}
+	writer.resetText()
+}
+
+func (writer pdfWriter) footer() {
+	pdf := writer.pdf
+	pdf.SetY(-12)
+	pdf.SetFont(writer.template.PDFFont, "I", 8)
+	pdf.SetTextColor(pdfMutedColor.R, pdfMutedColor.G, pdfMutedColor.B)
+	pdf.CellFormat(writer.width, 6, writer.translate(fmt.Sprintf("%s consultant profile  -  page %d", brandName, pdf.PageNo())), "", 0, "C", false, 0, "")
+}
+
+func (writer pdfWriter) heading(title string) {
+	pdf := writer.pdf
+	accent := writer.template.AccentColor
+	pdf.Ln(2)
+	pdf.SetFont(writer.template.PDFFont, "B", 13)
+	pdf.SetTextColor(accent.R, accent.G, accent.B)
+	pdf.CellFormat(writer.width, 7, writer.translate(strings.ToUpper(title)), "", 1, "L", false, 0, "")
+	pdf.SetDrawColor(accent.R, accent.G, accent.B)
+	pdf.SetLineWidth(0.2)
+	pdf.Line(pdfMargin, pdf.GetY(), pdfMargin+writer.width, pdf.GetY())
+	pdf.Ln(2)
+	writer.resetText()
+}
+
+func (writer pdfWriter) resetText() {
+	writer.pdf.SetFont(writer.template.PDFFont, "", 10.5)
+	writer.pdf.SetTextColor(0, 0, 0)
+}
+
+func (writer pdfWriter) paragraph(text string) {
+	if text == "" {
+		return
+	}
+	writer.pdf.MultiCell(writer.width, pdfLineHeight, writer.translate(text), "", "L", false)
+}
+
+// entryTitle writes a bold title with the period aligned to the right on the same line
+func (writer pdfWriter) entryTitle(title, period string) {
+	pdf := writer.pdf
+	pdf.SetFont(writer.template.PDFFont, "B", 11)
+	periodWidth := 0.0
+	if period != "" {
+		periodWidth = 45
+	}
+	pdf.CellFormat(writer.width-periodWidth, 6, writer.translate(title), "", 0, "L", false, 0, "")
+	pdf.SetFont(writer.template.PDFFont, "", 10)
+	pdf.SetTextColor(pdfMutedColor.R, pdfMutedColor.G, pdfMutedColor.B)
+	pdf.CellFormat(periodWidth, 6, writer.translate(period), "", 1, "R", false, 0, "")
+	writer.resetText()
+}
+
+func (writer pdfWriter) subtitle(text string) {
+	if text == "" {
+		return
+	}
+	pdf := writer.pdf
+	pdf.SetFont(writer.template.PDFFont, "I", 10.5)
+	pdf.MultiCell(writer.width, pdfLineHeight, writer.translate(text), "", "L", false)
+	writer.resetText()
+}
+
+func (writer pdfWriter) bullet(text string) {
+	pdf := writer.pdf
+	pdf.SetX(pdfMargin + 2)
+	pdf.CellFormat(4, pdfLineHeight, writer.translate("•"), "", 0, "L", false, 0, "")
+	pdf.MultiCell(writer.width-6, pdfLineHeight, writer.translate(text), "", "L", false)
+}
+
+func (writer pdfWriter) summary(resumeObj Resume) {
+	if resumeObj.Bio == "" {
+		return
+	}
+	writer.heading(sectionSummary)
+	writer.paragraph(resumeObj.Bio)
+}
+
+func (writer pdfWriter) skills(resumeObj Resume) {
+	if len(resumeObj.SkillGroups) == 0 {
+		return
+	}
+	writer.heading(sectionSkills)
+	pdf := writer.pdf
+	for _, group := range resumeObj.SkillGroups {
+		pdf.SetFont(writer.template.PDFFont, "B", 10.5)
+		pdf.CellFormat(40, pdfLineHeight, writer.translate(group.Category), "", 0, "L", false, 0, "")
+		writer.resetText()
+		pdf.MultiCell(writer.width-40, pdfLineHeight, writer.translate(strings.Join(group.Skills, ", ")), "", "L", false)
+	}
+}
+
+func (writer pdfWriter) experiences(resumeObj Resume) {
+	if len(resumeObj.Experiences) == 0 {
+		return
+	}
+	writer.heading(sectionExperience)
+	for _, experienceObj := range resumeObj.Experiences {
+		writer.entryTitle(experienceObj.Position, experienceObj.Period)
+		writer.subtitle(experienceObj.Company)
+		writer.paragraph(experienceObj.Description)
+		for _, responsibility := range experienceObj.Responsibilities {
+			writer.bullet(responsibility)
+		}
+		writer.pdf.Ln(2)
+	}
+}
+
+func (writer pdfWriter) projects(resumeObj Resume) {
+	if len(resumeObj.Projects) == 0 {
+		return
+	}
+	writer.heading(sectionProjects)
+	for _, projectObj := range resumeObj.Projects {
+		writer.entryTitle(projectObj.Name, "")
+		writer.subtitle(projectObj.Technologies)
+		writer.paragraph(projectObj.Description)
+		writer.paragraph(projectObj.Link)
+		writer.pdf.Ln(2)
+	}
+}
+
+func (writer pdfWriter) educations(resumeObj Resume) {
+	if len(resumeObj.Educations) == 0 {
+		return
+	}
+	writer.heading(sectionEducation)
+	for _, educationObj := range resumeObj.Educations {
+		writer.entryTitle(educationObj.Degree, educationObj.Period)
+		writer.subtitle(educationObj.Institution)
+		writer.paragraph(educationObj.Achievements)
+		writer.pdf.Ln(2)
+	}
+}
+
+func (writer pdfWriter) certifications(resumeObj Resume) {
+	if resumeObj.Certifications == "" {
+		return
+	}
+	writer.heading(sectionCertifications)
+	writer.paragraph(resumeObj.Certifications)
+}
@@ -0,0 +1,130 @@
+package resume
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	user "github.com/Octek/resource-profile-management-backend.git/api/users"
+)
+
+const (
+	periodLayout     = "Jan 2006"
+	otherSkillsGroup = "Other"
+)
+
+// NewResume picks the parts of a profile that belong on a CV. Contact details are left out
+// on purpose, CVs are sent to clients and the contact goes through the company.
+func NewResume(userObj user.User) Resume {
+	resumeObj := Resume{
+		Name:           strings.TrimSpace(userObj.FirstName + " " + userObj.LastName),
+		JobTitle:       strings.TrimSpace(userObj.JobTitle),
+		Location:       strings.TrimSpace(userObj.Location),
+		Bio:            strings.TrimSpace(userObj.Bio),
+		Certifications: strings.TrimSpace(userObj.Certifications),
+		SkillGroups:    groupSkills(userObj),
+	}
+	if userObj.UserCategory != nil {
+		resumeObj.Category = userObj.UserCategory.Name
+	}
+
+	experiences := userObj.Experiences
+	sort.SliceStable(experiences, func(i, j int) bool {
+		return experiences[i].StartDate.After(experiences[j].StartDate)
+	})
+	for _, experienceObj := range experiences {
+		resumeObj.Experiences = append(resumeObj.Experiences, ResumeExperience{
+			Position:         strings.TrimSpace(experienceObj.Position),
+			Company:          strings.TrimSpace(experienceObj.Company),
+			Period:           formatPeriod(experienceObj.StartDate, experienceObj.EndDate, experienceObj.IsCurrentlyWorking),
+			Description:      strings.TrimSpace(experienceObj.Description),
+			Responsibilities: nonEmpty(experienceObj.Responsibilities),
+		})
+	}
+
+	educations := userObj.Educations
+	sort.SliceStable(educations, func(i, j int) bool {
+		return educations[i].StartDate.After(educations[j].StartDate)
+	})
+	for _, educationObj := range educations {
+		resumeObj.Educations = append(resumeObj.Educations, ResumeEducation{
+			Degree:       strings.Join(nonEmpty([]string{educationObj.Degree, educationObj.FieldOfStudy}), ", "),
+			Institution:  strings.TrimSpace(educationObj.InstitutionName),
+			Period:       formatPeriod(educationObj.StartDate, educationObj.EndDate, false),
+			Achievements: strings.TrimSpace(educationObj.Achievements),
+		})
+	}
+
+	for _, projectObj := range userObj.Projects {
+		resumeObj.Projects = append(resumeObj.Projects, ResumeProject{
+			Name:         strings.TrimSpace(projectObj.Name),
+			Description:  strings.TrimSpace(projectObj.Description),
+			Technologies: strings.TrimSpace(projectObj.Technologies),
+			Link:         strings.TrimSpace(projectObj.Link),
+		})
+	}
+	return resumeObj
+}
+
+// groupSkills groups the skills by category, categories and skills are sorted by name
+func groupSkills(userObj user.User) []SkillGroup {
+	skillsByCategory := make(map[string][]string)
+	for _, skillObj := range userObj.Skills {
+		category := otherSkillsGroup
+		if skillObj.SkillCategory != nil && skillObj.SkillCategory.Name != "" {
+			category = skillObj.SkillCategory.Name
+		}
+		skillsByCategory[category] = append(skillsByCategory[category], skillObj.Name)
+	}
+	groups := make([]SkillGroup, 0, len(skillsByCategory))
+	for category, skillNames := range skillsByCategory {
+		sort.Strings(skillNames)
+		groups = append(groups, SkillGroup{Category: category, Skills: skillNames})
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Category < groups[j].Category
+	})
+	return groups
+}
+
+func formatPeriod(startDate, endDate time.Time, isCurrent bool) string {
+	if startDate.IsZero() {
+		return ""
+	}
+	end := "Present"
+	if !isCurrent && !endDate.IsZero() {
+		end = endDate.Format(periodLayout)
+	}
+	return fmt.Sprintf("%s – %s", startDate.Format(periodLayout), end)
+}
+
+func nonEmpty(values []string) []string {
+	var result []string
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+// fileName turns the name into a safe download file name
+func fileName(name, extension string) string {
+	var builder strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			builder.WriteRune(r)
+		case r == ' ' || r == '-' || r == '_':
+			if !strings.HasSuffix(builder.String(), "-") {
+				builder.WriteRune('-')
+			}
+		}
+	}
+	base := strings.Trim(builder.String(), "-")
+	if base == "" {
+		base = "resume"
+	}
+	return base + "-cv." + extension
+}
@@ -0,0 +1,60 @@
+package resume
+
+import (
+	"github.com/Octek/resource-profile-management-backend.git/api/skills"
+	user "github.com/Octek/resource-profile-management-backend.git/api/users"
+)
+
+type ResumeService struct {
+	userService  user.UserService
+	skillService skills.SkillService
+}
+
+func NewService(userService user.UserService, skillService skills.SkillService) ResumeService {
+	return ResumeService{userService: userService, skillService: skillService}
+}
+
+// GenerateResume renders the profile of a user with the given format and template
+func (svc *ResumeService) GenerateResume(userID uint, format, templateName string) (Document, error) {
+	template, err := GetTemplate(templateName)
+	if err != nil {
+		return Document{}, err
+	}
+	render, contentType, err := rendererFor(format)
+	if err != nil {
+		return Document{}, err
+	}
+	userObj, err := svc.userService.GetUserDetailsByUserId(userID)
+	if err != nil {
+		return Document{}, err
+	}
+	resumeObj := NewResume(*userObj)
+	content, err := render(resumeObj, template)
+	if err != nil {
+		return Document{}, err
+	}
+	return Document{Content: content, ContentType: contentType, FileName: fileName(resumeObj.Name, format)}, nil
+}
+
+// ExportJSONResume returns the profile of a user as a JSON Resume document
+func (svc *ResumeService) ExportJSONResume(userID uint) (JSONResume, error) {
+	userObj, err := svc.userService.GetUserDetailsByUserId(userID)
+	if err != nil {
+		return JSONResume{}, err
+	}
+	userSkills, err := svc.skillService.GetUserSkillProficiencies(userID)
+	if err != nil {
+		return JSONResume{}, err
+	}
+	return NewJSONResume(*userObj, userSkills), nil
+}
+
+func rendererFor(format string) (func(Resume, Template) ([]byte, error), string, error) {
+	switch format {
+	case FormatPDF:
+		return renderPDF, pdfContentType, nil
+	case FormatDOCX:
+		return renderDOCX, docxContentType, nil
+	}
+	return nil, "", ErrUnknownFormat
+}
@@ -0,0 +1,56 @@
+package search
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+const (
+	HitTypeUser       = "user"
+	HitTypeExperience = "experience"
+	HitTypeProject    = "project"
+	HitTypeEducation  = "education"
+
+	// textSearchConfig has to match the configuration of the generated search_vector columns
+	textSearchConfig = "english"
+	maxQueryTerms    = 10
+)
+
+var HitTypes = []string{HitTypeUser, HitTypeExperience, HitTypeProject, HitTypeEducation}
+
+var (
+	ErrEmptySearchQuery = errors.New("the search query has no words to search for")
+	ErrUnknownHitType   = errors.New("unknown search result type")
+)
+
+// Hit is one search result. Snippet is HTML escaped text in which the matches are wrapped in <mark> tags.
+type Hit struct {
+	Type    string  `json:"type"`
+	ID      uint    `json:"id"`
+	UserID  uint    `json:"user_id"`
+	Title   string  `json:"title"`
+	Snippet string  `json:"snippet"`
+	Rank    float64 `json:"rank"`
+}
+
+// PrefixQuery turns free text into a tsquery in which every word has to match the start of a word,
+// so "post" finds "PostgreSQL". Characters with a meaning in tsquery syntax are dropped.
+func PrefixQuery(text string) (string, error) {
+	// apostrophes are removed first, otherwise "Go's" searches for the prefix "s"
+	text = strings.NewReplacer("'", "", "’", "").Replace(text)
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		if len(terms) == maxQueryTerms {
+			break
+		}
+		terms = append(terms, strings.ToLower(word)+":*")
+	}
+	if len(terms) == 0 {
+		return "", ErrEmptySearchQuery
+	}
+	return strings.Join(terms, " & "), nil
+}
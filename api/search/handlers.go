@@ -0,0 +1,71 @@
+package search
+
+import (
+	"errors"
+	"fmt"
+	"github.com/Octek/resource-profile-management-backend.git/api/auth"
+	"github.com/Octek/resource-profile-management-backend.git/utils"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strings"
+)
+
+const maxSearchQueryLength = 200
+
+// Routes Exports all routes handled by this service, every route declares who may call it
+func Routes(router gin.IRouter, searchSvc SearchService) {
+	router.GET("/search", auth.Authorize(auth.AllowAuthenticated), func(c *gin.Context) {
+		HandlerToSearch(c, searchSvc)
+	})
+}
+
+// HandlerToSearch godoc
+// @Tags Search
+// @Summary Full text search
+// @Description Search the profiles, experiences, projects and educations at once, best match first. Every word has to match the start of a word, so "post" finds "PostgreSQL".
+// @Description The snippet is HTML escaped and the matches are wrapped in <mark> tags
+// @ID search
+// @Security ApiAuthKey
+// @Produce json
+// @Param   q    query     string     true  "Words to search for"
+// @Param   type    query     string     false  "Comma separated result types: user, experience, project, education. Defaults to all"
+// @Param   limit    query     int     false  "example - 50"     limit(int)
+// @Param   offset     query     int     false  "example - 0"     offset(int)
+// @Success 200 {object} []Hit
+// @Failure 400 {object} string
+// @Failure 500 {object} string
+// @Router /search [get]
+func HandlerToSearch(c *gin.Context, searchSvc SearchService) {
+	fmt.Println("HandlerToSearch")
+	baseQuery := c.Request.URL.Query()
+	text := strings.TrimSpace(baseQuery.Get("q"))
+	hitTypeList := baseQuery.Get("type")
+
+	if text == "" || len(text) > maxSearchQueryLength {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.InvalidSearchQuery, fmt.Sprintf("q is required and at most %d characters long", maxSearchQueryLength)), nil))
+		return
+	}
+	var hitTypes []string
+	for _, hitType := range strings.Split(hitTypeList, ",") {
+		if hitType = strings.TrimSpace(hitType); hitType != "" {
+			hitTypes = append(hitTypes, hitType)
+		}
+	}
+
+	page, ok := utils.BindPage(c, "")
+	if !ok {
+		return
+	}
+	hits, totalRecords, err := searchSvc.Search(text, hitTypes, page.Limit, page.Offset)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		message := utils.SomethingWentWrongWhileSearching
+		if errors.Is(err, ErrEmptySearchQuery) || errors.Is(err, ErrUnknownHitType) {
+			statusCode = http.StatusBadRequest
+			message = utils.InvalidSearchQuery
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(message, err), err))
+		return
+	}
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.Success, Data: utils.RecordsResponse{Total: totalRecords, RecordsFiltered: len(hits), Data: hits}})
+}
@@ -0,0 +1,6 @@
+package search
+
+// SearchRepository Used to run full text searches over the profiles
+type SearchRepository interface {
+	search(tsQuery string, hitTypes []string, limit, offset int) ([]Hit, int64, error)
+}
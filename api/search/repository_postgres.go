@@ -0,0 +1,135 @@
+package search
+
+import (
+	"html"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	// markers put around the matches by ts_headline, they are turned into <mark> tags once the snippet is escaped
+	highlightStart    = "⟦"
+	highlightStop     = "⟧"
+	headlineOptions   = `StartSel="` + highlightStart + `", StopSel="` + highlightStop + `", MaxWords=35, MinWords=15, ShortWord=3, MaxFragments=2, FragmentDelimiter=" … "`
+	searchQueryCTE    = `WITH search_query AS (SELECT to_tsquery('` + textSearchConfig + `', ?) AS query) `
+	rankNormalization = "32"
+)
+
+// searchIndexes adds a weighted, generated search_vector column and a GIN index to every searchable table.
+// Titles weigh most, then short lists like technologies and certifications, then long free text.
+var searchIndexes = []struct {
+	table  string
+	vector string
+}{
+	{"users", `setweight(to_tsvector('english', coalesce(first_name, '') || ' ' || coalesce(last_name, '') || ' ' || coalesce(job_title, '')), 'A') ||
+		setweight(to_tsvector('english', coalesce(certifications, '')), 'B') ||
+		setweight(to_tsvector('english', coalesce(bio, '')), 'C')`},
+	{"experiences", `setweight(to_tsvector('english', coalesce(position, '')), 'A') ||
+		setweight(to_tsvector('english', coalesce(company, '')), 'B') ||
+		setweight(to_tsvector('english', coalesce(description, '')) || jsonb_to_tsvector('english', coalesce(responsibilities, '[]'), '["string"]'), 'C')`},
+	{"projects", `setweight(to_tsvector('english', coalesce(name, '')), 'A') ||
+		setweight(to_tsvector('english', coalesce(technologies, '')), 'B') ||
+		setweight(to_tsvector('english', coalesce(description, '')), 'C')`},
+	{"educations", `setweight(to_tsvector('english', coalesce(degree, '') || ' ' || coalesce(field_of_study, '')), 'A') ||
+		setweight(to_tsvector('english', coalesce(institution_name, '')), 'B') ||
+		setweight(to_tsvector('english', coalesce(achievements, '')), 'C')`},
+}
+
+// hitQueries select the matches of one type, document is the text the snippet is cut from
+var hitQueries = map[string]string{
+	HitTypeUser: `SELECT 'user' AS type, users.id AS id, users.id AS user_id,
+		concat_ws(' ', users.first_name, users.last_name) AS title,
+		concat_ws(' ', users.job_title, users.certifications, users.bio) AS document,
+		ts_rank_cd(users.search_vector, search_query.query, ` + rankNormalization + `) AS rank
+		FROM users, search_query
+		WHERE users.deleted_at IS NULL AND users.search_vector @@ search_query.query`,
+	HitTypeExperience: `SELECT 'experience' AS type, experiences.id AS id, user_experiences.user_id AS user_id,
+		concat_ws(' at ', nullif(experiences.position, ''), nullif(experiences.company, '')) AS title,
+		concat_ws(' ', experiences.description, (SELECT string_agg(responsibility.item, ' · ' ORDER BY responsibility.position)
+			FROM jsonb_array_elements_text(experiences.responsibilities) WITH ORDINALITY AS responsibility(item, position))) AS document,
+		ts_rank_cd(experiences.search_vector, search_query.query, ` + rankNormalization + `) AS rank
+		FROM experiences
+		JOIN user_experiences ON user_experiences.experience_id = experiences.id
+		JOIN users ON users.id = user_experiences.user_id AND users.deleted_at IS NULL, search_query
+		WHERE experiences.deleted_at IS NULL AND experiences.search_vector @@ search_query.query`,
+	HitTypeProject: `SELECT 'project' AS type, projects.id AS id, user_projects.user_id AS user_id,
+		projects.name AS title,
+		concat_ws(' ', projects.technologies, projects.description) AS document,
+		ts_rank_cd(projects.search_vector, search_query.query, ` + rankNormalization + `) AS rank
+		FROM projects
+		JOIN user_projects ON user_projects.project_id = projects.id
+		JOIN users ON users.id = user_projects.user_id AND users.deleted_at IS NULL, search_query
+		WHERE projects.deleted_at IS NULL AND projects.search_vector @@ search_query.query`,
+	HitTypeEducation: `SELECT 'education' AS type, educations.id AS id, educations.user_id AS user_id,
+		concat_ws(', ', nullif(educations.degree, ''), nullif(educations.field_of_study, '')) AS title,
+		concat_ws(' ', educations.field_of_study, educations.institution_name, educations.achievements) AS document,
+		ts_rank_cd(educations.search_vector, search_query.query, ` + rankNormalization + `) AS rank
+		FROM educations
+		JOIN users ON users.id = educations.user_id AND users.deleted_at IS NULL, search_query
+		WHERE educations.search_vector @@ search_query.query`,
+}
+
+type searchRepositoryPostgres struct {
+	db *gorm.DB
+}
+
+// MigrateSearchVectors adds the search columns and their indexes to the searched tables
+func MigrateSearchVectors(db *gorm.DB) error {
+	for _, index := range searchIndexes {
+		statements := []string{
+			`ALTER TABLE ` + index.table + ` ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (` + index.vector + `) STORED`,
+			`CREATE INDEX IF NOT EXISTS ` + index.table + `_search_vector_idx ON ` + index.table + ` USING GIN (search_vector)`,
+		}
+		for _, statement := range statements {
+			if err := db.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func NewSearchRepositoryPostgres(db *gorm.DB) SearchRepository {
+	log.Print("Successfully connected to postgres in search service!")
+
+	return &searchRepositoryPostgres{
+		db: db,
+	}
+}
+
+// search ranks the hits of all requested types together, the snippets are only built for the returned page
+func (repo *searchRepositoryPostgres) search(tsQuery string, hitTypes []string, limit, offset int) ([]Hit, int64, error) {
+	parts := make([]string, 0, len(hitTypes))
+	for _, hitType := range hitTypes {
+		parts = append(parts, hitQueries[hitType])
+	}
+	union := strings.Join(parts, " UNION ALL ")
+
+	var totalRecords int64
+	if err := repo.db.Raw(searchQueryCTE+`SELECT COUNT(*) FROM (`+union+`) hits`, tsQuery).Scan(&totalRecords).Error; err != nil {
+		return nil, 0, err
+	}
+	hits := make([]Hit, 0)
+	if totalRecords == 0 {
+		return hits, 0, nil
+	}
+	err := repo.db.Raw(searchQueryCTE+`SELECT hits.type, hits.id, hits.user_id, hits.title, hits.rank,
+		ts_headline('`+textSearchConfig+`', hits.document, search_query.query, '`+headlineOptions+`') AS snippet
+		FROM (SELECT * FROM (`+union+`) ranked ORDER BY ranked.rank DESC, ranked.type, ranked.id, ranked.user_id LIMIT ? OFFSET ?) hits, search_query
+		ORDER BY hits.rank DESC, hits.type, hits.id, hits.user_id`, tsQuery, limit, offset).Scan(&hits).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := range hits {
+		hits[i].Snippet = highlight(hits[i].Snippet)
+	}
+	return hits, totalRecords, nil
+}
+
+// highlight escapes the snippet and turns the ts_headline markers into <mark> tags
+func highlight(snippet string) string {
+	snippet = html.EscapeString(snippet)
+	return strings.NewReplacer(highlightStart, "<mark>", highlightStop, "</mark>").Replace(snippet)
+}
@@ -0,0 +1,37 @@
+package search
+
+import (
+	"fmt"
+)
+
+type SearchService struct {
+	searchRepository SearchRepository
+}
+
+func NewService(r SearchRepository) SearchService {
+	return SearchService{searchRepository: r}
+}
+
+// Search finds the profiles, experiences, projects and educations matching every word of the text.
+// All types are searched when hitTypes is empty.
+func (svc *SearchService) Search(text string, hitTypes []string, limit, offset int) ([]Hit, int64, error) {
+	tsQuery, err := PrefixQuery(text)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(hitTypes) == 0 {
+		hitTypes = HitTypes
+	}
+	seen := make(map[string]bool, len(hitTypes))
+	uniqueHitTypes := make([]string, 0, len(hitTypes))
+	for _, hitType := range hitTypes {
+		if _, ok := hitQueries[hitType]; !ok {
+			return nil, 0, fmt.Errorf("%w: %q", ErrUnknownHitType, hitType)
+		}
+		if !seen[hitType] {
+			seen[hitType] = true
+			uniqueHitTypes = append(uniqueHitTypes, hitType)
+		}
+	}
+	return svc.searchRepository.search(tsQuery, uniqueHitTypes, limit, offset)
+}
@@ -2,8 +2,13 @@ package seed
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"github.com/Octek/resource-profile-management-backend.git/api/auth"
+	"github.com/Octek/resource-profile-management-backend.git/api/sharing"
 	user "github.com/Octek/resource-profile-management-backend.git/api/users"
+	"github.com/Octek/resource-profile-management-backend.git/utils"
+	"gorm.io/gorm"
 	"io/ioutil"
 	"os"
 	// "github.com/jinzhu/gorm"
@@ -15,8 +20,9 @@ type Seed struct {
 }
 
 type JsonData struct {
-	Categories []user.UserCategory
-	Roles      []user.Role
+	Categories        []user.UserCategory
+	Roles             []user.Role
+	RedactionProfiles []sharing.RedactionProfile
 }
 
 func GetJSONFileData() JsonData {
@@ -34,8 +40,47 @@ func GetJSONFileData() JsonData {
 	return jsonData
 }
 
-func SeedData(userService user.UserService) {
+func SeedData(userService user.UserService, sharingService sharing.SharingService) {
 	var jsonData = GetJSONFileData()
 	_ = userService.CreateCategories(jsonData.Categories)
 	_ = userService.CreateRoles(jsonData.Roles)
+	_ = sharingService.CreateRedactionProfiles(jsonData.RedactionProfiles)
+}
+
+// SeedInitialAdmin gives the INITIAL_ADMIN_EMAIL user a password and the Admin role so the first
+// login is possible, the user is created when missing. Nothing changes once the user has a password.
+func SeedInitialAdmin(userService user.UserService, authService auth.AuthService) {
+	email, password := utils.GetInitialAdminCredentials()
+	if email == "" || password == "" {
+		return
+	}
+	identity, created, err := authService.EnsureCredential(email, password)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		jsonData := GetJSONFileData()
+		if len(jsonData.Categories) == 0 {
+			fmt.Println("Cannot create the initial admin, no user categories are seeded")
+			return
+		}
+		_, err = userService.CreateUser(&user.User{FirstName: "Admin", Email: email, UserCategoryID: jsonData.Categories[0].ID})
+		if err == nil {
+			identity, created, err = authService.EnsureCredential(email, password)
+		}
+	}
+	if err != nil {
+		fmt.Println(fmt.Errorf("cannot seed the initial admin: %w", err))
+		return
+	}
+	if !created {
+		// roles of an existing login are managed through the api from here on
+		return
+	}
+	adminRole, err := userService.GetRoleByName(auth.RoleAdmin)
+	if err == nil {
+		err = userService.AssignRoleToUser(identity.UserID, adminRole.ID)
+	}
+	if err != nil && !errors.Is(err, user.ErrRoleAlreadyAssigned) {
+		fmt.Println(fmt.Errorf("cannot give the initial admin the admin role: %w", err))
+		return
+	}
+	fmt.Println("Initial admin credential is in place")
 }
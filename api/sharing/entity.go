@@ -0,0 +1,89 @@
+package sharing
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	DefaultShareLinkTTL = 7 * 24 * time.Hour
+
+	ShareLinkStatusActive  = "active"
+	ShareLinkStatusExpired = "expired"
+	ShareLinkStatusRevoked = "revoked"
+)
+
+var (
+	ErrShareLinkExpired      = errors.New("share link has expired")
+	ErrShareLinkRevoked      = errors.New("share link has been revoked")
+	ErrRedactionProfileInUse = errors.New("redaction profile is used by share links")
+	ErrRedactionProfileName  = errors.New("a redaction profile with this name already exists")
+)
+
+// RedactionProfile decides which personal details are hidden from the people a profile is shared with
+type RedactionProfile struct {
+	ID               uint      `json:"id" gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
+	Name             string    `json:"name" gorm:"NOT NULL;uniqueIndex:redaction_profile_name"`
+	HideLastName     bool      `json:"hide_last_name"`
+	HideEmail        bool      `json:"hide_email"`
+	HideMobileNumber bool      `json:"hide_mobile_number"`
+	HideCompany      bool      `json:"hide_company"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// ShareLink gives read only access to the redacted profiles of one or more users until it expires or is revoked
+type ShareLink struct {
+	ID                 uint              `json:"id" gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
+	Token              string            `json:"token" gorm:"NOT NULL;uniqueIndex:share_link_token"`
+	Label              string            `json:"label"`
+	RedactionProfileID uint              `json:"redaction_profile_id" gorm:"NOT NULL;index:redaction_profile_id"`
+	RedactionProfile   *RedactionProfile `json:"redaction_profile" gorm:"foreignKey:RedactionProfileID;references:ID"`
+	CreatedByUserID    uint              `json:"created_by_user_id" gorm:"NOT NULL;index:created_by_user_id"`
+	ExpiresAt          time.Time         `json:"expires_at" gorm:"NOT NULL"`
+	RevokedAt          *time.Time        `json:"revoked_at"`
+	Users              []ShareLinkUser   `json:"users" gorm:"foreignKey:ShareLinkID"`
+	Status             string            `json:"status" gorm:"-"`
+	AccessCount        int64             `json:"access_count" gorm:"-"`
+	CreatedAt          time.Time         `json:"created_at"`
+	UpdatedAt          time.Time         `json:"updated_at"`
+}
+
+// ShareLinkUser is a user shared by a link, Position keeps the order chosen when the link was created
+type ShareLinkUser struct {
+	ID          uint      `json:"id" gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
+	ShareLinkID uint      `json:"share_link_id" gorm:"NOT NULL;index:share_link_id"`
+	UserID      uint      `json:"user_id" gorm:"NOT NULL;index:user_id"`
+	Position    int       `json:"position"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ShareLinkAccess is one request made with a share link, requests after expiry or revocation are logged as not granted
+type ShareLinkAccess struct {
+	ID          uint      `json:"id" gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
+	ShareLinkID uint      `json:"share_link_id" gorm:"NOT NULL;index:share_link_id"`
+	Granted     bool      `json:"granted"`
+	IPAddress   string    `json:"ip_address"`
+	UserAgent   string    `json:"user_agent"`
+	Referer     string    `json:"referer"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func (link *ShareLink) StatusAt(now time.Time) string {
+	switch {
+	case link.RevokedAt != nil:
+		return ShareLinkStatusRevoked
+	case !now.Before(link.ExpiresAt):
+		return ShareLinkStatusExpired
+	}
+	return ShareLinkStatusActive
+}
+
+func (link *ShareLink) UserIDs() []uint {
+	userIDs := make([]uint, 0, len(link.Users))
+	for _, sharedUser := range link.Users {
+		userIDs = append(userIDs, sharedUser.UserID)
+	}
+	return userIDs
+}
@@ -0,0 +1,483 @@
+package sharing
+
+import (
+	"errors"
+	"fmt"
+	"github.com/Octek/resource-profile-management-backend.git/api/auth"
+	"github.com/Octek/resource-profile-management-backend.git/utils"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"net/http"
+	"time"
+)
+
+var validate = utils.NewValidator()
+
+// shareLinkSortColumns are the columns the share link list can be ordered by
+var shareLinkSortColumns = []string{"id", "label", "expires_at", "revoked_at", "created_at", "updated_at"}
+
+// Routes Exports all routes handled by this service. Managing links needs a login,
+// the shared view is public and authorized by the token in the url.
+func Routes(router gin.IRouter, authenticatedRouter gin.IRouter, sharingSvc SharingService) {
+	sharingRouter := authenticatedRouter.Group("/sharing")
+	profilesRouter := sharingRouter.Group("/redaction-profiles")
+	{
+		profilesRouter.GET("", auth.Authorize(auth.AllowAuthenticated), func(c *gin.Context) {
+			HandlerToGetAllRedactionProfiles(c, sharingSvc)
+		})
+		profilesRouter.POST("", auth.Authorize(auth.AllowAdmin), func(c *gin.Context) {
+			HandlerToCreateRedactionProfile(c, sharingSvc)
+		})
+		profilesRouter.PATCH("/:id", auth.Authorize(auth.AllowAdmin), func(c *gin.Context) {
+			HandlerToUpdateRedactionProfileByID(c, sharingSvc)
+		})
+		profilesRouter.DELETE("/:id", auth.Authorize(auth.AllowAdmin), func(c *gin.Context) {
+			HandlerToDeleteRedactionProfileByID(c, sharingSvc)
+		})
+	}
+	linksRouter := sharingRouter.Group("/links")
+	{
+		linksRouter.POST("", auth.Authorize(auth.AllowAdmin), func(c *gin.Context) {
+			HandlerToCreateShareLink(c, sharingSvc)
+		})
+		linksRouter.GET("", auth.Authorize(auth.AllowAdmin), func(c *gin.Context) {
+			HandlerToGetAllShareLinks(c, sharingSvc)
+		})
+		linksRouter.GET("/:id", auth.Authorize(auth.AllowAdmin), func(c *gin.Context) {
+			HandlerToGetShareLinkByID(c, sharingSvc)
+		})
+		linksRouter.DELETE("/:id", auth.Authorize(auth.AllowAdmin), func(c *gin.Context) {
+			HandlerToRevokeShareLinkByID(c, sharingSvc)
+		})
+		linksRouter.GET("/:id/accesses", auth.Authorize(auth.AllowAdmin), func(c *gin.Context) {
+			HandlerToGetShareLinkAccesses(c, sharingSvc)
+		})
+	}
+	router.GET("/shared/:token", func(c *gin.Context) {
+		HandlerToGetSharedProfiles(c, sharingSvc)
+	})
+}
+
+// HandlerToGetAllRedactionProfiles godoc
+// @Tags Sharing
+// @Summary Get redaction profiles
+// @Description List the redaction profiles a share link can use
+// @ID get-redaction-profiles
+// @Security ApiAuthKey
+// @Produce json
+// @Success 200 {object} []RedactionProfile
+// @Failure 500 {object} string
+// @Router /sharing/redaction-profiles [get]
+func HandlerToGetAllRedactionProfiles(c *gin.Context, sharingSvc SharingService) {
+	fmt.Println("HandlerToGetAllRedactionProfiles")
+	profiles, err := sharingSvc.FetchAllRedactionProfiles()
+	if err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusInternalServerError, fmt.Sprintf(utils.SomethingWentWrongWhileGettingRedactionProfile, err), err))
+		return
+	}
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.Success, Data: profiles})
+}
+
+// HandlerToCreateRedactionProfile godoc
+// @Tags Sharing
+// @Summary Create redaction profile
+// @Description Create a redaction profile, it decides which personal details share links hide
+// @ID create-redaction-profile
+// @Security ApiAuthKey
+// @Accept json
+// @Produce json
+// @Param RedactionProfileRequest body RedactionProfileRequest true "Redaction profile"
+// @Success 201 {object} RedactionProfile
+// @Failure 400 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Router /sharing/redaction-profiles [post]
+func HandlerToCreateRedactionProfile(c *gin.Context, sharingSvc SharingService) {
+	fmt.Println("HandlerToCreateRedactionProfile")
+	var profileRequest RedactionProfileRequest
+	if err := c.ShouldBindJSON(&profileRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.InvalidJsonBody, err), err))
+		return
+	}
+	if err := validate.Struct(profileRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.RequestSchemaInvalid, err), err))
+		return
+	}
+	profile := RedactionProfile{
+		Name:             profileRequest.Name,
+		HideLastName:     profileRequest.HideLastName,
+		HideEmail:        profileRequest.HideEmail,
+		HideMobileNumber: profileRequest.HideMobileNumber,
+		HideCompany:      profileRequest.HideCompany,
+	}
+	if err := sharingSvc.CreateRedactionProfile(&profile); err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, ErrRedactionProfileName) {
+			statusCode = http.StatusConflict
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileSavingRedactionProfile, err), err))
+		return
+	}
+	c.JSON(http.StatusCreated, utils.ResponseMessage{StatusCode: http.StatusCreated, Message: utils.SuccessfullySavedRedactionProfile, Data: profile})
+}
+
+// HandlerToUpdateRedactionProfileByID godoc
+// @Tags Sharing
+// @Summary Update redaction profile
+// @Description Update a redaction profile, existing share links that use it follow the change
+// @ID update-redaction-profile
+// @Security ApiAuthKey
+// @Accept json
+// @Produce json
+// @Param id path int true "Redaction profile ID"
+// @Param RedactionProfileUpdateRequest body RedactionProfileUpdateRequest true "Fields to change"
+// @Success 200 {object} RedactionProfile
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Router /sharing/redaction-profiles/{id} [patch]
+func HandlerToUpdateRedactionProfileByID(c *gin.Context, sharingSvc SharingService) {
+	fmt.Println("HandlerToUpdateRedactionProfileByID")
+	profileID, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
+	var updateRequest RedactionProfileUpdateRequest
+	if err := c.ShouldBindJSON(&updateRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.InvalidJsonBody, err), err))
+		return
+	}
+	if err := validate.Struct(updateRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.RequestSchemaInvalid, err), err))
+		return
+	}
+	profile, err := sharingSvc.GetRedactionProfileById(profileID)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileGettingRedactionProfile, err), err))
+		return
+	}
+	updateRequest.apply(&profile)
+	if err := sharingSvc.UpdateRedactionProfile(profile); err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, ErrRedactionProfileName) {
+			statusCode = http.StatusConflict
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileSavingRedactionProfile, err), err))
+		return
+	}
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.SuccessfullySavedRedactionProfile, Data: profile})
+}
+
+// HandlerToDeleteRedactionProfileByID godoc
+// @Tags Sharing
+// @Summary Delete redaction profile
+// @Description Delete a redaction profile that no share link uses
+// @ID delete-redaction-profile
+// @Security ApiAuthKey
+// @Param id path int true "Redaction profile ID"
+// @Success 200 {object} string
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Router /sharing/redaction-profiles/{id} [delete]
+func HandlerToDeleteRedactionProfileByID(c *gin.Context, sharingSvc SharingService) {
+	fmt.Println("HandlerToDeleteRedactionProfileByID")
+	profileID, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
+	if err := sharingSvc.DeleteRedactionProfileById(profileID); err != nil {
+		statusCode := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			statusCode = http.StatusNotFound
+		case errors.Is(err, ErrRedactionProfileInUse):
+			statusCode = http.StatusConflict
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileDeletingRedactionProfile, err), err))
+		return
+	}
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.SuccessfullyDeletedRedactionProfile, Data: nil})
+}
+
+// HandlerToCreateShareLink godoc
+// @Tags Sharing
+// @Summary Create share link
+// @Description Share the redacted profiles of one or more users through a public url until the link expires or is revoked
+// @ID create-share-link
+// @Security ApiAuthKey
+// @Accept json
+// @Produce json
+// @Param CreateShareLinkRequest body CreateShareLinkRequest true "Share link"
+// @Success 201 {object} ShareLinkResponse
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /sharing/links [post]
+func HandlerToCreateShareLink(c *gin.Context, sharingSvc SharingService) {
+	fmt.Println("HandlerToCreateShareLink")
+	var linkRequest CreateShareLinkRequest
+	if err := c.ShouldBindJSON(&linkRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.InvalidJsonBody, err), err))
+		return
+	}
+	if err := validate.Struct(linkRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.RequestSchemaInvalid, err), err))
+		return
+	}
+	ttl := DefaultShareLinkTTL
+	if linkRequest.ExpiresInHours > 0 {
+		ttl = time.Duration(linkRequest.ExpiresInHours) * time.Hour
+	}
+	identity, _ := auth.CurrentIdentity(c)
+	link := ShareLink{
+		Label:              linkRequest.Label,
+		RedactionProfileID: linkRequest.RedactionProfileID,
+		CreatedByUserID:    identity.UserID,
+	}
+	if err := sharingSvc.CreateShareLink(&link, linkRequest.UserIDs, ttl); err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileCreatingShareLink, err), err))
+		return
+	}
+	c.JSON(http.StatusCreated, utils.ResponseMessage{StatusCode: http.StatusCreated, Message: utils.SuccessfullyCreatedShareLink, Data: newShareLinkResponse(c, link)})
+}
+
+// HandlerToGetAllShareLinks godoc
+// @Tags Sharing
+// @Summary Get share links
+// @Description List the share links with their status and how often they were opened
+// @ID get-share-links
+// @Security ApiAuthKey
+// @Produce json
+// @Param   limit    query     int     false  "example - 50"     limit(int)
+// @Param   offset     query     int     false  "example - 0"     offset(int)
+// @Param   orderBy     query     string     false  "example - created_at desc"    orderBy(string)
+// @Success 200 {object} string
+// @Failure 400 {object} string
+// @Failure 500 {object} string
+// @Router /sharing/links [get]
+func HandlerToGetAllShareLinks(c *gin.Context, sharingSvc SharingService) {
+	fmt.Println("HandlerToGetAllShareLinks")
+	page, ok := utils.BindPage(c, utils.DefaultOrderBy, shareLinkSortColumns...)
+	if !ok {
+		return
+	}
+	links, totalRecords, err := sharingSvc.FetchAllShareLinks(page.Limit, page.Offset, page.OrderBy)
+	if err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusInternalServerError, fmt.Sprintf(utils.SomethingWentWrongWhileGettingShareLink, err), err))
+		return
+	}
+	linkResponses := make([]ShareLinkResponse, 0, len(links))
+	for _, link := range links {
+		linkResponses = append(linkResponses, newShareLinkResponse(c, link))
+	}
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.Success, Data: utils.RecordsResponse{Total: totalRecords, RecordsFiltered: len(linkResponses), Data: linkResponses}})
+}
+
+// HandlerToGetShareLinkByID godoc
+// @Tags Sharing
+// @Summary Get share link
+// @Description Get a share link with its status and how often it was opened
+// @ID get-share-link
+// @Security ApiAuthKey
+// @Produce json
+// @Param id path int true "Share link ID"
+// @Success 200 {object} ShareLinkResponse
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /sharing/links/{id} [get]
+func HandlerToGetShareLinkByID(c *gin.Context, sharingSvc SharingService) {
+	fmt.Println("HandlerToGetShareLinkByID")
+	linkID, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
+	link, err := sharingSvc.GetShareLinkById(linkID)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileGettingShareLink, err), err))
+		return
+	}
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.Success, Data: newShareLinkResponse(c, link)})
+}
+
+// HandlerToRevokeShareLinkByID godoc
+// @Tags Sharing
+// @Summary Revoke share link
+// @Description Revoke a share link, its url stops working immediately. The link and its access log are kept
+// @ID revoke-share-link
+// @Security ApiAuthKey
+// @Param id path int true "Share link ID"
+// @Success 200 {object} string
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /sharing/links/{id} [delete]
+func HandlerToRevokeShareLinkByID(c *gin.Context, sharingSvc SharingService) {
+	fmt.Println("HandlerToRevokeShareLinkByID")
+	linkID, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
+	if err := sharingSvc.RevokeShareLink(linkID); err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileRevokingShareLink, err), err))
+		return
+	}
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.SuccessfullyRevokedShareLink, Data: nil})
+}
+
+// HandlerToGetShareLinkAccesses godoc
+// @Tags Sharing
+// @Summary Get share link access log
+// @Description List the requests made with a share link, newest first. Requests after expiry or revocation are listed as not granted
+// @ID get-share-link-accesses
+// @Security ApiAuthKey
+// @Produce json
+// @Param id path int true "Share link ID"
+// @Param   limit    query     int     false  "example - 50"     limit(int)
+// @Param   offset     query     int     false  "example - 0"     offset(int)
+// @Success 200 {object} string
+// @Failure 400 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /sharing/links/{id}/accesses [get]
+func HandlerToGetShareLinkAccesses(c *gin.Context, sharingSvc SharingService) {
+	fmt.Println("HandlerToGetShareLinkAccesses")
+	linkID, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
+	page, ok := utils.BindPage(c, "")
+	if !ok {
+		return
+	}
+	accesses, totalRecords, err := sharingSvc.FetchShareLinkAccesses(linkID, page.Limit, page.Offset)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileGettingShareLink, err), err))
+		return
+	}
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.Success, Data: utils.RecordsResponse{Total: totalRecords, RecordsFiltered: len(accesses), Data: accesses}})
+}
+
+// HandlerToGetSharedProfiles godoc
+// @Tags Sharing
+// @Summary Shared profiles
+// @Description Read only, redacted profiles shared through a link. The token in the url authorizes the request and every request is logged
+// @ID get-shared-profiles
+// @Produce json
+// @Param token path string true "Share link token"
+// @Success 200 {object} SharedProfiles
+// @Failure 404 {object} string
+// @Failure 410 {object} string
+// @Failure 500 {object} string
+// @Router /shared/{token} [get]
+func HandlerToGetSharedProfiles(c *gin.Context, sharingSvc SharingService) {
+	fmt.Println("HandlerToGetSharedProfiles")
+	// the profiles must not end up in shared caches or search engines
+	c.Header("Cache-Control", "no-store")
+	c.Header("X-Robots-Tag", "noindex, nofollow")
+	access := ShareLinkAccess{
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+		Referer:   c.Request.Referer(),
+	}
+	sharedProfiles, err := sharingSvc.GetSharedProfiles(c.Param("token"), access)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			statusCode = http.StatusNotFound
+		case errors.Is(err, ErrShareLinkExpired), errors.Is(err, ErrShareLinkRevoked):
+			statusCode = http.StatusGone
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.ShareLinkUnavailable, err), err))
+		return
+	}
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.Success, Data: sharedProfiles})
+}
+
+func newShareLinkResponse(c *gin.Context, link ShareLink) ShareLinkResponse {
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+	if forwardedProto := c.GetHeader("X-Forwarded-Proto"); forwardedProto != "" {
+		scheme = forwardedProto
+	}
+	return ShareLinkResponse{
+		ShareLink: link,
+		URL:       fmt.Sprintf("%s://%s/shared/%s", scheme, c.Request.Host, link.Token),
+	}
+}
+
+// All requested and response structs
+
+type RedactionProfileRequest struct {
+	Name             string `json:"name" validate:"required,max=100"`
+	HideLastName     bool   `json:"hide_last_name"`
+	HideEmail        bool   `json:"hide_email"`
+	HideMobileNumber bool   `json:"hide_mobile_number"`
+	HideCompany      bool   `json:"hide_company"`
+}
+
+// RedactionProfileUpdateRequest uses pointers so a flag can be switched off, fields left out are not changed
+type RedactionProfileUpdateRequest struct {
+	Name             *string `json:"name" validate:"omitempty,min=1,max=100"`
+	HideLastName     *bool   `json:"hide_last_name"`
+	HideEmail        *bool   `json:"hide_email"`
+	HideMobileNumber *bool   `json:"hide_mobile_number"`
+	HideCompany      *bool   `json:"hide_company"`
+}
+
+func (updateRequest RedactionProfileUpdateRequest) apply(profile *RedactionProfile) {
+	if updateRequest.Name != nil {
+		profile.Name = *updateRequest.Name
+	}
+	if updateRequest.HideLastName != nil {
+		profile.HideLastName = *updateRequest.HideLastName
+	}
+	if updateRequest.HideEmail != nil {
+		profile.HideEmail = *updateRequest.HideEmail
+	}
+	if updateRequest.HideMobileNumber != nil {
+		profile.HideMobileNumber = *updateRequest.HideMobileNumber
+	}
+	if updateRequest.HideCompany != nil {
+		profile.HideCompany = *updateRequest.HideCompany
+	}
+}
+
+type CreateShareLinkRequest struct {
+	Label              string `json:"label" validate:"max=200"`
+	RedactionProfileID uint   `json:"redaction_profile_id" validate:"required"`
+	UserIDs            []uint `json:"user_ids" validate:"required,min=1,max=50,dive,required"`
+	// ExpiresInHours defaults to a week and is limited to 90 days
+	ExpiresInHours int `json:"expires_in_hours" validate:"omitempty,min=1,max=2160"`
+}
+
+type ShareLinkResponse struct {
+	ShareLink
+	URL string `json:"url"`
+}
@@ -0,0 +1,18 @@
+package sharing
+
+// SharingRepository Used to store redaction profiles, share links and their access log
+type SharingRepository interface {
+	createRedactionProfiles(profiles []RedactionProfile) error
+	fetchAllRedactionProfiles() ([]RedactionProfile, error)
+	getRedactionProfileById(id uint) (RedactionProfile, error)
+	createRedactionProfile(profile *RedactionProfile) error
+	updateRedactionProfile(profile RedactionProfile) error
+	deleteRedactionProfileById(id uint) error
+	createShareLink(link *ShareLink) error
+	getShareLinkById(id uint) (ShareLink, error)
+	getShareLinkByToken(token string) (ShareLink, error)
+	fetchAllShareLinks(limit, offset int, orderBy string) ([]ShareLink, int64, error)
+	revokeShareLink(id uint) error
+	recordShareLinkAccess(access *ShareLinkAccess) error
+	fetchShareLinkAccesses(linkID uint, limit, offset int) ([]ShareLinkAccess, int64, error)
+}
@@ -0,0 +1,241 @@
+package sharing
+
+import (
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"time"
+)
+
+type sharingRepositoryPostgres struct {
+	db *gorm.DB
+}
+
+func NewSharingRepositoryPostgres(db *gorm.DB) SharingRepository {
+	log.Print("Successfully connected to postgres in sharing service!")
+
+	return &sharingRepositoryPostgres{
+		db: db,
+	}
+}
+
+// createRedactionProfiles only adds the seeded profiles that are missing by name, changes made by an admin are kept.
+// The ids are left to the sequence so profiles created later through the api do not collide with them.
+func (repo *sharingRepositoryPostgres) createRedactionProfiles(profiles []RedactionProfile) error {
+	for _, profile := range profiles {
+		profile.ID = 0
+		var count int64
+		if err := repo.db.Model(&RedactionProfile{}).Where("LOWER(name) = LOWER(?)", profile.Name).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			continue
+		}
+		if err := repo.db.Create(&profile).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (repo *sharingRepositoryPostgres) fetchAllRedactionProfiles() ([]RedactionProfile, error) {
+	var profiles []RedactionProfile
+	err := repo.db.Order("id asc").Find(&profiles).Error
+	return profiles, err
+}
+
+func (repo *sharingRepositoryPostgres) getRedactionProfileById(id uint) (RedactionProfile, error) {
+	var profile RedactionProfile
+	if err := repo.db.Where("id = ?", id).First(&profile).Error; err != nil {
+		return RedactionProfile{}, err
+	}
+	return profile, nil
+}
+
+func (repo *sharingRepositoryPostgres) createRedactionProfile(profile *RedactionProfile) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := redactionProfileNameIsFree(tx, profile.Name, 0); err != nil {
+			return err
+		}
+		if err := tx.Create(profile).Error; err != nil {
+			return err
+		}
+		fmt.Printf("Redaction profile %d has been stored\n", profile.ID)
+		return nil
+	})
+}
+
+func (repo *sharingRepositoryPostgres) updateRedactionProfile(profile RedactionProfile) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := redactionProfileNameIsFree(tx, profile.Name, profile.ID); err != nil {
+			return err
+		}
+		if err := tx.Save(&profile).Error; err != nil {
+			return err
+		}
+		fmt.Printf("Redaction profile %d has been updated\n", profile.ID)
+		return nil
+	})
+}
+
+// deleteRedactionProfileById refuses to delete a profile that share links still use, even revoked ones,
+// their access log has to stay readable
+func (repo *sharingRepositoryPostgres) deleteRedactionProfileById(id uint) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&ShareLink{}).Where("redaction_profile_id = ?", id).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrRedactionProfileInUse
+		}
+		result := tx.Where("id = ?", id).Delete(&RedactionProfile{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("redaction profile with id %d: %w", id, gorm.ErrRecordNotFound)
+		}
+		fmt.Printf("Redaction profile %d has been deleted\n", id)
+		return nil
+	})
+}
+
+func (repo *sharingRepositoryPostgres) createShareLink(link *ShareLink) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("id = ?", link.RedactionProfileID).First(&RedactionProfile{}).Error; err != nil {
+			return fmt.Errorf("redaction profile with id %d: %w", link.RedactionProfileID, err)
+		}
+		for _, sharedUser := range link.Users {
+			if err := userExists(tx, sharedUser.UserID); err != nil {
+				return err
+			}
+		}
+		if err := tx.Create(link).Error; err != nil {
+			return err
+		}
+		fmt.Printf("Share link %d has been stored\n", link.ID)
+		return nil
+	})
+}
+
+func (repo *sharingRepositoryPostgres) getShareLinkById(id uint) (ShareLink, error) {
+	return repo.getShareLink("id = ?", id)
+}
+
+func (repo *sharingRepositoryPostgres) getShareLinkByToken(token string) (ShareLink, error) {
+	return repo.getShareLink("token = ?", token)
+}
+
+func (repo *sharingRepositoryPostgres) getShareLink(query string, value interface{}) (ShareLink, error) {
+	var link ShareLink
+	err := repo.db.Where(query, value).Preload("RedactionProfile").
+		Preload("Users", func(db *gorm.DB) *gorm.DB { return db.Order("position asc") }).
+		First(&link).Error
+	if err != nil {
+		return ShareLink{}, err
+	}
+	if err := repo.db.Model(&ShareLinkAccess{}).Where("share_link_id = ?", link.ID).Count(&link.AccessCount).Error; err != nil {
+		return ShareLink{}, err
+	}
+	return link, nil
+}
+
+func (repo *sharingRepositoryPostgres) fetchAllShareLinks(limit, offset int, orderBy string) ([]ShareLink, int64, error) {
+	var links []ShareLink
+	var totalRecords int64
+	if err := repo.db.Model(&ShareLink{}).Count(&totalRecords).Error; err != nil {
+		return nil, 0, err
+	}
+	err := repo.db.Preload("RedactionProfile").
+		Preload("Users", func(db *gorm.DB) *gorm.DB { return db.Order("position asc") }).
+		Order(orderBy).Offset(offset).Limit(limit).Find(&links).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(links) == 0 {
+		return links, totalRecords, nil
+	}
+
+	linkIDs := make([]uint, 0, len(links))
+	for _, link := range links {
+		linkIDs = append(linkIDs, link.ID)
+	}
+	var accessCounts []struct {
+		ShareLinkID uint
+		Total       int64
+	}
+	err = repo.db.Model(&ShareLinkAccess{}).Select("share_link_id, COUNT(*) AS total").
+		Where("share_link_id IN ?", linkIDs).Group("share_link_id").Scan(&accessCounts).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	countByLink := make(map[uint]int64, len(accessCounts))
+	for _, accessCount := range accessCounts {
+		countByLink[accessCount.ShareLinkID] = accessCount.Total
+	}
+	for i := range links {
+		links[i].AccessCount = countByLink[links[i].ID]
+	}
+	return links, totalRecords, nil
+}
+
+// revokeShareLink keeps the first revocation time when a link is revoked twice
+func (repo *sharingRepositoryPostgres) revokeShareLink(id uint) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		var link ShareLink
+		if err := tx.Where("id = ?", id).First(&link).Error; err != nil {
+			return err
+		}
+		if link.RevokedAt != nil {
+			return nil
+		}
+		if err := tx.Model(&link).Update("revoked_at", time.Now()).Error; err != nil {
+			return err
+		}
+		fmt.Printf("Share link %d has been revoked\n", id)
+		return nil
+	})
+}
+
+func (repo *sharingRepositoryPostgres) recordShareLinkAccess(access *ShareLinkAccess) error {
+	return repo.db.Create(access).Error
+}
+
+func (repo *sharingRepositoryPostgres) fetchShareLinkAccesses(linkID uint, limit, offset int) ([]ShareLinkAccess, int64, error) {
+	var accesses []ShareLinkAccess
+	var totalRecords int64
+	if err := repo.db.Where("id = ?", linkID).First(&ShareLink{}).Error; err != nil {
+		return nil, 0, err
+	}
+	query := repo.db.Model(&ShareLinkAccess{}).Where("share_link_id = ?", linkID)
+	if err := query.Count(&totalRecords).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := query.Order("created_at desc").Offset(offset).Limit(limit).Find(&accesses).Error; err != nil {
+		return nil, 0, err
+	}
+	return accesses, totalRecords, nil
+}
+
+func redactionProfileNameIsFree(tx *gorm.DB, name string, exceptID uint) error {
+	var count int64
+	if err := tx.Model(&RedactionProfile{}).Where("LOWER(name) = LOWER(?) AND id <> ?", name, exceptID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrRedactionProfileName
+	}
+	return nil
+}
+
+func userExists(tx *gorm.DB, userID uint) error {
+	var count int64
+	if err := tx.Table("users").Where("id = ? AND deleted_at IS NULL", userID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("user with id %d: %w", userID, gorm.ErrRecordNotFound)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package sharing
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	user "github.com/Octek/resource-profile-management-backend.git/api/users"
+	"gorm.io/gorm"
+)
+
+type SharingService struct {
+	sharingRepository SharingRepository
+	userService       user.UserService
+}
+
+func NewService(r SharingRepository, userService user.UserService) SharingService {
+	return SharingService{sharingRepository: r, userService: userService}
+}
+
+func (svc *SharingService) CreateRedactionProfiles(profiles []RedactionProfile) error {
+	return svc.sharingRepository.createRedactionProfiles(profiles)
+}
+func (svc *SharingService) FetchAllRedactionProfiles() ([]RedactionProfile, error) {
+	return svc.sharingRepository.fetchAllRedactionProfiles()
+}
+func (svc *SharingService) GetRedactionProfileById(id uint) (RedactionProfile, error) {
+	return svc.sharingRepository.getRedactionProfileById(id)
+}
+func (svc *SharingService) CreateRedactionProfile(profile *RedactionProfile) error {
+	return svc.sharingRepository.createRedactionProfile(profile)
+}
+func (svc *SharingService) UpdateRedactionProfile(profile RedactionProfile) error {
+	return svc.sharingRepository.updateRedactionProfile(profile)
+}
+func (svc *SharingService) DeleteRedactionProfileById(id uint) error {
+	return svc.sharingRepository.deleteRedactionProfileById(id)
+}
+func (svc *SharingService) GetShareLinkById(id uint) (ShareLink, error) {
+	link, err := svc.sharingRepository.getShareLinkById(id)
+	link.Status = link.StatusAt(time.Now())
+	return link, err
+}
+func (svc *SharingService) FetchAllShareLinks(limit, offset int, orderBy string) ([]ShareLink, int64, error) {
+	links, totalRecords, err := svc.sharingRepository.fetchAllShareLinks(limit, offset, orderBy)
+	now := time.Now()
+	for i := range links {
+		links[i].Status = links[i].StatusAt(now)
+	}
+	return links, totalRecords, err
+}
+func (svc *SharingService) RevokeShareLink(id uint) error {
+	return svc.sharingRepository.revokeShareLink(id)
+}
+func (svc *SharingService) FetchShareLinkAccesses(linkID uint, limit, offset int) ([]ShareLinkAccess, int64, error) {
+	return svc.sharingRepository.fetchShareLinkAccesses(linkID, limit, offset)
+}
+
+// CreateShareLink shares the users in the given order, the token is only readable by admins afterwards
+func (svc *SharingService) CreateShareLink(link *ShareLink, userIDs []uint, ttl time.Duration) error {
+	tokenBytes := make([]byte, 32)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return err
+	}
+	link.Token = hex.EncodeToString(tokenBytes)
+	link.ExpiresAt = time.Now().Add(ttl)
+	link.Users = nil
+	seen := make(map[uint]bool, len(userIDs))
+	for _, userID := range userIDs {
+		if seen[userID] {
+			continue
+		}
+		seen[userID] = true
+		link.Users = append(link.Users, ShareLinkUser{UserID: userID, Position: len(link.Users)})
+	}
+	if err := svc.sharingRepository.createShareLink(link); err != nil {
+		return err
+	}
+	link.Status = ShareLinkStatusActive
+	return nil
+}
+
+// GetSharedProfiles logs every request made with a known token, also the ones refused after expiry or revocation.
+// Users deleted after the link was created are left out.
+func (svc *SharingService) GetSharedProfiles(token string, access ShareLinkAccess) (SharedProfiles, error) {
+	link, err := svc.sharingRepository.getShareLinkByToken(token)
+	if err != nil {
+		return SharedProfiles{}, err
+	}
+	status := link.StatusAt(time.Now())
+	access.ShareLinkID = link.ID
+	access.Granted = status == ShareLinkStatusActive
+	if err := svc.sharingRepository.recordShareLinkAccess(&access); err != nil {
+		return SharedProfiles{}, err
+	}
+	switch status {
+	case ShareLinkStatusRevoked:
+		return SharedProfiles{}, ErrShareLinkRevoked
+	case ShareLinkStatusExpired:
+		return SharedProfiles{}, ErrShareLinkExpired
+	}
+
+	sharedProfiles := SharedProfiles{Label: link.Label, ExpiresAt: link.ExpiresAt, Profiles: []SharedProfile{}}
+	for _, userID := range link.UserIDs() {
+		userObj, err := svc.userService.GetUserDetailsByUserId(userID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			continue
+		}
+		if err != nil {
+			return SharedProfiles{}, err
+		}
+		reference := len(sharedProfiles.Profiles) + 1
+		sharedProfiles.Profiles = append(sharedProfiles.Profiles, NewSharedProfile(reference, *userObj, *link.RedactionProfile))
+	}
+	return sharedProfiles, nil
+}
@@ -0,0 +1,199 @@
+package sharing
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	user "github.com/Octek/resource-profile-management-backend.git/api/users"
+)
+
+const (
+	redactedText    = "[redacted]"
+	redactedCompany = "Confidential"
+	// values shorter than this are not scrubbed from free text, they would match inside ordinary words
+	minScrubLength = 2
+)
+
+// SharedProfiles is what the holder of a share link sees
+type SharedProfiles struct {
+	Label     string          `json:"label"`
+	ExpiresAt time.Time       `json:"expires_at"`
+	Profiles  []SharedProfile `json:"profiles"`
+}
+
+// SharedProfile is the read only, redacted view of a user. Internal ids, bookings and roles are never part of it.
+type SharedProfile struct {
+	Reference      int                `json:"reference"`
+	FirstName      string             `json:"first_name"`
+	LastName       string             `json:"last_name,omitempty"`
+	Email          string             `json:"email,omitempty"`
+	MobileNumber   string             `json:"mobile_number,omitempty"`
+	JobTitle       string             `json:"job_title"`
+	Category       string             `json:"category"`
+	Location       string             `json:"location"`
+	Bio            string             `json:"bio"`
+	VideoUrl       string             `json:"video_url"`
+	Certifications string             `json:"certifications"`
+	Skills         []SharedSkill      `json:"skills"`
+	Experiences    []SharedExperience `json:"experiences"`
+	Educations     []SharedEducation  `json:"educations"`
+	Projects       []SharedProject    `json:"projects"`
+}
+
+type SharedSkill struct {
+	Name     string `json:"name"`
+	Category string `json:"category"`
+}
+
+type SharedExperience struct {
+	Position           string    `json:"position"`
+	Company            string    `json:"company"`
+	Description        string    `json:"description"`
+	StartDate          time.Time `json:"start_date"`
+	EndDate            time.Time `json:"end_date"`
+	IsCurrentlyWorking bool      `json:"is_currently_working"`
+	Responsibilities   []string  `json:"responsibilities"`
+}
+
+type SharedEducation struct {
+	InstitutionName string    `json:"institution_name"`
+	Degree          string    `json:"degree"`
+	FieldOfStudy    string    `json:"field_of_study"`
+	Achievements    string    `json:"achievements"`
+	StartDate       time.Time `json:"start_date"`
+	EndDate         time.Time `json:"end_date"`
+}
+
+type SharedProject struct {
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	Technologies string `json:"technologies"`
+	Link         string `json:"link"`
+}
+
+// NewSharedProfile applies the redaction profile to a user. Hidden values are also scrubbed
+// from the free text fields, a bio often repeats the full name or the current employer.
+func NewSharedProfile(reference int, userObj user.User, profile RedactionProfile) SharedProfile {
+	scrub := newScrubber(hiddenValues(userObj, profile))
+	shared := SharedProfile{
+		Reference:      reference,
+		FirstName:      userObj.FirstName,
+		JobTitle:       userObj.JobTitle,
+		Location:       userObj.Location,
+		Bio:            scrub(userObj.Bio),
+		VideoUrl:       userObj.VideoUrl,
+		Certifications: scrub(userObj.Certifications),
+		Skills:         []SharedSkill{},
+		Experiences:    []SharedExperience{},
+		Educations:     []SharedEducation{},
+		Projects:       []SharedProject{},
+	}
+	if !profile.HideLastName {
+		shared.LastName = userObj.LastName
+	}
+	if !profile.HideEmail {
+		shared.Email = userObj.Email
+	}
+	if !profile.HideMobileNumber {
+		shared.MobileNumber = userObj.MobileNumber
+	}
+	if userObj.UserCategory != nil {
+		shared.Category = userObj.UserCategory.Name
+	}
+
+	for _, skillObj := range userObj.Skills {
+		sharedSkill := SharedSkill{Name: skillObj.Name}
+		if skillObj.SkillCategory != nil {
+			sharedSkill.Category = skillObj.SkillCategory.Name
+		}
+		shared.Skills = append(shared.Skills, sharedSkill)
+	}
+	for _, experienceObj := range userObj.Experiences {
+		company := experienceObj.Company
+		if profile.HideCompany && company != "" {
+			company = redactedCompany
+		}
+		responsibilities := make([]string, 0, len(experienceObj.Responsibilities))
+		for _, responsibility := range experienceObj.Responsibilities {
+			if responsibility != "" {
+				responsibilities = append(responsibilities, scrub(responsibility))
+			}
+		}
+		shared.Experiences = append(shared.Experiences, SharedExperience{
+			Position:           experienceObj.Position,
+			Company:            company,
+			Description:        scrub(experienceObj.Description),
+			StartDate:          experienceObj.StartDate,
+			EndDate:            experienceObj.EndDate,
+			IsCurrentlyWorking: experienceObj.IsCurrentlyWorking,
+			Responsibilities:   responsibilities,
+		})
+	}
+	sort.SliceStable(shared.Experiences, func(i, j int) bool {
+		return shared.Experiences[i].StartDate.After(shared.Experiences[j].StartDate)
+	})
+	for _, educationObj := range userObj.Educations {
+		shared.Educations = append(shared.Educations, SharedEducation{
+			InstitutionName: educationObj.InstitutionName,
+			Degree:          educationObj.Degree,
+			FieldOfStudy:    educationObj.FieldOfStudy,
+			Achievements:    scrub(educationObj.Achievements),
+			StartDate:       educationObj.StartDate,
+			EndDate:         educationObj.EndDate,
+		})
+	}
+	for _, projectObj := range userObj.Projects {
+		shared.Projects = append(shared.Projects, SharedProject{
+			Name:         projectObj.Name,
+			Description:  scrub(projectObj.Description),
+			Technologies: projectObj.Technologies,
+			Link:         projectObj.Link,
+		})
+	}
+	return shared
+}
+
+func hiddenValues(userObj user.User, profile RedactionProfile) []string {
+	var values []string
+	if profile.HideLastName {
+		values = append(values, userObj.LastName)
+	}
+	if profile.HideEmail {
+		values = append(values, userObj.Email)
+	}
+	if profile.HideMobileNumber {
+		values = append(values, userObj.MobileNumber)
+	}
+	if profile.HideCompany {
+		for _, experienceObj := range userObj.Experiences {
+			values = append(values, experienceObj.Company)
+		}
+	}
+	return values
+}
+
+// newScrubber replaces whole word, case insensitive occurrences of the values in a text
+func newScrubber(values []string) func(string) string {
+	var patterns []string
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if len([]rune(value)) >= minScrubLength {
+			patterns = append(patterns, regexp.QuoteMeta(value))
+		}
+	}
+	if len(patterns) == 0 {
+		return func(text string) string { return text }
+	}
+	// longest first so a company name wins over a shorter value contained in it
+	sort.Slice(patterns, func(i, j int) bool { return len(patterns[i]) > len(patterns[j]) })
+	expression := regexp.MustCompile(`(?i)(^|[^\p{L}\p{N}])(` + strings.Join(patterns, "|") + `)($|[^\p{L}\p{N}])`)
+	return func(text string) string {
+		// matches share their boundary characters, a second pass catches values that are only one character apart
+		for i := 0; i < 2; i++ {
+			text = expression.ReplaceAllString(text, "${1}"+redactedText+"${3}")
+		}
+		return text
+	}
+}
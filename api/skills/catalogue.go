@@ -0,0 +1,33 @@
+package skills
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrEmptySkillName       = errors.New("skill name is empty")
+	ErrDuplicateSkill       = errors.New("a skill with this name already exists in the category")
+	ErrSkillAlreadyAssigned = errors.New("skill is already assigned to the user")
+	ErrAliasTaken           = errors.New("the alias is already the name or an alias of a skill")
+	ErrSkillHierarchyCycle  = errors.New("a skill cannot be placed below itself or one of its descendants")
+)
+
+// SkillQuery selects skills of the catalogue by id or by a name, which also matches the aliases of a skill.
+// With IncludeDescendants the skills below the selected ones are selected as well.
+type SkillQuery struct {
+	SkillID            uint
+	Name               string
+	IncludeDescendants bool
+}
+
+// NormalizeSkillName is the name a skill is unique by within its category, "  Go " and "go" are the same skill
+func NormalizeSkillName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// normalizedSkillNameSQL is NormalizeSkillName as a SQL expression
+func normalizedSkillNameSQL(column string) string {
+	return fmt.Sprintf(`LOWER(TRIM(REGEXP_REPLACE(%s, '\s+', ' ', 'g')))`, column)
+}
@@ -0,0 +1,41 @@
+package skills
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrSelfEndorsement = errors.New("users cannot endorse their own skills")
+	ErrAlreadyEndorsed = errors.New("the skill is already endorsed by this user")
+)
+
+// SkillEndorsement is a colleague vouching for a skill of a user, a user endorses a UserSkill at most once
+type SkillEndorsement struct {
+	ID             uint      `json:"id" gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
+	UserSkillID    uint      `json:"user_skill_id" gorm:"NOT NULL;uniqueIndex:idx_skill_endorsements_user_skill_endorser"`
+	EndorserUserID uint      `json:"endorser_user_id" gorm:"NOT NULL;uniqueIndex:idx_skill_endorsements_user_skill_endorser;index"`
+	Comment        string    `json:"comment"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// Endorsement is an endorsement as listed on a profile, with the skill and the colleague who gave it
+type Endorsement struct {
+	ID                uint      `json:"id"`
+	UserID            uint      `json:"user_id"`
+	SkillID           uint      `json:"skill_id"`
+	SkillName         string    `json:"skill_name"`
+	EndorserUserID    uint      `json:"endorser_user_id"`
+	EndorserFirstName string    `json:"endorser_first_name"`
+	EndorserLastName  string    `json:"endorser_last_name"`
+	EndorserJobTitle  string    `json:"endorser_job_title"`
+	Comment           string    `json:"comment"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+// EndorsementCountSQL counts the endorsements of the skills of the user in column, for ranking users by them
+func EndorsementCountSQL(column string) string {
+	return "(SELECT COUNT(*) FROM skill_endorsements JOIN user_skills ON user_skills.id = skill_endorsements.user_skill_id " +
+		"WHERE user_skills.user_id = " + column + ")"
+}
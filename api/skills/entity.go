@@ -8,16 +8,34 @@ import (
 	"time"
 )
 
+// Skill is an entry of the skill catalogue, users share the skills they have in common through UserSkill.
+// NormalizedName is unique within a category for the skills that are not deleted. Besides its category a skill
+// can sit below a broader skill, "Kubernetes" under "Container Orchestration".
+// EndorsementCount is only set where the skill is listed as a skill of a user.
 type Skill struct {
-	ID              uint               `json:"id" gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
-	Name            string             `json:"name"`
-	Icon            string             `json:"icon"`
-	SkillCategoryID uint               `json:"skill_category_id" gorm:"NOT NULL;index:skill_category_id"`
-	SkillCategory   *SkillCategory     `json:"skill_category" gorm:"foreignKey:SkillCategoryID;references:ID"`
-	Bookings        []bookings.Booking `json:"bookings" gorm:"many2many:booking_skills;"`
-	DeletedAt       gorm.DeletedAt     `json:"deleted_at"`
-	CreatedAt       time.Time          `json:"created_at"`
-	UpdatedAt       time.Time          `json:"updated_at"`
+	ID               uint               `json:"id" gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
+	Name             string             `json:"name"`
+	NormalizedName   string             `json:"normalized_name" gorm:"NOT NULL;default:''"`
+	Icon             string             `json:"icon"`
+	SkillCategoryID  uint               `json:"skill_category_id" gorm:"NOT NULL;index:skill_category_id"`
+	SkillCategory    *SkillCategory     `json:"skill_category" gorm:"foreignKey:SkillCategoryID;references:ID"`
+	ParentID         *uint              `json:"parent_id" gorm:"index"`
+	Aliases          []SkillAlias       `json:"aliases" gorm:"foreignKey:SkillID"`
+	Bookings         []bookings.Booking `json:"bookings" gorm:"many2many:booking_skills;"`
+	EndorsementCount *int64             `json:"endorsement_count,omitempty" gorm:"-"`
+	DeletedAt        gorm.DeletedAt     `json:"deleted_at"`
+	CreatedAt        time.Time          `json:"created_at"`
+	UpdatedAt        time.Time          `json:"updated_at"`
+}
+
+// SkillAlias is another name of a skill, "golang" for "Go". An alias names one skill across all categories
+// and cannot be the name of another skill.
+type SkillAlias struct {
+	ID              uint      `json:"id" gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
+	SkillID         uint      `json:"skill_id" gorm:"NOT NULL;index"`
+	Alias           string    `json:"alias"`
+	NormalizedAlias string    `json:"-" gorm:"NOT NULL;uniqueIndex"`
+	CreatedAt       time.Time `json:"created_at"`
 }
 
 type SkillCategory struct {
@@ -27,13 +45,62 @@ type SkillCategory struct {
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+// UserSkill is a skill of a user with its proficiency on the 1 to 5 scale, 0 when the user did not rate it.
+// A verified assessment was confirmed by an admin, any change by the user makes it a self assessment again.
 type UserSkill struct {
-	ID         uint      `json:"id" gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
-	UserID     uint      `json:"user_id" gorm:"NOT NULL;index:user_id"`
-	SkillID    uint      `json:"skill_id" gorm:"NOT NULL;index:skill_id"`
-	SkillLevel string    `json:"skill_level"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
+	ID      uint `json:"id" gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
+	UserID  uint `json:"user_id" gorm:"NOT NULL;index:user_id"`
+	SkillID uint `json:"skill_id" gorm:"NOT NULL;index:skill_id"`
+	// SkillLevel is the label of the proficiency, kept for the clients reading the former free text level
+	SkillLevel       string     `json:"skill_level"`
+	Proficiency      int        `json:"proficiency" gorm:"NOT NULL;default:0"`
+	YearsOfUse       float64    `json:"years_of_use" gorm:"NOT NULL;default:0"`
+	LastUsedOn       *time.Time `json:"last_used_on" gorm:"type:date"`
+	Assessment       string     `json:"assessment" gorm:"NOT NULL;default:self"`
+	VerifiedByUserID *uint      `json:"verified_by_user_id"`
+	VerifiedAt       *time.Time `json:"verified_at"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// UserSkillProficiency is a skill of a user together with how well the user knows it
+type UserSkillProficiency struct {
+	Skill     Skill     `json:"skill"`
+	UserSkill UserSkill `json:"user_skill"`
+}
+
+// SkillLevelDistribution counts the users per proficiency level of a skill of the catalogue. For a whole category
+// the levels count the skills of its users.
+type SkillLevelDistribution struct {
+	SkillID            uint         `json:"skill_id,omitempty"`
+	SkillName          string       `json:"skill_name,omitempty"`
+	SkillCategoryID    uint         `json:"skill_category_id"`
+	SkillCategory      string       `json:"skill_category"`
+	Users              int64        `json:"users"`
+	Rated              int64        `json:"rated"`
+	Verified           int64        `json:"verified"`
+	AverageProficiency float64      `json:"average_proficiency"`
+	Levels             []LevelCount `json:"levels"`
+}
+
+type LevelCount struct {
+	Level int    `json:"level"`
+	Label string `json:"label"`
+	Count int64  `json:"count"`
+}
+
+// ProficiencyUpdate is a new rating of a skill of a user, VerifiedByUserID is zero for a self assessment
+type ProficiencyUpdate struct {
+	Proficiency      int
+	YearsOfUse       float64
+	LastUsedOn       *time.Time
+	VerifiedByUserID uint
+}
+
+// DistributionFilter narrows the skills of a level distribution, zero values do not filter
+type DistributionFilter struct {
+	SkillCategoryID uint
+	Keyword         string
 }
 
 func asSha256SkillCategory(category SkillCategory) string {
@@ -1,51 +1,112 @@
 package skills
 
 import (
+	"errors"
 	"fmt"
+	"github.com/Octek/resource-profile-management-backend.git/api/auth"
 	"github.com/Octek/resource-profile-management-backend.git/utils"
 	"github.com/gin-gonic/gin"
-	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+	"io"
 	"net/http"
 	"strconv"
+	"time"
 )
 
-var validate = validator.New()
+const lastUsedOnLayout = "2006-01-02"
 
-// Routes Exports all routes handled by this service
-func Routes(router *gin.Engine, skillSvc SkillService) {
+var validate = utils.NewValidator()
+
+// the columns the lists can be ordered by
+var (
+	skillSortColumns         = []string{"id", "name", "skill_category_id", "parent_id", "created_at", "updated_at"}
+	skillCategorySortColumns = []string{"id", "name", "created_at", "updated_at"}
+)
+
+// Routes Exports all routes handled by this service, every route declares who may call it
+func Routes(router gin.IRouter, skillSvc SkillService) {
 	skillsRouter := router.Group("/skills")
 	categoriesRouter := skillsRouter.Group("/categories")
 	{
-		categoriesRouter.POST("", func(c *gin.Context) {
+		categoriesRouter.POST("", auth.Authorize(auth.AllowAdmin), func(c *gin.Context) {
 			HandlerToCreateSkillCategories(c, skillSvc)
 		})
-		categoriesRouter.PATCH("/:id", func(c *gin.Context) {
+		categoriesRouter.PATCH("/:id", auth.Authorize(auth.AllowAdmin), func(c *gin.Context) {
 			HandlerToUpdateSkillCategoryByID(c, skillSvc)
 		})
-		categoriesRouter.GET("", func(c *gin.Context) {
+		categoriesRouter.GET("", auth.Authorize(auth.AllowAuthenticated), func(c *gin.Context) {
 			HandlerToGetAllSkillCategories(c, skillSvc)
 		})
-		categoriesRouter.GET("/:id", func(c *gin.Context) {
+		categoriesRouter.GET("/:id", auth.Authorize(auth.AllowAuthenticated), func(c *gin.Context) {
 			HandlerToGetSkillCategoryByID(c, skillSvc)
 		})
-		categoriesRouter.DELETE("/:id", func(c *gin.Context) {
+		categoriesRouter.DELETE("/:id", auth.Authorize(auth.AllowAdmin), func(c *gin.Context) {
 			HandlerToDeleteSkillCategoryByID(c, skillSvc)
 		})
 
 	}
-	skillsRouter.POST("", func(c *gin.Context) {
+	skillsRouter.GET("/proficiency-levels", auth.Authorize(auth.AllowAuthenticated), func(c *gin.Context) {
+		HandlerToGetProficiencyLevels(c)
+	})
+	skillsRouter.GET("/proficiency/distribution", auth.Authorize(auth.AllowAuthenticated), func(c *gin.Context) {
+		HandlerToGetSkillLevelDistribution(c, skillSvc)
+	})
+	skillsRouter.GET("/proficiency/distribution/categories", auth.Authorize(auth.AllowAuthenticated), func(c *gin.Context) {
+		HandlerToGetCategoryLevelDistribution(c, skillSvc)
+	})
+	skillsRouter.GET("/user/:userId/proficiency", auth.Authorize(auth.AllowAuthenticated), func(c *gin.Context) {
+		HandlerToGetUserSkillProficiencies(c, skillSvc)
+	})
+	skillsRouter.GET("/:id/children", auth.Authorize(auth.AllowAuthenticated), func(c *gin.Context) {
+		HandlerToGetSkillChildren(c, skillSvc)
+	})
+	skillsRouter.PUT("/:id/parent", auth.Authorize(auth.AllowAdmin), func(c *gin.Context) {
+		HandlerToSetSkillParent(c, skillSvc)
+	})
+	skillsRouter.POST("/:id/aliases", auth.Authorize(auth.AllowAdmin), func(c *gin.Context) {
+		HandlerToAddSkillAlias(c, skillSvc)
+	})
+	skillsRouter.DELETE("/:id/aliases/:aliasId", auth.Authorize(auth.AllowAdmin), func(c *gin.Context) {
+		HandlerToRemoveSkillAlias(c, skillSvc)
+	})
+	skillsRouter.GET("/user/:userId/endorsements", auth.Authorize(auth.AllowAuthenticated), func(c *gin.Context) {
+		HandlerToGetUserEndorsements(c, skillSvc)
+	})
+	skillsRouter.GET("/:id/user/:userId/endorsements", auth.Authorize(auth.AllowAuthenticated), func(c *gin.Context) {
+		HandlerToGetUserSkillEndorsements(c, skillSvc)
+	})
+	skillsRouter.POST("/:id/user/:userId/endorsements", auth.Authorize(auth.AllowAuthenticated), func(c *gin.Context) {
+		HandlerToEndorseSkill(c, skillSvc)
+	})
+	skillsRouter.DELETE("/endorsements/:endorsementId", auth.Authorize(auth.AllowAdmin, auth.AllowOwner(skillSvc.isEndorser)), func(c *gin.Context) {
+		HandlerToDeleteEndorsement(c, skillSvc)
+	})
+	skillsRouter.PUT("/user/:userId", auth.Authorize(auth.AllowAdmin, auth.AllowSelf(auth.UserIDFromParam("userId"))), func(c *gin.Context) {
+		HandlerToSetUserSkills(c, skillSvc)
+	})
+	skillsRouter.POST("/:id/user/:userId", auth.Authorize(auth.AllowAdmin, auth.AllowSelf(auth.UserIDFromParam("userId"))), func(c *gin.Context) {
+		HandlerToAssignSkillToUser(c, skillSvc)
+	})
+	skillsRouter.DELETE("/:id/user/:userId", auth.Authorize(auth.AllowAdmin, auth.AllowSelf(auth.UserIDFromParam("userId"))), func(c *gin.Context) {
+		HandlerToUnassignSkillFromUser(c, skillSvc)
+	})
+	skillsRouter.PUT("/:id/user/:userId/proficiency", auth.Authorize(auth.AllowAdmin, auth.AllowSelf(auth.UserIDFromParam("userId"))), func(c *gin.Context) {
+		HandlerToUpdateUserSkillProficiency(c, skillSvc)
+	})
+	skillsRouter.POST("", auth.Authorize(auth.AllowAdmin, auth.AllowSelf(auth.UserIDFromJSONBody("user_id"))), func(c *gin.Context) {
 		HandlerToCreateSkill(c, skillSvc)
 	})
-	skillsRouter.PATCH("/:id", func(c *gin.Context) {
+	// the catalogue is shared by all users, users change their own skills through the assignment routes
+	skillsRouter.PATCH("/:id", auth.Authorize(auth.AllowAdmin), func(c *gin.Context) {
 		HandlerToUpdateSkillByID(c, skillSvc)
 	})
-	skillsRouter.GET("", func(c *gin.Context) {
+	skillsRouter.GET("", auth.Authorize(auth.AllowAuthenticated), func(c *gin.Context) {
 		HandlerToGetAllSkills(c, skillSvc)
 	})
-	skillsRouter.GET("/:id", func(c *gin.Context) {
+	skillsRouter.GET("/:id", auth.Authorize(auth.AllowAuthenticated), func(c *gin.Context) {
 		HandlerToGetSkillByID(c, skillSvc)
 	})
-	skillsRouter.DELETE("/:id", func(c *gin.Context) {
+	skillsRouter.DELETE("/:id", auth.Authorize(auth.AllowAdmin), func(c *gin.Context) {
 		HandlerToDeleteSkillByID(c, skillSvc)
 	})
 }
@@ -61,7 +122,8 @@ func Routes(router *gin.Engine, skillSvc SkillService) {
 // @Param   limit    query     int     false  "example - 50"     limit(int)
 // @Param   offset     query     int     false  "example - 0"     offset(int)
 // @Param   orderBy     query     string     false  "example - created_at desc,updated_at desc"    orderBy(string)
-// @Param   keyword   query   string  false  "Search for a keyword in skill names"
+// @Param   keyword   query   string  false  "Search for a keyword in skill names and aliases"
+// @Param   includeDescendants   query   bool  false  "Also list the skills below the matching skills, example - false"
 // @Success 200 {object} string
 // @Failure 400 {object} string
 // @Failure 404 {object} string
@@ -70,34 +132,25 @@ func Routes(router *gin.Engine, skillSvc SkillService) {
 func HandlerToGetAllSkills(c *gin.Context, skillSvc SkillService) {
 	fmt.Println("HandlerToGetAllSkills")
 	baseQuery := c.Request.URL.Query()
-	limit := baseQuery.Get("limit")
-	offset := baseQuery.Get("offset")
-	orderBy := baseQuery.Get("orderBy")
 	keyword := baseQuery.Get("keyword")
+	includeDescendants := baseQuery.Get("includeDescendants")
 
-	if limit == "" {
-		limit = utils.DefaultLimit
-	}
-	if offset == "" {
-		offset = utils.DefaultOffset
-	}
-	if orderBy == "" {
-		orderBy = utils.DefaultOrderBy
+	if includeDescendants == "" {
+		includeDescendants = "false"
 	}
 
-	limitInt, err := strconv.Atoi(limit)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, utils.ResponseMessage{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf(utils.InvalidIntegerValueLimitMessage, err), Data: nil})
+	page, ok := utils.BindPage(c, utils.DefaultOrderBy, skillSortColumns...)
+	if !ok {
 		return
 	}
-	offsetInt, err := strconv.Atoi(offset)
+	includeDescendantsBool, err := strconv.ParseBool(includeDescendants)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, utils.ResponseMessage{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf(utils.InvalidIntegerValueOffsetMessage, err), Data: nil})
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.InvalidBooleanValueMessage, "includeDescendants", err), err))
 		return
 	}
-	skillList, totalRecords, err := skillSvc.FetchAllSkill(limitInt, offsetInt, orderBy, keyword)
+	skillList, totalRecords, err := skillSvc.FetchAllSkill(page.Limit, page.Offset, page.OrderBy, keyword, includeDescendantsBool)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, utils.ResponseMessage{StatusCode: http.StatusInternalServerError, Message: fmt.Sprintf(utils.SomethingWentWrongWhileGettingSkill, err), Data: nil})
+		utils.AbortWithError(c, utils.NewError(http.StatusInternalServerError, fmt.Sprintf(utils.SomethingWentWrongWhileGettingSkill, err), err))
 		return
 	}
 	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.Success, Data: utils.RecordsResponse{Total: totalRecords, RecordsFiltered: len(skillList), Data: skillList}})
@@ -119,15 +172,13 @@ func HandlerToGetAllSkills(c *gin.Context, skillSvc SkillService) {
 // @Router /skills/{id} [delete]
 func HandlerToDeleteSkillByID(c *gin.Context, skillSvc SkillService) {
 	fmt.Println("HandlerToDeleteSkillByID")
-	skillID := c.Param("id")
-	skillIDInt, err := strconv.Atoi(skillID)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, utils.ResponseMessage{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf(utils.SomethingWentWrong, err), Data: nil})
+	skillID, ok := utils.BindID(c, "id")
+	if !ok {
 		return
 	}
-	err = skillSvc.DeleteSkillById(uint(skillIDInt))
+	err := skillSvc.DeleteSkillById(skillID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, utils.ResponseMessage{StatusCode: http.StatusInternalServerError, Message: fmt.Sprintf(utils.SomethingWentWrongWhileDeletingSkill, err), Data: nil})
+		utils.AbortWithError(c, utils.NewError(http.StatusInternalServerError, fmt.Sprintf(utils.SomethingWentWrongWhileDeletingSkill, err), err))
 		return
 	}
 
@@ -150,15 +201,13 @@ func HandlerToDeleteSkillByID(c *gin.Context, skillSvc SkillService) {
 // @Router /skills/{id} [get]
 func HandlerToGetSkillByID(c *gin.Context, skillSvc SkillService) {
 	fmt.Println("HandlerToGetSkillByID")
-	skillID := c.Param("id")
-	skillIDInt, err := strconv.Atoi(skillID)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, utils.ResponseMessage{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf(utils.SomethingWentWrong, err), Data: nil})
+	skillID, ok := utils.BindID(c, "id")
+	if !ok {
 		return
 	}
-	fetchedSkill, err := skillSvc.GetSkillById(uint(skillIDInt))
+	fetchedSkill, err := skillSvc.GetSkillById(skillID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, utils.ResponseMessage{StatusCode: http.StatusInternalServerError, Message: fmt.Sprintf(utils.SomethingWentWrongWhileGettingSkill, err), Data: nil})
+		utils.AbortWithError(c, utils.NewError(http.StatusInternalServerError, fmt.Sprintf(utils.SomethingWentWrongWhileGettingSkill, err), err))
 		return
 	}
 
@@ -168,7 +217,7 @@ func HandlerToGetSkillByID(c *gin.Context, skillSvc SkillService) {
 // HandlerToUpdateSkillByID godoc
 // @Tags Skills
 // @Summary Update skill
-// @Description Update skill
+// @Description Update a skill of the catalogue, the name has to stay unique within the category
 // @ID update-skill
 // @Security ApiAuthKey
 // @Accept json
@@ -177,35 +226,41 @@ func HandlerToGetSkillByID(c *gin.Context, skillSvc SkillService) {
 // @Success 200 {object} string
 // @Failure 400 {object} string
 // @Failure 404 {object} string
+// @Failure 409 {object} string
 // @Failure 500 {object} string
 // @Router /skills/{id} [patch]
 func HandlerToUpdateSkillByID(c *gin.Context, skillSvc SkillService) {
 	fmt.Println("HandlerToUpdateSkillByID")
-	skillID := c.Param("id")
-	skillIDInt, err := strconv.Atoi(skillID)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, utils.ResponseMessage{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf(utils.SomethingWentWrong, err), Data: nil})
+	skillID, ok := utils.BindID(c, "id")
+	if !ok {
 		return
 	}
 
 	var updateSkillRequest SkillRequest
 	if err := c.ShouldBind(&updateSkillRequest); err != nil {
-		c.JSON(http.StatusBadRequest, utils.ResponseMessage{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf(utils.InvalidJsonBody, err), Data: nil})
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.InvalidJsonBody, err), err))
 		return
 	}
 	if err := validate.Struct(updateSkillRequest); err != nil {
-		c.JSON(http.StatusBadRequest, utils.ResponseMessage{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf(utils.RequestSchemaInvalid, err), Data: nil})
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.RequestSchemaInvalid, err), err))
 		return
 	}
-	fetchedSkill, err := skillSvc.GetSkillById(uint(skillIDInt))
+	fetchedSkill, err := skillSvc.GetSkillById(skillID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, utils.ResponseMessage{StatusCode: http.StatusInternalServerError, Message: fmt.Sprintf(utils.SomethingWentWrongWhileGettingSkill, err), Data: nil})
+		utils.AbortWithError(c, utils.NewError(http.StatusInternalServerError, fmt.Sprintf(utils.SomethingWentWrongWhileGettingSkill, err), err))
 		return
 	}
 	utils.UpdateEntity(&fetchedSkill, updateSkillRequest)
 	err = skillSvc.UpdateSkill(fetchedSkill)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, utils.ResponseMessage{StatusCode: http.StatusInternalServerError, Message: fmt.Sprintf(utils.SomethingWentWrongWhileUpdatingSkill, err), Data: nil})
+		statusCode := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, ErrEmptySkillName):
+			statusCode = http.StatusBadRequest
+		case errors.Is(err, ErrDuplicateSkill), errors.Is(err, ErrAliasTaken):
+			statusCode = http.StatusConflict
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileUpdatingSkill, err), err))
 		return
 	}
 	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.SuccessfullyUpdatedSkill, Data: nil})
@@ -215,7 +270,8 @@ func HandlerToUpdateSkillByID(c *gin.Context, skillSvc SkillService) {
 // HandlerToCreateSkill godoc
 // @Tags Skills
 // @Summary Create skills
-// @Description Create skills
+// @Description Add a skill to the catalogue and assign it to the user of user_id. A skill with the same name in the
+// @Description category is not created again, the existing skill is assigned. Only admins can create a skill without a user.
 // @ID Create-skills
 // @Security ApiAuthKey
 // @Accept json
@@ -224,18 +280,24 @@ func HandlerToUpdateSkillByID(c *gin.Context, skillSvc SkillService) {
 // @Success 200 {object} utils.ResponseMessage
 // @Failure 400 {object} utils.ResponseMessage
 // @Failure 404 {object} utils.ResponseMessage
+// @Failure 409 {object} utils.ResponseMessage
 // @Failure 500 {object} utils.ResponseMessage
 // @Router /skills [post]
 func HandlerToCreateSkill(c *gin.Context, skillSvc SkillService) {
 	fmt.Println("HandlerToCreateSkills")
 	var createUserSkillRequest UserSkillRequest
 	if err := c.ShouldBind(&createUserSkillRequest); err != nil {
-		c.JSON(http.StatusBadRequest, utils.ResponseMessage{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf(utils.InvalidJsonBody, err), Data: nil})
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.InvalidJsonBody, err), err))
 		return
 	}
 
 	if err := validate.Struct(createUserSkillRequest); err != nil {
-		c.JSON(http.StatusBadRequest, utils.ResponseMessage{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf(utils.RequestSchemaInvalid, err), Data: nil})
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.RequestSchemaInvalid, err), err))
+		return
+	}
+	userSkill, err := createUserSkillRequest.userSkill()
+	if err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.InvalidSkillLevel, err), err))
 		return
 	}
 	skillObj := Skill{
@@ -243,12 +305,653 @@ func HandlerToCreateSkill(c *gin.Context, skillSvc SkillService) {
 		Icon:            createUserSkillRequest.SkillData.Icon,
 		SkillCategoryID: createUserSkillRequest.SkillData.SkillCategoryID,
 	}
-	err := skillSvc.CreateSkill(&skillObj, createUserSkillRequest.UserID, createUserSkillRequest.SkillLevel)
+	err = skillSvc.CreateSkill(&skillObj, userSkill)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, ErrEmptySkillName):
+			statusCode = http.StatusBadRequest
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			statusCode = http.StatusNotFound
+		case errors.Is(err, ErrSkillAlreadyAssigned):
+			statusCode = http.StatusConflict
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileCreatingSkill, err), err))
+		return
+	}
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: fmt.Sprintf(utils.SuccessfullyCreatedSkill), Data: skillObj})
+}
+
+// HandlerToEndorseSkill godoc
+// @Tags Skill Endorsements
+// @Summary Endorse skill of a user
+// @Description Vouch for a skill of a colleague, with an optional comment. Every user endorses a skill of a user once and cannot endorse their own skills.
+// @ID endorse-skill
+// @Security ApiAuthKey
+// @Accept json
+// @Produce json
+// @Param id path int true "Skill ID"
+// @Param userId path int true "User ID"
+// @Param EndorsementRequest body EndorsementRequest false "Endorsement"
+// @Success 201 {object} utils.ResponseMessage
+// @Failure 400 {object} utils.ResponseMessage
+// @Failure 403 {object} utils.ResponseMessage
+// @Failure 404 {object} utils.ResponseMessage
+// @Failure 409 {object} utils.ResponseMessage
+// @Failure 500 {object} utils.ResponseMessage
+// @Router /skills/{id}/user/{userId}/endorsements [post]
+func HandlerToEndorseSkill(c *gin.Context, skillSvc SkillService) {
+	fmt.Println("HandlerToEndorseSkill")
+	skillID, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
+	userID, ok := utils.BindID(c, "userId")
+	if !ok {
+		return
+	}
+	var endorsementRequest EndorsementRequest
+	if err := c.ShouldBindJSON(&endorsementRequest); err != nil && !errors.Is(err, io.EOF) {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.InvalidJsonBody, err), err))
+		return
+	}
+	if err := validate.Struct(endorsementRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.RequestSchemaInvalid, err), err))
+		return
+	}
+	identity, _ := auth.CurrentIdentity(c)
+	endorsement, err := skillSvc.EndorseSkill(skillID, userID, identity.UserID, endorsementRequest.Comment)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, ErrSelfEndorsement):
+			statusCode = http.StatusForbidden
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			statusCode = http.StatusNotFound
+		case errors.Is(err, ErrAlreadyEndorsed):
+			statusCode = http.StatusConflict
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileEndorsingSkill, err), err))
+		return
+	}
+	c.JSON(http.StatusCreated, utils.ResponseMessage{StatusCode: http.StatusCreated, Message: utils.SuccessfullyEndorsedSkill, Data: endorsement})
+}
+
+// HandlerToDeleteEndorsement godoc
+// @Tags Skill Endorsements
+// @Summary Withdraw endorsement
+// @Description Withdraw an endorsement, users withdraw their own endorsements and admins any of them
+// @ID delete-endorsement
+// @Security ApiAuthKey
+// @Param endorsementId path int true "Endorsement ID"
+// @Success 200 {object} utils.ResponseMessage
+// @Failure 400 {object} utils.ResponseMessage
+// @Failure 403 {object} utils.ResponseMessage
+// @Failure 404 {object} utils.ResponseMessage
+// @Failure 500 {object} utils.ResponseMessage
+// @Router /skills/endorsements/{endorsementId} [delete]
+func HandlerToDeleteEndorsement(c *gin.Context, skillSvc SkillService) {
+	fmt.Println("HandlerToDeleteEndorsement")
+	endorsementID, ok := utils.BindID(c, "endorsementId")
+	if !ok {
+		return
+	}
+	if err := skillSvc.DeleteEndorsementById(endorsementID); err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileDeletingEndorsement, err), err))
+		return
+	}
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.SuccessfullyDeletedEndorsement, Data: nil})
+}
+
+// HandlerToGetUserEndorsements godoc
+// @Tags Skill Endorsements
+// @Summary Get endorsements of a user
+// @Description List the endorsements of the skills of a user, or of one skill of the user, newest first
+// @ID get-user-endorsements
+// @Security ApiAuthKey
+// @Produce json
+// @Param userId path int true "User ID"
+// @Param   limit    query     int     false  "example - 50"     limit(int)
+// @Param   offset     query     int     false  "example - 0"     offset(int)
+// @Success 200 {object} utils.ResponseMessage
+// @Failure 400 {object} utils.ResponseMessage
+// @Failure 500 {object} utils.ResponseMessage
+// @Router /skills/user/{userId}/endorsements [get]
+func HandlerToGetUserEndorsements(c *gin.Context, skillSvc SkillService) {
+	fmt.Println("HandlerToGetUserEndorsements")
+	userID, ok := utils.BindID(c, "userId")
+	if !ok {
+		return
+	}
+	var skillID uint
+	if c.Param("id") != "" {
+		if skillID, ok = utils.BindID(c, "id"); !ok {
+			return
+		}
+	}
+
+	page, ok := utils.BindPage(c, "")
+	if !ok {
+		return
+	}
+	endorsements, totalRecords, err := skillSvc.GetEndorsements(userID, skillID, page.Limit, page.Offset)
+	if err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusInternalServerError, fmt.Sprintf(utils.SomethingWentWrongWhileGettingEndorsements, err), err))
+		return
+	}
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.Success, Data: utils.RecordsResponse{Total: totalRecords, RecordsFiltered: len(endorsements), Data: endorsements}})
+}
+
+// HandlerToGetUserSkillEndorsements godoc
+// @Tags Skill Endorsements
+// @Summary Get endorsements of a skill of a user
+// @Description List the endorsements of one skill of a user, newest first
+// @ID get-user-skill-endorsements
+// @Security ApiAuthKey
+// @Produce json
+// @Param id path int true "Skill ID"
+// @Param userId path int true "User ID"
+// @Param   limit    query     int     false  "example - 50"     limit(int)
+// @Param   offset     query     int     false  "example - 0"     offset(int)
+// @Success 200 {object} utils.ResponseMessage
+// @Failure 400 {object} utils.ResponseMessage
+// @Failure 500 {object} utils.ResponseMessage
+// @Router /skills/{id}/user/{userId}/endorsements [get]
+func HandlerToGetUserSkillEndorsements(c *gin.Context, skillSvc SkillService) {
+	HandlerToGetUserEndorsements(c, skillSvc)
+}
+
+// HandlerToGetSkillChildren godoc
+// @Tags Skills
+// @Summary Get skills below a skill
+// @Description Get the skills directly below a skill in the hierarchy, or every skill below it with includeDescendants
+// @ID get-skill-children
+// @Security ApiAuthKey
+// @Produce json
+// @Param id path int true "Skill ID"
+// @Param   includeDescendants   query   bool  false  "example - false"
+// @Success 200 {object} utils.ResponseMessage
+// @Failure 400 {object} utils.ResponseMessage
+// @Failure 404 {object} utils.ResponseMessage
+// @Failure 500 {object} utils.ResponseMessage
+// @Router /skills/{id}/children [get]
+func HandlerToGetSkillChildren(c *gin.Context, skillSvc SkillService) {
+	fmt.Println("HandlerToGetSkillChildren")
+	skillID, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
+	includeDescendants := c.DefaultQuery("includeDescendants", "false")
+	includeDescendantsBool, err := strconv.ParseBool(includeDescendants)
+	if err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.InvalidBooleanValueMessage, "includeDescendants", err), err))
+		return
+	}
+	children, err := skillSvc.GetSkillChildren(skillID, includeDescendantsBool)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileGettingSkill, err), err))
+		return
+	}
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.Success, Data: children})
+}
+
+// HandlerToSetSkillParent godoc
+// @Tags Skills
+// @Summary Set parent of a skill
+// @Description Place a skill below a broader skill, a null parent_id makes it a top level skill
+// @ID set-skill-parent
+// @Security ApiAuthKey
+// @Accept json
+// @Produce json
+// @Param id path int true "Skill ID"
+// @Param SkillParentRequest body SkillParentRequest true "Parent"
+// @Success 200 {object} utils.ResponseMessage
+// @Failure 400 {object} utils.ResponseMessage
+// @Failure 404 {object} utils.ResponseMessage
+// @Failure 409 {object} utils.ResponseMessage
+// @Failure 500 {object} utils.ResponseMessage
+// @Router /skills/{id}/parent [put]
+func HandlerToSetSkillParent(c *gin.Context, skillSvc SkillService) {
+	fmt.Println("HandlerToSetSkillParent")
+	skillID, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
+	var parentRequest SkillParentRequest
+	if err := c.ShouldBindJSON(&parentRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.InvalidJsonBody, err), err))
+		return
+	}
+	if err := validate.Struct(parentRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.RequestSchemaInvalid, err), err))
+		return
+	}
+	if err := skillSvc.SetSkillParent(skillID, parentRequest.ParentID); err != nil {
+		statusCode := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			statusCode = http.StatusNotFound
+		case errors.Is(err, ErrSkillHierarchyCycle):
+			statusCode = http.StatusConflict
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileSettingSkillParent, err), err))
+		return
+	}
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.SuccessfullySetSkillParent, Data: nil})
+}
+
+// HandlerToAddSkillAlias godoc
+// @Tags Skills
+// @Summary Add alias to a skill
+// @Description Add another name of a skill, searching or adding a skill by its alias finds the skill
+// @ID add-skill-alias
+// @Security ApiAuthKey
+// @Accept json
+// @Produce json
+// @Param id path int true "Skill ID"
+// @Param SkillAliasRequest body SkillAliasRequest true "Alias"
+// @Success 201 {object} utils.ResponseMessage
+// @Failure 400 {object} utils.ResponseMessage
+// @Failure 404 {object} utils.ResponseMessage
+// @Failure 409 {object} utils.ResponseMessage
+// @Failure 500 {object} utils.ResponseMessage
+// @Router /skills/{id}/aliases [post]
+func HandlerToAddSkillAlias(c *gin.Context, skillSvc SkillService) {
+	fmt.Println("HandlerToAddSkillAlias")
+	skillID, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
+	var aliasRequest SkillAliasRequest
+	if err := c.ShouldBindJSON(&aliasRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.InvalidJsonBody, err), err))
+		return
+	}
+	if err := validate.Struct(aliasRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.RequestSchemaInvalid, err), err))
+		return
+	}
+	alias, err := skillSvc.AddSkillAlias(skillID, aliasRequest.Alias)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, ErrEmptySkillName):
+			statusCode = http.StatusBadRequest
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			statusCode = http.StatusNotFound
+		case errors.Is(err, ErrAliasTaken):
+			statusCode = http.StatusConflict
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileSavingSkillAlias, err), err))
+		return
+	}
+	c.JSON(http.StatusCreated, utils.ResponseMessage{StatusCode: http.StatusCreated, Message: utils.SuccessfullyAddedSkillAlias, Data: alias})
+}
+
+// HandlerToRemoveSkillAlias godoc
+// @Tags Skills
+// @Summary Remove alias of a skill
+// @Description Remove an alias from a skill
+// @ID remove-skill-alias
+// @Security ApiAuthKey
+// @Param id path int true "Skill ID"
+// @Param aliasId path int true "Alias ID"
+// @Success 200 {object} utils.ResponseMessage
+// @Failure 400 {object} utils.ResponseMessage
+// @Failure 404 {object} utils.ResponseMessage
+// @Failure 500 {object} utils.ResponseMessage
+// @Router /skills/{id}/aliases/{aliasId} [delete]
+func HandlerToRemoveSkillAlias(c *gin.Context, skillSvc SkillService) {
+	fmt.Println("HandlerToRemoveSkillAlias")
+	skillID, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
+	aliasID, ok := utils.BindID(c, "aliasId")
+	if !ok {
+		return
+	}
+	if err := skillSvc.RemoveSkillAlias(skillID, aliasID); err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileDeletingSkillAlias, err), err))
+		return
+	}
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.SuccessfullyDeletedSkillAlias, Data: nil})
+}
+
+// HandlerToAssignSkillToUser godoc
+// @Tags Skills
+// @Summary Assign skill to user
+// @Description Assign a skill of the catalogue to a user, optionally rated. The body can be left out.
+// @ID assign-skill-to-user
+// @Security ApiAuthKey
+// @Accept json
+// @Produce json
+// @Param id path int true "Skill ID"
+// @Param userId path int true "User ID"
+// @Param UserSkillAssignmentRequest body UserSkillAssignmentRequest false "Rating"
+// @Success 201 {object} utils.ResponseMessage
+// @Failure 400 {object} utils.ResponseMessage
+// @Failure 404 {object} utils.ResponseMessage
+// @Failure 409 {object} utils.ResponseMessage
+// @Failure 500 {object} utils.ResponseMessage
+// @Router /skills/{id}/user/{userId} [post]
+func HandlerToAssignSkillToUser(c *gin.Context, skillSvc SkillService) {
+	fmt.Println("HandlerToAssignSkillToUser")
+	skillID, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
+	userID, ok := utils.BindID(c, "userId")
+	if !ok {
+		return
+	}
+	var assignmentRequest UserSkillAssignmentRequest
+	if err := c.ShouldBindJSON(&assignmentRequest); err != nil && !errors.Is(err, io.EOF) {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.InvalidJsonBody, err), err))
+		return
+	}
+	assignmentRequest.SkillID = skillID
+	if err := validate.Struct(assignmentRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.RequestSchemaInvalid, err), err))
+		return
+	}
+	userSkill, err := skillSvc.AssignSkillToUser(assignmentRequest.userSkill(userID))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, utils.ResponseMessage{StatusCode: http.StatusInternalServerError, Message: fmt.Sprintf(utils.SomethingWentWrongWhileCreatingSkill, err), Data: nil})
+		statusCode := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			statusCode = http.StatusNotFound
+		case errors.Is(err, ErrSkillAlreadyAssigned):
+			statusCode = http.StatusConflict
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileAssigningSkill, err), err))
+		return
+	}
+	c.JSON(http.StatusCreated, utils.ResponseMessage{StatusCode: http.StatusCreated, Message: utils.SuccessfullyAssignedSkill, Data: userSkill})
+}
+
+// HandlerToUnassignSkillFromUser godoc
+// @Tags Skills
+// @Summary Unassign skill from user
+// @Description Remove a skill from a user, the skill stays in the catalogue
+// @ID unassign-skill-from-user
+// @Security ApiAuthKey
+// @Param id path int true "Skill ID"
+// @Param userId path int true "User ID"
+// @Success 200 {object} utils.ResponseMessage
+// @Failure 400 {object} utils.ResponseMessage
+// @Failure 404 {object} utils.ResponseMessage
+// @Failure 500 {object} utils.ResponseMessage
+// @Router /skills/{id}/user/{userId} [delete]
+func HandlerToUnassignSkillFromUser(c *gin.Context, skillSvc SkillService) {
+	fmt.Println("HandlerToUnassignSkillFromUser")
+	skillID, ok := utils.BindID(c, "id")
+	if !ok {
 		return
 	}
-	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: fmt.Sprintf(utils.SuccessfullyCreatedSkill), Data: nil})
+	userID, ok := utils.BindID(c, "userId")
+	if !ok {
+		return
+	}
+	if err := skillSvc.UnassignSkillFromUser(skillID, userID); err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileUnassigningSkill, err), err))
+		return
+	}
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.SuccessfullyUnassignedSkill, Data: nil})
+}
+
+// HandlerToSetUserSkills godoc
+// @Tags Skills
+// @Summary Set skills of user
+// @Description Replace the skills of a user with the given list, skills missing from the list are unassigned.
+// @Description Skills that stay keep a verified rating as long as their proficiency does not change.
+// @ID set-user-skills
+// @Security ApiAuthKey
+// @Accept json
+// @Produce json
+// @Param userId path int true "User ID"
+// @Param SetUserSkillsRequest body SetUserSkillsRequest true "Skills"
+// @Success 200 {object} utils.ResponseMessage
+// @Failure 400 {object} utils.ResponseMessage
+// @Failure 404 {object} utils.ResponseMessage
+// @Failure 500 {object} utils.ResponseMessage
+// @Router /skills/user/{userId} [put]
+func HandlerToSetUserSkills(c *gin.Context, skillSvc SkillService) {
+	fmt.Println("HandlerToSetUserSkills")
+	userID, ok := utils.BindID(c, "userId")
+	if !ok {
+		return
+	}
+	var setUserSkillsRequest SetUserSkillsRequest
+	if err := c.ShouldBindJSON(&setUserSkillsRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.InvalidJsonBody, err), err))
+		return
+	}
+	if err := validate.Struct(setUserSkillsRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.RequestSchemaInvalid, err), err))
+		return
+	}
+	userSkills := make([]UserSkill, 0, len(setUserSkillsRequest.Skills))
+	for _, assignmentRequest := range setUserSkillsRequest.Skills {
+		userSkills = append(userSkills, assignmentRequest.userSkill(userID))
+	}
+	userSkills, err := skillSvc.SetUserSkills(userID, userSkills)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileSettingUserSkills, err), err))
+		return
+	}
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.SuccessfullySetUserSkills, Data: userSkills})
+}
+
+// HandlerToGetProficiencyLevels godoc
+// @Tags Skills
+// @Summary Get proficiency levels
+// @Description Get the scale skills are rated on, from 1 (Beginner) to 5 (Expert). 0 means the skill is not rated.
+// @ID get-proficiency-levels
+// @Security ApiAuthKey
+// @Produce json
+// @Success 200 {object} utils.ResponseMessage
+// @Router /skills/proficiency-levels [get]
+func HandlerToGetProficiencyLevels(c *gin.Context) {
+	fmt.Println("HandlerToGetProficiencyLevels")
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.Success, Data: ProficiencyLevels})
+}
+
+// HandlerToGetUserSkillProficiencies godoc
+// @Tags Skills
+// @Summary Get skill proficiencies of a user
+// @Description Get the skills of a user with their proficiency, years of use, last use and assessment
+// @ID get-user-skill-proficiencies
+// @Security ApiAuthKey
+// @Produce json
+// @Param userId path int true "User ID"
+// @Success 200 {object} utils.ResponseMessage
+// @Failure 400 {object} utils.ResponseMessage
+// @Failure 500 {object} utils.ResponseMessage
+// @Router /skills/user/{userId}/proficiency [get]
+func HandlerToGetUserSkillProficiencies(c *gin.Context, skillSvc SkillService) {
+	fmt.Println("HandlerToGetUserSkillProficiencies")
+	userID, ok := utils.BindID(c, "userId")
+	if !ok {
+		return
+	}
+	proficiencies, err := skillSvc.GetUserSkillProficiencies(userID)
+	if err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusInternalServerError, fmt.Sprintf(utils.SomethingWentWrongWhileGettingSkillProficiency, err), err))
+		return
+	}
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.Success, Data: proficiencies})
+}
+
+// HandlerToUpdateUserSkillProficiency godoc
+// @Tags Skills
+// @Summary Update skill proficiency of a user
+// @Description Rate a skill of a user. Users rate their own skills as a self assessment, admins can rate the skills of other users as verified.
+// @Description Changing a verified rating without verifying it again makes it a self assessment.
+// @ID update-user-skill-proficiency
+// @Security ApiAuthKey
+// @Accept json
+// @Produce json
+// @Param id path int true "Skill ID"
+// @Param userId path int true "User ID"
+// @Param UserSkillProficiencyRequest body UserSkillProficiencyRequest true "Proficiency"
+// @Success 200 {object} utils.ResponseMessage
+// @Failure 400 {object} utils.ResponseMessage
+// @Failure 403 {object} utils.ResponseMessage
+// @Failure 404 {object} utils.ResponseMessage
+// @Failure 500 {object} utils.ResponseMessage
+// @Router /skills/{id}/user/{userId}/proficiency [put]
+func HandlerToUpdateUserSkillProficiency(c *gin.Context, skillSvc SkillService) {
+	fmt.Println("HandlerToUpdateUserSkillProficiency")
+	skillID, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
+	userID, ok := utils.BindID(c, "userId")
+	if !ok {
+		return
+	}
+	var proficiencyRequest UserSkillProficiencyRequest
+	if err := c.ShouldBindJSON(&proficiencyRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.InvalidJsonBody, err), err))
+		return
+	}
+	if err := validate.Struct(proficiencyRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.RequestSchemaInvalid, err), err))
+		return
+	}
+	update := ProficiencyUpdate{
+		Proficiency: proficiencyRequest.Proficiency,
+		YearsOfUse:  proficiencyRequest.YearsOfUse,
+		LastUsedOn:  parseLastUsedOn(proficiencyRequest.LastUsedOn),
+	}
+	if proficiencyRequest.Verified {
+		identity, _ := auth.CurrentIdentity(c)
+		if !identity.IsAdmin() {
+			utils.AbortWithError(c, utils.NewError(http.StatusForbidden, fmt.Sprintf(utils.Forbidden, "only admins can verify a skill"), nil))
+			return
+		}
+		update.VerifiedByUserID = identity.UserID
+	}
+	userSkill, err := skillSvc.UpdateUserSkillProficiency(skillID, userID, update)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			statusCode = http.StatusNotFound
+		case errors.Is(err, ErrSelfVerification):
+			statusCode = http.StatusForbidden
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileUpdatingSkillProficiency, err), err))
+		return
+	}
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.SuccessfullyUpdatedSkillProficiency, Data: userSkill})
+}
+
+// HandlerToGetSkillLevelDistribution godoc
+// @Tags Skills
+// @Summary Get proficiency distribution per skill
+// @Description Count the users of the company per proficiency level of every skill, the most common skills first
+// @ID get-skill-level-distribution
+// @Security ApiAuthKey
+// @Produce json
+// @Param   limit    query     int     false  "example - 50"     limit(int)
+// @Param   offset     query     int     false  "example - 0"     offset(int)
+// @Param   skill_category_id   query   int  false  "Only skills of this category"
+// @Param   keyword   query   string  false  "Search for a keyword in skill names"
+// @Success 200 {object} utils.ResponseMessage
+// @Failure 400 {object} utils.ResponseMessage
+// @Failure 500 {object} utils.ResponseMessage
+// @Router /skills/proficiency/distribution [get]
+func HandlerToGetSkillLevelDistribution(c *gin.Context, skillSvc SkillService) {
+	fmt.Println("HandlerToGetSkillLevelDistribution")
+	page, ok := utils.BindPage(c, "")
+	if !ok {
+		return
+	}
+	filter, err := distributionFilter(c)
+	if err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.SomethingWentWrong, err), err))
+		return
+	}
+	distributions, totalRecords, err := skillSvc.GetSkillLevelDistribution(filter, page.Limit, page.Offset)
+	if err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusInternalServerError, fmt.Sprintf(utils.SomethingWentWrongWhileGettingSkillDistribution, err), err))
+		return
+	}
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.Success, Data: utils.RecordsResponse{Total: totalRecords, RecordsFiltered: len(distributions), Data: distributions}})
+}
+
+// HandlerToGetCategoryLevelDistribution godoc
+// @Tags Skills
+// @Summary Get proficiency distribution per skill category
+// @Description Count the skills of the company per proficiency level of every skill category
+// @ID get-category-level-distribution
+// @Security ApiAuthKey
+// @Produce json
+// @Param   skill_category_id   query   int  false  "Only this category"
+// @Param   keyword   query   string  false  "Only skills with this keyword in their name"
+// @Success 200 {object} utils.ResponseMessage
+// @Failure 400 {object} utils.ResponseMessage
+// @Failure 500 {object} utils.ResponseMessage
+// @Router /skills/proficiency/distribution/categories [get]
+func HandlerToGetCategoryLevelDistribution(c *gin.Context, skillSvc SkillService) {
+	fmt.Println("HandlerToGetCategoryLevelDistribution")
+	filter, err := distributionFilter(c)
+	if err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.SomethingWentWrong, err), err))
+		return
+	}
+	distributions, err := skillSvc.GetCategoryLevelDistribution(filter)
+	if err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusInternalServerError, fmt.Sprintf(utils.SomethingWentWrongWhileGettingSkillDistribution, err), err))
+		return
+	}
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.Success, Data: distributions})
+}
+
+func distributionFilter(c *gin.Context) (DistributionFilter, error) {
+	filter := DistributionFilter{Keyword: c.Query("keyword")}
+	if skillCategoryID := c.Query("skill_category_id"); skillCategoryID != "" {
+		id, err := utils.ParseID(skillCategoryID)
+		if err != nil {
+			return DistributionFilter{}, err
+		}
+		filter.SkillCategoryID = id
+	}
+	return filter, nil
+}
+
+// parseLastUsedOn reads a date the validator already accepted, an empty date is unknown
+func parseLastUsedOn(date string) *time.Time {
+	if date == "" {
+		return nil
+	}
+	lastUsedOn, err := time.Parse(lastUsedOnLayout, date)
+	if err != nil {
+		return nil
+	}
+	return &lastUsedOn
 }
 
 // HandlerToGetAllSkillCategories godoc
@@ -269,34 +972,13 @@ func HandlerToCreateSkill(c *gin.Context, skillSvc SkillService) {
 // @Router /skills/categories [get]
 func HandlerToGetAllSkillCategories(c *gin.Context, skillSvc SkillService) {
 	fmt.Println("HandlerToGetAllSkillCategories")
-	baseQuery := c.Request.URL.Query()
-	limit := baseQuery.Get("limit")
-	offset := baseQuery.Get("offset")
-	orderBy := baseQuery.Get("orderBy")
-
-	if limit == "" {
-		limit = utils.DefaultLimit
-	}
-	if offset == "" {
-		offset = utils.DefaultOffset
-	}
-	if orderBy == "" {
-		orderBy = utils.DefaultOrderBy
-	}
-
-	limitInt, err := strconv.Atoi(limit)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, utils.ResponseMessage{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf(utils.InvalidIntegerValueLimitMessage, err), Data: nil})
+	page, ok := utils.BindPage(c, utils.DefaultOrderBy, skillCategorySortColumns...)
+	if !ok {
 		return
 	}
-	offsetInt, err := strconv.Atoi(offset)
+	skillCategoryList, totalRecords, err := skillSvc.FetchAllSkillCategories(page.Limit, page.Offset, page.OrderBy)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, utils.ResponseMessage{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf(utils.InvalidIntegerValueOffsetMessage, err), Data: nil})
-		return
-	}
-	skillCategoryList, totalRecords, err := skillSvc.FetchAllSkillCategories(limitInt, offsetInt, orderBy)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, utils.ResponseMessage{StatusCode: http.StatusInternalServerError, Message: fmt.Sprintf(utils.SomethingWentWrongWhileGettingSkillCategory, err), Data: nil})
+		utils.AbortWithError(c, utils.NewError(http.StatusInternalServerError, fmt.Sprintf(utils.SomethingWentWrongWhileGettingSkillCategory, err), err))
 		return
 	}
 	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.Success, Data: utils.RecordsResponse{Total: totalRecords, RecordsFiltered: len(skillCategoryList), Data: skillCategoryList}})
@@ -319,31 +1001,29 @@ func HandlerToGetAllSkillCategories(c *gin.Context, skillSvc SkillService) {
 // @Router /skills/categories/{id} [patch]
 func HandlerToUpdateSkillCategoryByID(c *gin.Context, skillSvc SkillService) {
 	fmt.Println("HandlerToUpdateSkillCategoryByID")
-	skillCategoryID := c.Param("id")
-	skillCategoryIDInt, err := strconv.Atoi(skillCategoryID)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, utils.ResponseMessage{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf(utils.SomethingWentWrong, err), Data: nil})
+	skillCategoryID, ok := utils.BindID(c, "id")
+	if !ok {
 		return
 	}
 
 	var skillCategoryUpdateRequest SkillCategoryUpdateRequest
 	if err := c.ShouldBind(&skillCategoryUpdateRequest); err != nil {
-		c.JSON(http.StatusBadRequest, utils.ResponseMessage{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf(utils.InvalidJsonBody, err), Data: nil})
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.InvalidJsonBody, err), err))
 		return
 	}
 	if err := validate.Struct(skillCategoryUpdateRequest); err != nil {
-		c.JSON(http.StatusBadRequest, utils.ResponseMessage{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf(utils.RequestSchemaInvalid, err), Data: nil})
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.RequestSchemaInvalid, err), err))
 		return
 	}
-	fetchedSkillCategory, err := skillSvc.GetSkillCategoryById(uint(skillCategoryIDInt))
+	fetchedSkillCategory, err := skillSvc.GetSkillCategoryById(skillCategoryID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, utils.ResponseMessage{StatusCode: http.StatusInternalServerError, Message: fmt.Sprintf(utils.SomethingWentWrongWhileGettingSkillCategory, err), Data: nil})
+		utils.AbortWithError(c, utils.NewError(http.StatusInternalServerError, fmt.Sprintf(utils.SomethingWentWrongWhileGettingSkillCategory, err), err))
 		return
 	}
 	utils.UpdateEntity(&fetchedSkillCategory, skillCategoryUpdateRequest)
 	err = skillSvc.UpdateSkillCategory(fetchedSkillCategory)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, utils.ResponseMessage{StatusCode: http.StatusInternalServerError, Message: fmt.Sprintf(utils.SomethingWentWrongWhileUpdatingSkillCategory, err), Data: nil})
+		utils.AbortWithError(c, utils.NewError(http.StatusInternalServerError, fmt.Sprintf(utils.SomethingWentWrongWhileUpdatingSkillCategory, err), err))
 		return
 	}
 	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.SuccessfullyUpdatedSkillsCategory, Data: nil})
@@ -365,15 +1045,13 @@ func HandlerToUpdateSkillCategoryByID(c *gin.Context, skillSvc SkillService) {
 // @Router /skills/categories/{id} [delete]
 func HandlerToDeleteSkillCategoryByID(c *gin.Context, skillSvc SkillService) {
 	fmt.Println("HandlerToDeleteSkillCategoryByID")
-	skillCategoryID := c.Param("id")
-	skillCategoryIDInt, err := strconv.Atoi(skillCategoryID)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, utils.ResponseMessage{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf(utils.SomethingWentWrong, err), Data: nil})
+	skillCategoryID, ok := utils.BindID(c, "id")
+	if !ok {
 		return
 	}
-	err = skillSvc.DeleteSkillCategoryById(uint(skillCategoryIDInt))
+	err := skillSvc.DeleteSkillCategoryById(skillCategoryID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, utils.ResponseMessage{StatusCode: http.StatusInternalServerError, Message: fmt.Sprintf(utils.SomethingWentWrongWhileDeletingSkillCategories, err), Data: nil})
+		utils.AbortWithError(c, utils.NewError(http.StatusInternalServerError, fmt.Sprintf(utils.SomethingWentWrongWhileDeletingSkillCategories, err), err))
 		return
 	}
 
@@ -396,15 +1074,13 @@ func HandlerToDeleteSkillCategoryByID(c *gin.Context, skillSvc SkillService) {
 // @Router /skills/categories/{id} [get]
 func HandlerToGetSkillCategoryByID(c *gin.Context, skillSvc SkillService) {
 	fmt.Println("HandlerToGetSkillCategoryByID")
-	skillCategoryID := c.Param("id")
-	skillCategoryIDInt, err := strconv.Atoi(skillCategoryID)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, utils.ResponseMessage{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf(utils.SomethingWentWrong, err), Data: nil})
+	skillCategoryID, ok := utils.BindID(c, "id")
+	if !ok {
 		return
 	}
-	fetchedSkillCategory, err := skillSvc.GetSkillCategoryById(uint(skillCategoryIDInt))
+	fetchedSkillCategory, err := skillSvc.GetSkillCategoryById(skillCategoryID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, utils.ResponseMessage{StatusCode: http.StatusInternalServerError, Message: fmt.Sprintf(utils.SomethingWentWrongWhileGettingSkillCategory, err), Data: nil})
+		utils.AbortWithError(c, utils.NewError(http.StatusInternalServerError, fmt.Sprintf(utils.SomethingWentWrongWhileGettingSkillCategory, err), err))
 		return
 	}
 
@@ -429,16 +1105,16 @@ func HandlerToCreateSkillCategories(c *gin.Context, skillSvc SkillService) {
 	fmt.Println("HandlerToCreateSkillCategory")
 	var createSkillCategoryRequest CreateSkillCategoryRequest
 	if err := c.ShouldBind(&createSkillCategoryRequest); err != nil {
-		c.JSON(http.StatusBadRequest, utils.ResponseMessage{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf(utils.InvalidJsonBody, err), Data: nil})
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.InvalidJsonBody, err), err))
 		return
 	}
 
 	if err := validate.Struct(createSkillCategoryRequest); err != nil {
-		c.JSON(http.StatusBadRequest, utils.ResponseMessage{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf(utils.RequestSchemaInvalid, err), Data: nil})
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.RequestSchemaInvalid, err), err))
 		return
 	}
 	if len(createSkillCategoryRequest.Name) == 0 {
-		c.JSON(http.StatusBadRequest, utils.ResponseMessage{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf(utils.RequiredSkillCategoryNames), Data: nil})
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.RequiredSkillCategoryNames), nil))
 		return
 	}
 	var skillsCategories []SkillCategory
@@ -447,7 +1123,7 @@ func HandlerToCreateSkillCategories(c *gin.Context, skillSvc SkillService) {
 	}
 	err := skillSvc.CreateSkillCategories(skillsCategories)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, utils.ResponseMessage{StatusCode: http.StatusInternalServerError, Message: fmt.Sprintf(utils.SomethingWentWrongWhileCreatingSkillCategories, err), Data: nil})
+		utils.AbortWithError(c, utils.NewError(http.StatusInternalServerError, fmt.Sprintf(utils.SomethingWentWrongWhileCreatingSkillCategories, err), err))
 		return
 	}
 	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: fmt.Sprintf(utils.SuccessfullyCreatedSkillsCategories), Data: nil})
@@ -463,10 +1139,73 @@ type SkillCategoryUpdateRequest struct {
 	Name string `json:"name" validate:"required"`
 }
 
+// UserSkillRequest rates the skill with proficiency, skill_level is the former free text level and is only read
+// when no proficiency is given
 type UserSkillRequest struct {
-	SkillData  SkillRequest `json:"skillData"`
-	UserID     uint         `json:"user_id"`
-	SkillLevel string       `json:"skill_level"`
+	SkillData   SkillRequest `json:"skillData"`
+	UserID      uint         `json:"user_id"`
+	SkillLevel  string       `json:"skill_level"`
+	Proficiency int          `json:"proficiency" validate:"min=0,max=5"`
+	YearsOfUse  float64      `json:"years_of_use" validate:"min=0"`
+	LastUsedOn  string       `json:"last_used_on" validate:"omitempty,datetime=2006-01-02"`
+}
+
+func (request UserSkillRequest) userSkill() (UserSkill, error) {
+	proficiency := request.Proficiency
+	if proficiency == ProficiencyUnrated {
+		var err error
+		if proficiency, err = ParseSkillLevel(request.SkillLevel); err != nil {
+			return UserSkill{}, err
+		}
+	}
+	return UserSkill{
+		UserID:      request.UserID,
+		Proficiency: proficiency,
+		YearsOfUse:  request.YearsOfUse,
+		LastUsedOn:  parseLastUsedOn(request.LastUsedOn),
+	}, nil
+}
+
+type EndorsementRequest struct {
+	Comment string `json:"comment" validate:"max=500"`
+}
+
+type SkillParentRequest struct {
+	ParentID *uint `json:"parent_id"`
+}
+
+type SkillAliasRequest struct {
+	Alias string `json:"alias" validate:"required,max=100"`
+}
+
+// UserSkillAssignmentRequest is a skill of the catalogue given to a user, the skill id comes from the path when
+// a single skill is assigned
+type UserSkillAssignmentRequest struct {
+	SkillID     uint    `json:"skill_id" validate:"required"`
+	Proficiency int     `json:"proficiency" validate:"min=0,max=5"`
+	YearsOfUse  float64 `json:"years_of_use" validate:"min=0"`
+	LastUsedOn  string  `json:"last_used_on" validate:"omitempty,datetime=2006-01-02"`
+}
+
+func (request UserSkillAssignmentRequest) userSkill(userID uint) UserSkill {
+	return UserSkill{
+		UserID:      userID,
+		SkillID:     request.SkillID,
+		Proficiency: request.Proficiency,
+		YearsOfUse:  request.YearsOfUse,
+		LastUsedOn:  parseLastUsedOn(request.LastUsedOn),
+	}
+}
+
+type SetUserSkillsRequest struct {
+	Skills []UserSkillAssignmentRequest `json:"skills" validate:"required,dive"`
+}
+
+type UserSkillProficiencyRequest struct {
+	Proficiency int     `json:"proficiency" validate:"required,min=1,max=5"`
+	YearsOfUse  float64 `json:"years_of_use" validate:"min=0"`
+	LastUsedOn  string  `json:"last_used_on" validate:"omitempty,datetime=2006-01-02"`
+	Verified    bool    `json:"verified"`
 }
 type SkillRequest struct {
 	Name            string `json:"name"`
@@ -0,0 +1,79 @@
+package skills
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/Octek/resource-profile-management-backend.git/utils"
+	"github.com/Octek/resource-profile-management-backend.git/utils/apitest"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// fakeSkillRepository keeps the catalogue in memory
+type fakeSkillRepository struct {
+	SkillRepository
+	skills map[uint]Skill
+	page   utils.Page
+}
+
+func (repo *fakeSkillRepository) getSkillById(id uint) (Skill, error) {
+	if skillObj, found := repo.skills[id]; found {
+		return skillObj, nil
+	}
+	return Skill{}, gorm.ErrRecordNotFound
+}
+
+func (repo *fakeSkillRepository) fetchAllSkill(limit, offset int, orderBy, keyword string, includeDescendants bool) ([]Skill, int64, error) {
+	repo.page = utils.Page{Limit: limit, Offset: offset, OrderBy: orderBy}
+	return []Skill{}, 0, nil
+}
+
+func newTestRouter(repo *fakeSkillRepository) *gin.Engine {
+	skillSvc := NewService(repo)
+	router := apitest.NewRouter()
+	router.GET("/skills", func(c *gin.Context) {
+		HandlerToGetAllSkills(c, skillSvc)
+	})
+	router.GET("/skills/:id", func(c *gin.Context) {
+		HandlerToGetSkillByID(c, skillSvc)
+	})
+	return router
+}
+
+func TestHandlerToGetSkillByID(t *testing.T) {
+	router := newTestRouter(&fakeSkillRepository{skills: map[uint]Skill{7: {ID: 7, Name: "Go"}}})
+	tests := []struct {
+		path       string
+		statusCode int
+		code       string
+	}{
+		{path: "/skills/7", statusCode: http.StatusOK},
+		{path: "/skills/8", statusCode: http.StatusNotFound, code: utils.ErrorCodeNotFound},
+		{path: "/skills/abc", statusCode: http.StatusBadRequest, code: utils.ErrorCodeBadRequest},
+		{path: "/skills/0", statusCode: http.StatusBadRequest, code: utils.ErrorCodeBadRequest},
+		{path: "/skills/%2B7", statusCode: http.StatusBadRequest, code: utils.ErrorCodeBadRequest},
+	}
+	for _, test := range tests {
+		statusCode, response := apitest.Serve(t, router, http.MethodGet, test.path, "")
+		if statusCode != test.statusCode || response.StatusCode != test.statusCode || response.Code != test.code {
+			t.Errorf("GET %s = %d with code %q, want %d with code %q", test.path, statusCode, response.Code, test.statusCode, test.code)
+		}
+	}
+}
+
+func TestHandlerToGetAllSkills(t *testing.T) {
+	repo := &fakeSkillRepository{}
+	router := newTestRouter(repo)
+
+	statusCode, _ := apitest.Serve(t, router, http.MethodGet, "/skills?limit=50&offset=100&orderBy=name%20asc,id", "")
+	if want := (utils.Page{Limit: 50, Offset: 100, OrderBy: "name asc,id"}); statusCode != http.StatusOK || repo.page != want {
+		t.Errorf("GET /skills with a page = %d listing %+v, want 200 listing %+v", statusCode, repo.page, want)
+	}
+	for _, query := range []string{"limit=ten", "limit=0", "offset=-5", "orderBy=name%20asc%3B--", "orderBy=normalized_name", "includeDescendants=maybe"} {
+		statusCode, response := apitest.Serve(t, router, http.MethodGet, "/skills?"+query, "")
+		if statusCode != http.StatusBadRequest || response.Code != utils.ErrorCodeBadRequest {
+			t.Errorf("GET /skills?%s = %d with code %q, want 400", query, statusCode, response.Code)
+		}
+	}
+}
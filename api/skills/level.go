@@ -0,0 +1,99 @@
+package skills
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	ProficiencyUnrated      = 0
+	ProficiencyBeginner     = 1
+	ProficiencyElementary   = 2
+	ProficiencyIntermediate = 3
+	ProficiencyAdvanced     = 4
+	ProficiencyExpert       = 5
+
+	MinProficiency = ProficiencyBeginner
+	MaxProficiency = ProficiencyExpert
+
+	AssessmentSelf     = "self"
+	AssessmentVerified = "verified"
+)
+
+var (
+	ErrUnknownSkillLevel = errors.New("unknown skill level")
+	ErrSelfVerification  = errors.New("a skill cannot be verified by its own user")
+)
+
+type ProficiencyLevel struct {
+	Level       int    `json:"level"`
+	Label       string `json:"label"`
+	Description string `json:"description"`
+}
+
+var ProficiencyLevels = []ProficiencyLevel{
+	{Level: ProficiencyBeginner, Label: "Beginner", Description: "Knows the basics and needs guidance"},
+	{Level: ProficiencyElementary, Label: "Elementary", Description: "Handles simple tasks without help"},
+	{Level: ProficiencyIntermediate, Label: "Intermediate", Description: "Works independently on most tasks"},
+	{Level: ProficiencyAdvanced, Label: "Advanced", Description: "Handles complex work and guides others"},
+	{Level: ProficiencyExpert, Label: "Expert", Description: "Recognised authority who sets the direction"},
+}
+
+// skillLevelAliases maps the free text levels stored before the scale existed, both the numbers and the usual labels
+var skillLevelAliases = map[string]int{
+	"1": ProficiencyBeginner, "beginner": ProficiencyBeginner, "novice": ProficiencyBeginner,
+	"2": ProficiencyElementary, "elementary": ProficiencyElementary, "basic": ProficiencyElementary,
+	"3": ProficiencyIntermediate, "intermediate": ProficiencyIntermediate, "competent": ProficiencyIntermediate,
+	"4": ProficiencyAdvanced, "advanced": ProficiencyAdvanced, "proficient": ProficiencyAdvanced,
+	"5": ProficiencyExpert, "expert": ProficiencyExpert,
+}
+
+// ProficiencyLabel is empty for an unrated skill
+func ProficiencyLabel(proficiency int) string {
+	if proficiency < MinProficiency || proficiency > MaxProficiency {
+		return ""
+	}
+	return ProficiencyLevels[proficiency-1].Label
+}
+
+// ParseSkillLevel reads a level number or label, an empty level is unrated
+func ParseSkillLevel(level string) (int, error) {
+	level = strings.ToLower(strings.TrimSpace(level))
+	if level == "" {
+		return ProficiencyUnrated, nil
+	}
+	proficiency, ok := skillLevelAliases[level]
+	if !ok {
+		return ProficiencyUnrated, fmt.Errorf("%w: %q", ErrUnknownSkillLevel, level)
+	}
+	return proficiency, nil
+}
+
+// proficiencyFromSkillLevelSQL is ParseSkillLevel as a SQL expression, unknown levels become unrated
+func proficiencyFromSkillLevelSQL(column string) string {
+	levels := make([]string, 0, len(skillLevelAliases))
+	for level := range skillLevelAliases {
+		levels = append(levels, level)
+	}
+	sort.Strings(levels)
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "(CASE LOWER(TRIM(%s))", column)
+	for _, level := range levels {
+		fmt.Fprintf(&builder, " WHEN '%s' THEN %d", level, skillLevelAliases[level])
+	}
+	builder.WriteString(" ELSE 0 END)")
+	return builder.String()
+}
+
+// proficiencyLabelSQL is ProficiencyLabel as a SQL expression
+func proficiencyLabelSQL(column string) string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "(CASE %s", column)
+	for _, level := range ProficiencyLevels {
+		fmt.Fprintf(&builder, " WHEN %d THEN '%s'", level.Level, level.Label)
+	}
+	builder.WriteString(" ELSE '' END)")
+	return builder.String()
+}
@@ -3,7 +3,7 @@ package skills
 // SkillRepository Used to store and retrieve skills based on experience and bookings
 type SkillRepository interface {
 	createCategories(jsonData []SkillCategory) error
-	createSkill(skillObj *Skill, userID uint, skillLevel string) error
+	createSkill(skillObj *Skill, userSkill UserSkill) error
 	createSkillCategories(skillCategories []SkillCategory) error
 	getSkillCategoryById(id uint) (SkillCategory, error)
 	deleteSkillCategoryById(id uint) error
@@ -12,5 +12,22 @@ type SkillRepository interface {
 	getSkillById(id uint) (Skill, error)
 	updateSkill(skillObj Skill) error
 	deleteSkillById(id uint) error
-	fetchAllSkill(limit, offset int, orderBy, keyword string) ([]Skill, int64, error)
+	fetchAllSkill(limit, offset int, orderBy, keyword string, includeDescendants bool) ([]Skill, int64, error)
+	resolveSkillIDs(query SkillQuery) ([]uint, error)
+	fetchSkillChildren(skillID uint, includeDescendants bool) ([]Skill, error)
+	setSkillParent(skillID uint, parentID *uint) error
+	createSkillAlias(alias *SkillAlias) error
+	deleteSkillAlias(skillID, aliasID uint) error
+	assignSkillToUser(userSkill *UserSkill) error
+	unassignSkillFromUser(skillID, userID uint) error
+	setUserSkills(userID uint, userSkills []UserSkill) ([]UserSkill, error)
+	createEndorsement(endorsement *SkillEndorsement, skillID, userID uint) error
+	getEndorsementById(id uint) (SkillEndorsement, error)
+	deleteEndorsementById(id uint) error
+	fetchEndorsements(userID, skillID uint, limit, offset int) ([]Endorsement, int64, error)
+	getUserSkill(skillID, userID uint) (UserSkill, error)
+	updateUserSkill(userSkill UserSkill) error
+	fetchUserSkillProficiencies(userID uint) ([]UserSkillProficiency, error)
+	fetchSkillLevelDistribution(filter DistributionFilter, limit, offset int) ([]SkillLevelDistribution, int64, error)
+	fetchCategoryLevelDistribution(filter DistributionFilter) ([]SkillLevelDistribution, error)
 }
@@ -1,9 +1,11 @@
 package skills
 
 import (
+	"errors"
 	"fmt"
 	log "github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"math"
 	"strings"
 )
 
@@ -12,10 +14,6 @@ type skillRepositoryPostgres struct {
 }
 
 func NewSkillRepositoryPostgres(db *gorm.DB) SkillRepository {
-	err := db.AutoMigrate(&UserSkill{}, &SkillCategory{}, &Skill{})
-	if err != nil {
-		log.Fatal(err)
-	}
 	log.Print("Successfully connected to postgres in skills service!")
 
 	return &skillRepositoryPostgres{
@@ -41,17 +39,131 @@ func (repo *skillRepositoryPostgres) createCategories(jsonData []SkillCategory)
 	return nil
 }
 
-func (repo *skillRepositoryPostgres) createSkill(skillObj *Skill, userID uint, skillLevel string) error {
+// MigrateSkillLevels rates the user skills stored with a free text level and rewrites the level as the label of the rating.
+// Levels that cannot be read stay as they are and the skill stays unrated.
+func MigrateSkillLevels(db *gorm.DB) error {
+	if err := db.Exec(fmt.Sprintf("UPDATE user_skills SET proficiency = %s WHERE proficiency = 0 AND skill_level <> ''",
+		proficiencyFromSkillLevelSQL("skill_level"))).Error; err != nil {
+		return err
+	}
+	labelSQL := proficiencyLabelSQL("proficiency")
+	return db.Exec(fmt.Sprintf("UPDATE user_skills SET skill_level = %s WHERE proficiency > 0 AND skill_level IS DISTINCT FROM %s",
+		labelSQL, labelSQL)).Error
+}
+
+// skillLinkTables are the tables linking a skill to something else, with the column of the other side
+var skillLinkTables = []struct {
+	table  string
+	column string
+}{
+	{table: "experience_skills", column: "experience_id"},
+	{table: "booking_skills", column: "booking_id"},
+}
+
+// MigrateSkillCatalogue turns the skills created per user into a catalogue. Skills with the same normalized name in a
+// category are merged into the oldest one: the users, experiences and bookings of the duplicates move to it and the
+// duplicates are deleted. A user holding the merged skill more than once keeps the verified or best rated record,
+// with the longest use and the latest last use of all of them.
+func MigrateSkillCatalogue(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		normalizedName := normalizedSkillNameSQL("name")
+		if err := tx.Exec(fmt.Sprintf("UPDATE skills SET normalized_name = %s WHERE normalized_name IS DISTINCT FROM %s",
+			normalizedName, normalizedName)).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(`CREATE TEMP TABLE skill_merges ON COMMIT DROP AS
+			SELECT id AS duplicate_id, canonical_id FROM (
+				SELECT id, MIN(id) OVER (PARTITION BY skill_category_id, normalized_name) AS canonical_id
+				FROM skills WHERE deleted_at IS NULL
+			) AS catalogue WHERE id <> canonical_id`).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Exec(`UPDATE user_skills SET skill_id = skill_merges.canonical_id
+			FROM skill_merges WHERE user_skills.skill_id = skill_merges.duplicate_id`).Error; err != nil {
+			return err
+		}
+		// CREATE TABLE AS takes no bind parameters
+		if err := tx.Exec(fmt.Sprintf(`CREATE TEMP TABLE user_skill_merges ON COMMIT DROP AS
+			SELECT id,
+				FIRST_VALUE(id) OVER (PARTITION BY user_id, skill_id ORDER BY assessment = '%s' DESC, proficiency DESC, id) AS kept_id,
+				MAX(years_of_use) OVER (PARTITION BY user_id, skill_id) AS years_of_use,
+				MAX(last_used_on) OVER (PARTITION BY user_id, skill_id) AS last_used_on,
+				COUNT(*) OVER (PARTITION BY user_id, skill_id) AS records
+			FROM user_skills`, AssessmentVerified)).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(`UPDATE user_skills SET years_of_use = user_skill_merges.years_of_use, last_used_on = user_skill_merges.last_used_on
+			FROM user_skill_merges WHERE user_skills.id = user_skill_merges.kept_id AND user_skill_merges.id = user_skill_merges.kept_id
+			AND user_skill_merges.records > 1`).Error; err != nil {
+			return err
+		}
+		// an endorser keeps one endorsement on the kept record, the older one
+		if err := tx.Exec(`UPDATE skill_endorsements SET user_skill_id = user_skill_merges.kept_id FROM user_skill_merges
+			WHERE skill_endorsements.user_skill_id = user_skill_merges.id AND user_skill_merges.id <> user_skill_merges.kept_id
+			AND skill_endorsements.id IN (
+				SELECT DISTINCT ON (merges.kept_id, endorsements.endorser_user_id) endorsements.id
+				FROM skill_endorsements AS endorsements JOIN user_skill_merges AS merges ON merges.id = endorsements.user_skill_id
+				WHERE merges.id <> merges.kept_id
+				ORDER BY merges.kept_id, endorsements.endorser_user_id, endorsements.id)
+			AND NOT EXISTS (SELECT 1 FROM skill_endorsements AS kept WHERE kept.user_skill_id = user_skill_merges.kept_id
+				AND kept.endorser_user_id = skill_endorsements.endorser_user_id)`).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(`DELETE FROM skill_endorsements USING user_skill_merges
+			WHERE skill_endorsements.user_skill_id = user_skill_merges.id AND user_skill_merges.id <> user_skill_merges.kept_id`).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(`DELETE FROM user_skills USING user_skill_merges
+			WHERE user_skills.id = user_skill_merges.id AND user_skill_merges.id <> user_skill_merges.kept_id`).Error; err != nil {
+			return err
+		}
+
+		for _, link := range skillLinkTables {
+			if !tx.Migrator().HasTable(link.table) {
+				continue
+			}
+			// one link per owner moves to the canonical skill unless it is already there, the other links are dropped
+			if err := tx.Exec(fmt.Sprintf(`UPDATE %[1]s SET skill_id = skill_merges.canonical_id FROM skill_merges
+				WHERE %[1]s.skill_id = skill_merges.duplicate_id
+				AND %[1]s.ctid IN (
+					SELECT DISTINCT ON (linked.%[2]s, merges.canonical_id) linked.ctid FROM %[1]s AS linked
+					JOIN skill_merges AS merges ON merges.duplicate_id = linked.skill_id
+					ORDER BY linked.%[2]s, merges.canonical_id, linked.skill_id)
+				AND NOT EXISTS (SELECT 1 FROM %[1]s AS linked WHERE linked.%[2]s = %[1]s.%[2]s AND linked.skill_id = skill_merges.canonical_id)`,
+				link.table, link.column)).Error; err != nil {
+				return err
+			}
+			if err := tx.Exec(fmt.Sprintf("DELETE FROM %[1]s USING skill_merges WHERE %[1]s.skill_id = skill_merges.duplicate_id",
+				link.table)).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Exec(`UPDATE skills SET deleted_at = NOW() FROM skill_merges WHERE skills.id = skill_merges.duplicate_id`).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_skills_category_normalized_name
+			ON skills (skill_category_id, normalized_name) WHERE deleted_at IS NULL`).Error; err != nil {
+			return err
+		}
+		return tx.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_user_skills_user_skill ON user_skills (user_id, skill_id)").Error
+	})
+}
+
+// createSkill adds the skill to the catalogue unless the category already has it, skillObj is then the existing skill.
+// With a user the skill is assigned to the user as well.
+func (repo *skillRepositoryPostgres) createSkill(skillObj *Skill, userSkill UserSkill) error {
 	return repo.db.Transaction(func(tx *gorm.DB) error {
-		if err := tx.Create(&skillObj).Error; err != nil {
+		if err := findOrCreateSkill(tx, skillObj); err != nil {
 			return err
 		}
-		userSkillObj := UserSkill{
-			SkillLevel: skillLevel,
-			SkillID:    skillObj.ID,
-			UserID:     userID,
+		if userSkill.UserID == 0 {
+			fmt.Println("Skill has been stored")
+			return nil
 		}
-		if err := tx.Create(&userSkillObj).Error; err != nil {
+		userSkill.SkillID = skillObj.ID
+		if err := assignSkill(tx, &userSkill); err != nil {
 			return err
 		}
 		fmt.Println("Skill and UserSkill objects have been stored")
@@ -59,6 +171,144 @@ func (repo *skillRepositoryPostgres) createSkill(skillObj *Skill, userID uint, s
 	})
 }
 
+// findOrCreateSkill resolves the name of skillObj within its category and then across the aliases,
+// a skill named after an alias is the aliased skill
+func findOrCreateSkill(tx *gorm.DB, skillObj *Skill) error {
+	err := tx.Where("skill_category_id = ? AND normalized_name = ?", skillObj.SkillCategoryID, skillObj.NormalizedName).First(skillObj).Error
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	err = tx.Where("id IN (?)", tx.Model(&SkillAlias{}).Select("skill_id").Where("normalized_alias = ?", skillObj.NormalizedName)).First(skillObj).Error
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	if err := tx.Where("id = ?", skillObj.SkillCategoryID).First(&SkillCategory{}).Error; err != nil {
+		return err
+	}
+	return tx.Create(skillObj).Error
+}
+
+// assignSkill checks that the skill and the user exist before linking them
+func assignSkill(tx *gorm.DB, userSkill *UserSkill) error {
+	if err := tx.Where("id = ?", userSkill.SkillID).First(&Skill{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Table("users").Where("id = ? AND deleted_at IS NULL", userSkill.UserID).Take(&struct{ ID uint }{}).Error; err != nil {
+		return err
+	}
+	var assigned int64
+	if err := tx.Model(&UserSkill{}).Where("user_id = ? AND skill_id = ?", userSkill.UserID, userSkill.SkillID).Count(&assigned).Error; err != nil {
+		return err
+	}
+	if assigned > 0 {
+		return ErrSkillAlreadyAssigned
+	}
+	return tx.Create(userSkill).Error
+}
+
+func (repo *skillRepositoryPostgres) assignSkillToUser(userSkill *UserSkill) error {
+	if err := assignSkill(repo.db, userSkill); err != nil {
+		return err
+	}
+	fmt.Printf("Skill %d has been assigned to user %d\n", userSkill.SkillID, userSkill.UserID)
+	return nil
+}
+
+func (repo *skillRepositoryPostgres) unassignSkillFromUser(skillID, userID uint) error {
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		unassigned := func() *gorm.DB {
+			return tx.Model(&UserSkill{}).Where("skill_id = ? AND user_id = ?", skillID, userID)
+		}
+		if err := tx.Where("user_skill_id IN (?)", unassigned().Select("id")).Delete(&SkillEndorsement{}).Error; err != nil {
+			return err
+		}
+		result := unassigned().Delete(&UserSkill{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Skill %d has been unassigned from user %d\n", skillID, userID)
+	return nil
+}
+
+// setUserSkills replaces the skills of a user. Skills the user keeps are updated in place so they keep their
+// history, skills missing from the list are unassigned.
+func (repo *skillRepositoryPostgres) setUserSkills(userID uint, userSkills []UserSkill) ([]UserSkill, error) {
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table("users").Where("id = ? AND deleted_at IS NULL", userID).Take(&struct{ ID uint }{}).Error; err != nil {
+			return err
+		}
+		skillIDs := make([]uint, 0, len(userSkills))
+		for _, userSkill := range userSkills {
+			skillIDs = append(skillIDs, userSkill.SkillID)
+		}
+		var found int64
+		if err := tx.Model(&Skill{}).Where("id IN ?", skillIDs).Count(&found).Error; err != nil {
+			return err
+		}
+		if found != int64(len(skillIDs)) {
+			return fmt.Errorf("%w: one or more skills do not exist", gorm.ErrRecordNotFound)
+		}
+
+		removed := func() *gorm.DB {
+			query := tx.Model(&UserSkill{}).Where("user_id = ?", userID)
+			if len(skillIDs) > 0 {
+				query = query.Where("skill_id NOT IN ?", skillIDs)
+			}
+			return query
+		}
+		if err := tx.Where("user_skill_id IN (?)", removed().Select("id")).Delete(&SkillEndorsement{}).Error; err != nil {
+			return err
+		}
+		if err := removed().Delete(&UserSkill{}).Error; err != nil {
+			return err
+		}
+		var current []UserSkill
+		if err := tx.Where("user_id = ?", userID).Find(&current).Error; err != nil {
+			return err
+		}
+		currentBySkill := make(map[uint]UserSkill, len(current))
+		for _, userSkill := range current {
+			currentBySkill[userSkill.SkillID] = userSkill
+		}
+		for i, userSkill := range userSkills {
+			existing, ok := currentBySkill[userSkill.SkillID]
+			if !ok {
+				if err := tx.Create(&userSkills[i]).Error; err != nil {
+					return err
+				}
+				continue
+			}
+			if existing.Proficiency != userSkill.Proficiency {
+				existing.Assessment = AssessmentSelf
+				existing.VerifiedByUserID = nil
+				existing.VerifiedAt = nil
+			}
+			existing.Proficiency = userSkill.Proficiency
+			existing.SkillLevel = userSkill.SkillLevel
+			existing.YearsOfUse = userSkill.YearsOfUse
+			existing.LastUsedOn = userSkill.LastUsedOn
+			if err := tx.Save(&existing).Error; err != nil {
+				return err
+			}
+			userSkills[i] = existing
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	fmt.Printf("Skills of user %d have been set\n", userID)
+	return userSkills, nil
+}
+
 func (repo *skillRepositoryPostgres) createSkillCategories(skillCategories []SkillCategory) error {
 	if err := repo.db.CreateInBatches(skillCategories, len(skillCategories)).Error; err != nil {
 		return err
@@ -112,7 +362,7 @@ func (repo *skillRepositoryPostgres) fetchAllSkillCategories(limit, offset int,
 
 func (repo *skillRepositoryPostgres) getSkillById(id uint) (Skill, error) {
 	var skillObj Skill
-	if err := repo.db.Where("id = ?", id).Preload("SkillCategory").Preload("Bookings").First(&skillObj).Error; err != nil {
+	if err := repo.db.Where("id = ?", id).Preload("SkillCategory").Preload("Bookings").Preload("Aliases").First(&skillObj).Error; err != nil {
 		return Skill{}, err
 	}
 
@@ -121,43 +371,405 @@ func (repo *skillRepositoryPostgres) getSkillById(id uint) (Skill, error) {
 }
 
 func (repo *skillRepositoryPostgres) updateSkill(skillObj Skill) error {
-	if err := repo.db.Save(&skillObj).Error; err != nil {
+	var duplicates int64
+	if err := repo.db.Model(&Skill{}).Where("skill_category_id = ? AND normalized_name = ? AND id <> ?",
+		skillObj.SkillCategoryID, skillObj.NormalizedName, skillObj.ID).Count(&duplicates).Error; err != nil {
+		return err
+	}
+	if duplicates > 0 {
+		return ErrDuplicateSkill
+	}
+	var aliases int64
+	if err := repo.db.Model(&SkillAlias{}).Where("normalized_alias = ? AND skill_id <> ?", skillObj.NormalizedName, skillObj.ID).
+		Count(&aliases).Error; err != nil {
+		return err
+	}
+	if aliases > 0 {
+		return ErrAliasTaken
+	}
+	if err := repo.db.Omit("Aliases").Save(&skillObj).Error; err != nil {
 		return err
 	}
 	fmt.Println("Skill has been updated")
 	return nil
 }
 
+// deleteSkillById moves the children of the skill up to its parent
 func (repo *skillRepositoryPostgres) deleteSkillById(id uint) error {
-	result := repo.db.Where("id = ?", id).Delete(&Skill{})
-	if result.RowsAffected == 0 {
-		return fmt.Errorf("skill with id %d not found", id)
-	}
-	if result.Error != nil {
-		return result.Error
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		var skillObj Skill
+		if err := tx.Where("id = ?", id).First(&skillObj).Error; err != nil {
+			return fmt.Errorf("skill with id %d not found: %w", id, err)
+		}
+		if err := tx.Model(&Skill{}).Where("parent_id = ?", id).Update("parent_id", skillObj.ParentID).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&skillObj).Error
+	})
+	if err != nil {
+		return err
 	}
 
 	fmt.Printf("Skill by id %d has been deleted\n", id)
 	return nil
 }
 
-func (repo *skillRepositoryPostgres) fetchAllSkill(limit, offset int, orderBy, keyword string) ([]Skill, int64, error) {
+func (repo *skillRepositoryPostgres) fetchAllSkill(limit, offset int, orderBy, keyword string, includeDescendants bool) ([]Skill, int64, error) {
 	var skillList []Skill
 	var totalRecords int64
 
 	query := repo.db.Model(&Skill{}).Where("deleted_at IS NULL")
 	if keyword != "" {
-		query = query.Where("LOWER(name) LIKE ?", "%"+strings.ToLower(keyword)+"%")
+		pattern := "%" + NormalizeSkillName(keyword) + "%"
+		matched := repo.db.Model(&Skill{}).Select("skills.id").
+			Where("LOWER(skills.name) LIKE ? OR skills.id IN (?)", pattern,
+				repo.db.Model(&SkillAlias{}).Select("skill_id").Where("normalized_alias LIKE ?", pattern))
+		if includeDescendants {
+			matched = repo.skillTree(matched)
+		}
+		query = query.Where("skills.id IN (?)", matched)
 	}
 
 	err := query.Count(&totalRecords).Error
 	if err != nil {
 		return nil, 0, err
 	}
-	err = query.Order(orderBy).Limit(limit).Offset(offset).Preload("SkillCategory").Preload("Bookings").Find(&skillList).Error
+	err = query.Order(orderBy).Limit(limit).Offset(offset).Preload("SkillCategory").Preload("Bookings").Preload("Aliases").Find(&skillList).Error
 	if err != nil {
 		return nil, totalRecords, err
 	}
 
 	return skillList, totalRecords, nil
 }
+
+// skillTree selects the ids of the given skills and of every skill below them
+func (repo *skillRepositoryPostgres) skillTree(skillIDs interface{}) *gorm.DB {
+	return repo.db.Raw(`WITH RECURSIVE skill_tree AS (
+			SELECT skills.id FROM skills WHERE skills.deleted_at IS NULL AND skills.id IN (?)
+			UNION
+			SELECT skills.id FROM skills JOIN skill_tree ON skills.parent_id = skill_tree.id WHERE skills.deleted_at IS NULL
+		) SELECT id FROM skill_tree`, skillIDs)
+}
+
+func (repo *skillRepositoryPostgres) resolveSkillIDs(query SkillQuery) ([]uint, error) {
+	matched := repo.db.Model(&Skill{}).Select("skills.id")
+	if query.SkillID != 0 {
+		matched = matched.Where("skills.id = ?", query.SkillID)
+	} else {
+		name := NormalizeSkillName(query.Name)
+		matched = matched.Where("skills.normalized_name = ? OR skills.id IN (?)", name,
+			repo.db.Model(&SkillAlias{}).Select("skill_id").Where("normalized_alias = ?", name))
+	}
+	if query.IncludeDescendants {
+		matched = repo.skillTree(matched)
+	}
+	var skillIDs []uint
+	if err := matched.Scan(&skillIDs).Error; err != nil {
+		return nil, err
+	}
+	return skillIDs, nil
+}
+
+func (repo *skillRepositoryPostgres) fetchSkillChildren(skillID uint, includeDescendants bool) ([]Skill, error) {
+	var children []Skill
+	query := repo.db.Where("parent_id = ?", skillID)
+	if includeDescendants {
+		query = repo.db.Where("id IN (?) AND id <> ?", repo.skillTree([]uint{skillID}), skillID)
+	}
+	if err := query.Order("name asc").Preload("SkillCategory").Preload("Aliases").Find(&children).Error; err != nil {
+		return nil, err
+	}
+	return children, nil
+}
+
+// setSkillParent places a skill below parentID, nil makes it a top level skill
+func (repo *skillRepositoryPostgres) setSkillParent(skillID uint, parentID *uint) error {
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("id = ?", skillID).First(&Skill{}).Error; err != nil {
+			return err
+		}
+		if parentID != nil {
+			if err := tx.Where("id = ?", *parentID).First(&Skill{}).Error; err != nil {
+				return err
+			}
+			var below int64
+			if err := tx.Table("(?) AS descendants", repo.skillTree([]uint{skillID})).Where("id = ?", *parentID).Count(&below).Error; err != nil {
+				return err
+			}
+			if below > 0 {
+				return ErrSkillHierarchyCycle
+			}
+		}
+		return tx.Model(&Skill{}).Where("id = ?", skillID).Update("parent_id", parentID).Error
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Parent of skill %d has been set\n", skillID)
+	return nil
+}
+
+func (repo *skillRepositoryPostgres) createSkillAlias(alias *SkillAlias) error {
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("id = ?", alias.SkillID).First(&Skill{}).Error; err != nil {
+			return err
+		}
+		var taken int64
+		if err := tx.Model(&Skill{}).Where("normalized_name = ?", alias.NormalizedAlias).Count(&taken).Error; err != nil {
+			return err
+		}
+		if taken == 0 {
+			if err := tx.Model(&SkillAlias{}).Where("normalized_alias = ?", alias.NormalizedAlias).Count(&taken).Error; err != nil {
+				return err
+			}
+		}
+		if taken > 0 {
+			return ErrAliasTaken
+		}
+		return tx.Create(alias).Error
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Alias %q has been added to skill %d\n", alias.Alias, alias.SkillID)
+	return nil
+}
+
+func (repo *skillRepositoryPostgres) deleteSkillAlias(skillID, aliasID uint) error {
+	result := repo.db.Where("id = ? AND skill_id = ?", aliasID, skillID).Delete(&SkillAlias{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	fmt.Printf("Alias %d has been removed from skill %d\n", aliasID, skillID)
+	return nil
+}
+
+func (repo *skillRepositoryPostgres) getUserSkill(skillID, userID uint) (UserSkill, error) {
+	var userSkill UserSkill
+	if err := repo.db.Where("skill_id = ? AND user_id = ?", skillID, userID).First(&userSkill).Error; err != nil {
+		return UserSkill{}, err
+	}
+	return userSkill, nil
+}
+
+func (repo *skillRepositoryPostgres) updateUserSkill(userSkill UserSkill) error {
+	if err := repo.db.Save(&userSkill).Error; err != nil {
+		return err
+	}
+	fmt.Printf("Proficiency of skill %d of user %d has been updated\n", userSkill.SkillID, userSkill.UserID)
+	return nil
+}
+
+func (repo *skillRepositoryPostgres) fetchUserSkillProficiencies(userID uint) ([]UserSkillProficiency, error) {
+	var userSkills []UserSkill
+	err := repo.db.Joins("JOIN skills ON skills.id = user_skills.skill_id AND skills.deleted_at IS NULL").
+		Where("user_skills.user_id = ?", userID).
+		Order("user_skills.proficiency desc, user_skills.id asc").
+		Find(&userSkills).Error
+	if err != nil {
+		return nil, err
+	}
+	skillIDs := make([]uint, 0, len(userSkills))
+	for _, userSkill := range userSkills {
+		skillIDs = append(skillIDs, userSkill.SkillID)
+	}
+	var skillList []Skill
+	if err := repo.db.Where("id IN ?", skillIDs).Preload("SkillCategory").Find(&skillList).Error; err != nil {
+		return nil, err
+	}
+	skillsByID := make(map[uint]Skill, len(skillList))
+	for _, skill := range skillList {
+		skillsByID[skill.ID] = skill
+	}
+	proficiencies := make([]UserSkillProficiency, 0, len(userSkills))
+	for _, userSkill := range userSkills {
+		proficiencies = append(proficiencies, UserSkillProficiency{Skill: skillsByID[userSkill.SkillID], UserSkill: userSkill})
+	}
+	return proficiencies, nil
+}
+
+// levelDistributionRow is a row of the distribution queries, one count column per proficiency level
+type levelDistributionRow struct {
+	SkillID            uint
+	SkillName          string
+	SkillCategoryID    uint
+	SkillCategory      string
+	Users              int64
+	Rated              int64
+	Verified           int64
+	AverageProficiency float64
+	Level1             int64
+	Level2             int64
+	Level3             int64
+	Level4             int64
+	Level5             int64
+}
+
+func (row levelDistributionRow) distribution() SkillLevelDistribution {
+	counts := []int64{row.Level1, row.Level2, row.Level3, row.Level4, row.Level5}
+	levels := make([]LevelCount, 0, len(ProficiencyLevels))
+	for i, level := range ProficiencyLevels {
+		levels = append(levels, LevelCount{Level: level.Level, Label: level.Label, Count: counts[i]})
+	}
+	return SkillLevelDistribution{
+		SkillID:            row.SkillID,
+		SkillName:          row.SkillName,
+		SkillCategoryID:    row.SkillCategoryID,
+		SkillCategory:      row.SkillCategory,
+		Users:              row.Users,
+		Rated:              row.Rated,
+		Verified:           row.Verified,
+		AverageProficiency: math.Round(row.AverageProficiency*100) / 100,
+		Levels:             levels,
+	}
+}
+
+// userSkillLevels has one row per user and skill of the catalogue. A user holding the same skill
+// more than once counts once, with the best proficiency and verified if any of them is.
+func (repo *skillRepositoryPostgres) userSkillLevels(filter DistributionFilter) *gorm.DB {
+	query := repo.db.Table("user_skills").
+		Select(`user_skills.user_id, skills.id AS skill_id, skills.name AS skill_name, skills.skill_category_id,
+			MAX(user_skills.proficiency) AS proficiency, BOOL_OR(user_skills.assessment = ?) AS verified`, AssessmentVerified).
+		Joins("JOIN skills ON skills.id = user_skills.skill_id AND skills.deleted_at IS NULL").
+		Joins("JOIN users ON users.id = user_skills.user_id AND users.deleted_at IS NULL").
+		Group("user_skills.user_id, skills.id")
+	if filter.SkillCategoryID != 0 {
+		query = query.Where("skills.skill_category_id = ?", filter.SkillCategoryID)
+	}
+	if filter.Keyword != "" {
+		query = query.Where("LOWER(skills.name) LIKE ?", "%"+strings.ToLower(filter.Keyword)+"%")
+	}
+	return query
+}
+
+func levelDistributionSelect(users string) string {
+	columns := []string{
+		users + " AS users",
+		"COUNT(*) FILTER (WHERE user_levels.proficiency > 0) AS rated",
+		"COUNT(*) FILTER (WHERE user_levels.verified) AS verified",
+		"COALESCE(AVG(user_levels.proficiency) FILTER (WHERE user_levels.proficiency > 0), 0) AS average_proficiency",
+	}
+	for _, level := range ProficiencyLevels {
+		columns = append(columns, fmt.Sprintf("COUNT(*) FILTER (WHERE user_levels.proficiency = %d) AS level%d", level.Level, level.Level))
+	}
+	return strings.Join(columns, ", ")
+}
+
+func (repo *skillRepositoryPostgres) fetchSkillLevelDistribution(filter DistributionFilter, limit, offset int) ([]SkillLevelDistribution, int64, error) {
+	query := repo.db.Table("(?) AS user_levels", repo.userSkillLevels(filter)).
+		Select("user_levels.skill_id, user_levels.skill_name, user_levels.skill_category_id, COALESCE(skill_categories.name, '') AS skill_category, " +
+			levelDistributionSelect("COUNT(*)")).
+		Joins("LEFT JOIN skill_categories ON skill_categories.id = user_levels.skill_category_id").
+		Group("user_levels.skill_id, user_levels.skill_name, user_levels.skill_category_id, skill_categories.name")
+
+	var totalRecords int64
+	if err := repo.db.Table("(?) AS distribution", query).Count(&totalRecords).Error; err != nil {
+		return nil, 0, err
+	}
+	var rows []levelDistributionRow
+	if err := query.Order("users desc, skill_name asc").Limit(limit).Offset(offset).Scan(&rows).Error; err != nil {
+		return nil, totalRecords, err
+	}
+	distributions := make([]SkillLevelDistribution, 0, len(rows))
+	for _, row := range rows {
+		distributions = append(distributions, row.distribution())
+	}
+	return distributions, totalRecords, nil
+}
+
+func (repo *skillRepositoryPostgres) fetchCategoryLevelDistribution(filter DistributionFilter) ([]SkillLevelDistribution, error) {
+	var rows []levelDistributionRow
+	err := repo.db.Table("(?) AS user_levels", repo.userSkillLevels(filter)).
+		Select("user_levels.skill_category_id, COALESCE(skill_categories.name, '') AS skill_category, " +
+			levelDistributionSelect("COUNT(DISTINCT user_levels.user_id)")).
+		Joins("LEFT JOIN skill_categories ON skill_categories.id = user_levels.skill_category_id").
+		Group("user_levels.skill_category_id, skill_categories.name").
+		Order("skill_category asc").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	distributions := make([]SkillLevelDistribution, 0, len(rows))
+	for _, row := range rows {
+		distributions = append(distributions, row.distribution())
+	}
+	return distributions, nil
+}
+
+func (repo *skillRepositoryPostgres) createEndorsement(endorsement *SkillEndorsement, skillID, userID uint) error {
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		var userSkill UserSkill
+		if err := tx.Joins("JOIN users ON users.id = user_skills.user_id AND users.deleted_at IS NULL").
+			Where("user_skills.skill_id = ? AND user_skills.user_id = ?", skillID, userID).First(&userSkill).Error; err != nil {
+			return err
+		}
+		if err := tx.Table("users").Where("id = ? AND deleted_at IS NULL", endorsement.EndorserUserID).Take(&struct{ ID uint }{}).Error; err != nil {
+			return err
+		}
+		var endorsed int64
+		if err := tx.Model(&SkillEndorsement{}).Where("user_skill_id = ? AND endorser_user_id = ?", userSkill.ID, endorsement.EndorserUserID).
+			Count(&endorsed).Error; err != nil {
+			return err
+		}
+		if endorsed > 0 {
+			return ErrAlreadyEndorsed
+		}
+		endorsement.UserSkillID = userSkill.ID
+		return tx.Create(endorsement).Error
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Skill %d of user %d has been endorsed by user %d\n", skillID, userID, endorsement.EndorserUserID)
+	return nil
+}
+
+func (repo *skillRepositoryPostgres) getEndorsementById(id uint) (SkillEndorsement, error) {
+	var endorsement SkillEndorsement
+	if err := repo.db.Where("id = ?", id).First(&endorsement).Error; err != nil {
+		return SkillEndorsement{}, err
+	}
+	return endorsement, nil
+}
+
+func (repo *skillRepositoryPostgres) deleteEndorsementById(id uint) error {
+	result := repo.db.Where("id = ?", id).Delete(&SkillEndorsement{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	fmt.Printf("Endorsement %d has been deleted\n", id)
+	return nil
+}
+
+// fetchEndorsements lists the endorsements of the skills of a user, of one skill when skillID is set, newest first
+func (repo *skillRepositoryPostgres) fetchEndorsements(userID, skillID uint, limit, offset int) ([]Endorsement, int64, error) {
+	query := repo.db.Table("skill_endorsements").
+		Joins("JOIN user_skills ON user_skills.id = skill_endorsements.user_skill_id").
+		Joins("JOIN skills ON skills.id = user_skills.skill_id AND skills.deleted_at IS NULL").
+		Joins("JOIN users AS endorsers ON endorsers.id = skill_endorsements.endorser_user_id AND endorsers.deleted_at IS NULL").
+		Where("user_skills.user_id = ?", userID)
+	if skillID != 0 {
+		query = query.Where("user_skills.skill_id = ?", skillID)
+	}
+
+	var totalRecords int64
+	if err := query.Count(&totalRecords).Error; err != nil {
+		return nil, 0, err
+	}
+	var endorsements []Endorsement
+	err := query.Select(`skill_endorsements.id, user_skills.user_id, skills.id AS skill_id, skills.name AS skill_name,
+			skill_endorsements.endorser_user_id, endorsers.first_name AS endorser_first_name, endorsers.last_name AS endorser_last_name,
+			endorsers.job_title AS endorser_job_title, skill_endorsements.comment, skill_endorsements.created_at`).
+		Order("skill_endorsements.created_at desc, skill_endorsements.id desc").Limit(limit).Offset(offset).
+		Scan(&endorsements).Error
+	if err != nil {
+		return nil, totalRecords, err
+	}
+	return endorsements, totalRecords, nil
+}
@@ -1,5 +1,12 @@
 package skills
 
+import (
+	"github.com/Octek/resource-profile-management-backend.git/api/auth"
+	"github.com/gin-gonic/gin"
+	"strings"
+	"time"
+)
+
 type SkillService struct {
 	skillRepository SkillRepository
 }
@@ -11,8 +18,62 @@ func NewService(r SkillRepository) SkillService {
 func (svc *SkillService) CreateCategories(jsonData []SkillCategory) error {
 	return svc.skillRepository.createCategories(jsonData)
 }
-func (svc *SkillService) CreateSkill(skillObj *Skill, userID uint, skillLevel string) error {
-	return svc.skillRepository.createSkill(skillObj, userID, skillLevel)
+
+// CreateSkill adds a skill to the catalogue and assigns it to the user of userSkill, if any. A skill the category
+// already has is not created again, skillObj is then the existing skill.
+func (svc *SkillService) CreateSkill(skillObj *Skill, userSkill UserSkill) error {
+	if err := normalizeSkill(skillObj); err != nil {
+		return err
+	}
+	return svc.skillRepository.createSkill(skillObj, selfAssessed(userSkill))
+}
+
+func (svc *SkillService) AssignSkillToUser(userSkill UserSkill) (UserSkill, error) {
+	userSkill = selfAssessed(userSkill)
+	if err := svc.skillRepository.assignSkillToUser(&userSkill); err != nil {
+		return UserSkill{}, err
+	}
+	return userSkill, nil
+}
+
+func (svc *SkillService) UnassignSkillFromUser(skillID, userID uint) error {
+	return svc.skillRepository.unassignSkillFromUser(skillID, userID)
+}
+
+// SetUserSkills makes the given skills the skills of the user, a skill listed twice is assigned once with the last
+// rating given. Verified ratings are kept for the skills whose proficiency does not change.
+func (svc *SkillService) SetUserSkills(userID uint, userSkills []UserSkill) ([]UserSkill, error) {
+	positions := make(map[uint]int, len(userSkills))
+	uniqueSkills := make([]UserSkill, 0, len(userSkills))
+	for _, userSkill := range userSkills {
+		userSkill.UserID = userID
+		userSkill = selfAssessed(userSkill)
+		if position, ok := positions[userSkill.SkillID]; ok {
+			uniqueSkills[position] = userSkill
+			continue
+		}
+		positions[userSkill.SkillID] = len(uniqueSkills)
+		uniqueSkills = append(uniqueSkills, userSkill)
+	}
+	return svc.skillRepository.setUserSkills(userID, uniqueSkills)
+}
+
+// selfAssessed is a new rating given by the user, any verification has to be made again
+func selfAssessed(userSkill UserSkill) UserSkill {
+	userSkill.SkillLevel = ProficiencyLabel(userSkill.Proficiency)
+	userSkill.Assessment = AssessmentSelf
+	userSkill.VerifiedByUserID = nil
+	userSkill.VerifiedAt = nil
+	return userSkill
+}
+
+func normalizeSkill(skillObj *Skill) error {
+	skillObj.Name = strings.Join(strings.Fields(skillObj.Name), " ")
+	skillObj.NormalizedName = NormalizeSkillName(skillObj.Name)
+	if skillObj.NormalizedName == "" {
+		return ErrEmptySkillName
+	}
+	return nil
 }
 func (svc *SkillService) CreateSkillCategories(skillCategoryObj []SkillCategory) error {
 	return svc.skillRepository.createSkillCategories(skillCategoryObj)
@@ -31,6 +92,9 @@ func (svc *SkillService) FetchAllSkillCategories(limit, offset int, orderBy stri
 	return svc.skillRepository.fetchAllSkillCategories(limit, offset, orderBy)
 }
 func (svc *SkillService) UpdateSkill(skillObj Skill) error {
+	if err := normalizeSkill(&skillObj); err != nil {
+		return err
+	}
 	return svc.skillRepository.updateSkill(skillObj)
 }
 func (svc *SkillService) GetSkillById(id uint) (Skill, error) {
@@ -39,6 +103,123 @@ func (svc *SkillService) GetSkillById(id uint) (Skill, error) {
 func (svc *SkillService) DeleteSkillById(id uint) error {
 	return svc.skillRepository.deleteSkillById(id)
 }
-func (svc *SkillService) FetchAllSkill(limit, offset int, orderBy, keyword string) ([]Skill, int64, error) {
-	return svc.skillRepository.fetchAllSkill(limit, offset, orderBy, keyword)
+
+// FetchAllSkill matches the keyword against the names and the aliases of the skills
+func (svc *SkillService) FetchAllSkill(limit, offset int, orderBy, keyword string, includeDescendants bool) ([]Skill, int64, error) {
+	return svc.skillRepository.fetchAllSkill(limit, offset, orderBy, keyword, includeDescendants)
+}
+
+// ResolveSkillIDs lists the skills a query selects, a name matches a skill or one of its aliases exactly
+func (svc *SkillService) ResolveSkillIDs(query SkillQuery) ([]uint, error) {
+	return svc.skillRepository.resolveSkillIDs(query)
+}
+
+func (svc *SkillService) GetSkillChildren(skillID uint, includeDescendants bool) ([]Skill, error) {
+	if _, err := svc.skillRepository.getSkillById(skillID); err != nil {
+		return nil, err
+	}
+	return svc.skillRepository.fetchSkillChildren(skillID, includeDescendants)
+}
+
+func (svc *SkillService) SetSkillParent(skillID uint, parentID *uint) error {
+	if parentID != nil && *parentID == skillID {
+		return ErrSkillHierarchyCycle
+	}
+	return svc.skillRepository.setSkillParent(skillID, parentID)
+}
+
+func (svc *SkillService) AddSkillAlias(skillID uint, alias string) (SkillAlias, error) {
+	skillAlias := SkillAlias{
+		SkillID:         skillID,
+		Alias:           strings.Join(strings.Fields(alias), " "),
+		NormalizedAlias: NormalizeSkillName(alias),
+	}
+	if skillAlias.NormalizedAlias == "" {
+		return SkillAlias{}, ErrEmptySkillName
+	}
+	if err := svc.skillRepository.createSkillAlias(&skillAlias); err != nil {
+		return SkillAlias{}, err
+	}
+	return skillAlias, nil
+}
+
+func (svc *SkillService) RemoveSkillAlias(skillID, aliasID uint) error {
+	return svc.skillRepository.deleteSkillAlias(skillID, aliasID)
+}
+
+func (svc *SkillService) GetUserSkillProficiencies(userID uint) ([]UserSkillProficiency, error) {
+	return svc.skillRepository.fetchUserSkillProficiencies(userID)
+}
+
+// UpdateUserSkillProficiency rates a skill of a user. With a verifier the rating is a verified assessment,
+// without one it is a self assessment and a former verification no longer applies.
+func (svc *SkillService) UpdateUserSkillProficiency(skillID, userID uint, update ProficiencyUpdate) (UserSkill, error) {
+	if update.VerifiedByUserID != 0 && update.VerifiedByUserID == userID {
+		return UserSkill{}, ErrSelfVerification
+	}
+	if _, err := svc.skillRepository.getSkillById(skillID); err != nil {
+		return UserSkill{}, err
+	}
+	userSkill, err := svc.skillRepository.getUserSkill(skillID, userID)
+	if err != nil {
+		return UserSkill{}, err
+	}
+	userSkill.Proficiency = update.Proficiency
+	userSkill.SkillLevel = ProficiencyLabel(update.Proficiency)
+	userSkill.YearsOfUse = update.YearsOfUse
+	userSkill.LastUsedOn = update.LastUsedOn
+	userSkill.Assessment = AssessmentSelf
+	userSkill.VerifiedByUserID = nil
+	userSkill.VerifiedAt = nil
+	if update.VerifiedByUserID != 0 {
+		verifiedAt := time.Now()
+		userSkill.Assessment = AssessmentVerified
+		userSkill.VerifiedByUserID = &update.VerifiedByUserID
+		userSkill.VerifiedAt = &verifiedAt
+	}
+	if err := svc.skillRepository.updateUserSkill(userSkill); err != nil {
+		return UserSkill{}, err
+	}
+	return userSkill, nil
+}
+
+func (svc *SkillService) GetSkillLevelDistribution(filter DistributionFilter, limit, offset int) ([]SkillLevelDistribution, int64, error) {
+	return svc.skillRepository.fetchSkillLevelDistribution(filter, limit, offset)
+}
+
+func (svc *SkillService) GetCategoryLevelDistribution(filter DistributionFilter) ([]SkillLevelDistribution, error) {
+	return svc.skillRepository.fetchCategoryLevelDistribution(filter)
+}
+
+func (svc *SkillService) EndorseSkill(skillID, userID, endorserUserID uint, comment string) (SkillEndorsement, error) {
+	if userID == endorserUserID {
+		return SkillEndorsement{}, ErrSelfEndorsement
+	}
+	endorsement := SkillEndorsement{EndorserUserID: endorserUserID, Comment: strings.TrimSpace(comment)}
+	if err := svc.skillRepository.createEndorsement(&endorsement, skillID, userID); err != nil {
+		return SkillEndorsement{}, err
+	}
+	return endorsement, nil
+}
+
+func (svc *SkillService) DeleteEndorsementById(id uint) error {
+	return svc.skillRepository.deleteEndorsementById(id)
+}
+
+func (svc *SkillService) GetEndorsements(userID, skillID uint, limit, offset int) ([]Endorsement, int64, error) {
+	return svc.skillRepository.fetchEndorsements(userID, skillID, limit, offset)
+}
+
+// isEndorser is the owner check of the routes addressing an endorsement by its id, an endorsement belongs to the
+// user who gave it. A missing endorsement is reported as not found.
+func (svc *SkillService) isEndorser(c *gin.Context, userID uint) (bool, error) {
+	endorsementID, err := auth.ParamID(c, "endorsementId")
+	if err != nil {
+		return false, err
+	}
+	endorsement, err := svc.skillRepository.getEndorsementById(endorsementID)
+	if err != nil {
+		return false, err
+	}
+	return endorsement.EndorserUserID == userID, nil
 }
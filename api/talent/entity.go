@@ -0,0 +1,92 @@
+package talent
+
+import (
+	"time"
+)
+
+const (
+	// weights of the ranking, a required skill outweighs everything else
+	requiredSkillScore     = 10.0
+	optionalSkillScore     = 5.0
+	levelAboveMinimumScore = 2.0
+	belowMinimumLevelScore = 1.0
+	verifiedSkillScore     = 1.0
+	endorsementScore       = 0.5
+	maxScoredEndorsements  = 10
+	yearOfExperienceScore  = 0.5
+	maxScoredYears         = 20.0
+	jobTitleMatchScore     = 3.0
+	locationMatchScore     = 2.0
+	freeSlotScore          = 0.25
+	maxScoredFreeSlots     = 20
+	searchDateLayout       = "2006-01-02"
+	// maxAvailabilityCandidates is how many of the best matches an availability filter checks for free slots
+	maxAvailabilityCandidates = 100
+)
+
+// SearchCriteria are the filters of a talent search, every filter that is set has to match.
+// Optional skills do not filter, they only improve the rank of the users that have them.
+type SearchCriteria struct {
+	Skills               []SkillCriterion
+	UserCategoryID       uint
+	Location             string
+	JobTitle             string
+	MinYearsOfExperience float64
+	AvailableFrom        *time.Time
+	AvailableTo          *time.Time
+}
+
+// SkillCriterion matches a skill by id or by name, a name also matches the aliases of a skill.
+// With IncludeDescendants the skills below the matched skill match as well.
+type SkillCriterion struct {
+	SkillID            uint
+	Name               string
+	MinLevel           int
+	Optional           bool
+	IncludeDescendants bool
+	// skillIDs are the skills of the catalogue the criterion resolves to
+	skillIDs []uint
+}
+
+type SearchResult struct {
+	UserID            uint           `json:"user_id"`
+	FirstName         string         `json:"first_name"`
+	LastName          string         `json:"last_name"`
+	JobTitle          string         `json:"job_title"`
+	Location          string         `json:"location"`
+	UserCategory      string         `json:"user_category"`
+	Score             float64        `json:"score"`
+	YearsOfExperience float64        `json:"years_of_experience"`
+	FreeSlots         *int           `json:"free_slots,omitempty"`
+	MatchedSkills     []MatchedSkill `json:"matched_skills"`
+	Explanations      []string       `json:"explanations"`
+}
+
+type MatchedSkill struct {
+	SkillID      uint   `json:"skill_id"`
+	Name         string `json:"name"`
+	Level        string `json:"level"`
+	LevelRank    int    `json:"level_rank"`
+	Verified     bool   `json:"verified"`
+	Endorsements int64  `json:"endorsements"`
+	Optional     bool   `json:"optional"`
+}
+
+// UserSkillLevel is a skill of a user with its proficiency, how it was assessed and how often it was endorsed
+type UserSkillLevel struct {
+	UserID       uint
+	SkillID      uint
+	Name         string
+	SkillLevel   string
+	Proficiency  int
+	Assessment   string
+	Endorsements int64
+}
+
+// ExperiencePeriod is the time span of one experience of a user
+type ExperiencePeriod struct {
+	UserID             uint
+	StartDate          time.Time
+	EndDate            time.Time
+	IsCurrentlyWorking bool
+}
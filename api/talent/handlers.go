@@ -0,0 +1,130 @@
+package talent
+
+import (
+	"fmt"
+	"github.com/Octek/resource-profile-management-backend.git/api/auth"
+	"github.com/Octek/resource-profile-management-backend.git/api/bookings"
+	"github.com/Octek/resource-profile-management-backend.git/utils"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+var validate = utils.NewValidator()
+
+// Routes Exports all routes handled by this service, every route declares who may call it
+func Routes(router gin.IRouter, talentSvc TalentService) {
+	talentRouter := router.Group("/talent")
+	{
+		talentRouter.POST("/search", auth.Authorize(auth.AllowAuthenticated), func(c *gin.Context) {
+			HandlerToSearchTalent(c, talentSvc)
+		})
+	}
+}
+
+// HandlerToSearchTalent godoc
+// @Tags Talent
+// @Summary Search talent
+// @Description Find users by any combination of skills with a minimum level, category, location, job title, years of experience and availability.
+// @Description Results are ranked best match first and explain why they matched. Skill levels range from 1 (beginner) to 5 (expert)
+// @Description Skill names also match aliases, "golang" finds "Go", and can include the skills below them in the hierarchy.
+// @Description Verified and endorsed skills rank higher. With an availability filter only the 100 best matches are checked for free slots,
+// @Description unchecked_candidates counts the matches below them that are left out of the results and the total.
+// @ID search-talent
+// @Security ApiAuthKey
+// @Accept json
+// @Produce json
+// @Param   limit    query     int     false  "example - 50"     limit(int)
+// @Param   offset     query     int     false  "example - 0"     offset(int)
+// @Param TalentSearchRequest body TalentSearchRequest true "Search filters"
+// @Success 200 {object} TalentSearchResponse
+// @Failure 400 {object} string
+// @Failure 500 {object} string
+// @Router /talent/search [post]
+func HandlerToSearchTalent(c *gin.Context, talentSvc TalentService) {
+	fmt.Println("HandlerToSearchTalent")
+	page, ok := utils.BindPage(c, "")
+	if !ok {
+		return
+	}
+
+	var searchRequest TalentSearchRequest
+	if err := c.ShouldBindJSON(&searchRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.InvalidJsonBody, err), err))
+		return
+	}
+	if err := validate.Struct(searchRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.RequestSchemaInvalid, err), err))
+		return
+	}
+	criteria := SearchCriteria{
+		UserCategoryID:       searchRequest.UserCategoryID,
+		Location:             searchRequest.Location,
+		JobTitle:             searchRequest.JobTitle,
+		MinYearsOfExperience: searchRequest.MinYearsOfExperience,
+	}
+	for _, skillRequest := range searchRequest.Skills {
+		criteria.Skills = append(criteria.Skills, SkillCriterion{
+			SkillID:            skillRequest.SkillID,
+			Name:               skillRequest.Name,
+			MinLevel:           skillRequest.MinLevel,
+			Optional:           skillRequest.Optional,
+			IncludeDescendants: skillRequest.IncludeDescendants,
+		})
+	}
+	if searchRequest.AvailableFrom != "" {
+		// the date formats are checked by the validator
+		fromDate, _ := time.Parse(searchDateLayout, searchRequest.AvailableFrom)
+		toDate := fromDate.AddDate(0, 0, 6)
+		if searchRequest.AvailableTo != "" {
+			toDate, _ = time.Parse(searchDateLayout, searchRequest.AvailableTo)
+		}
+		if toDate.Before(fromDate) || toDate.Sub(fromDate) > bookings.MaxFreeSlotsRangeDays*24*time.Hour {
+			utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.InvalidDateRangeMessage, bookings.MaxFreeSlotsRangeDays), nil))
+			return
+		}
+		criteria.AvailableFrom = &fromDate
+		criteria.AvailableTo = &toDate
+	}
+
+	results, totalRecords, unchecked, err := talentSvc.SearchTalent(criteria, page.Limit, page.Offset)
+	if err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusInternalServerError, fmt.Sprintf(utils.SomethingWentWrongWhileSearchingTalent, err), err))
+		return
+	}
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.Success, Data: TalentSearchResponse{
+		RecordsResponse:     utils.RecordsResponse{Total: totalRecords, RecordsFiltered: len(results), Data: results},
+		UncheckedCandidates: unchecked,
+	}})
+}
+
+// All requested and response structs
+
+type TalentSearchRequest struct {
+	Skills               []SkillCriterionRequest `json:"skills" validate:"max=20,dive"`
+	UserCategoryID       uint                    `json:"user_category_id"`
+	Location             string                  `json:"location" validate:"max=100"`
+	JobTitle             string                  `json:"job_title" validate:"max=100"`
+	MinYearsOfExperience float64                 `json:"min_years_of_experience" validate:"min=0,max=60"`
+	// AvailableFrom and AvailableTo are YYYY-MM-DD, only users with a free slot in the range match.
+	// AvailableTo defaults to a week after AvailableFrom.
+	AvailableFrom string `json:"available_from" validate:"omitempty,datetime=2006-01-02"`
+	AvailableTo   string `json:"available_to" validate:"omitempty,datetime=2006-01-02,excluded_without=AvailableFrom"`
+}
+
+// TalentSearchResponse is a page of the results, UncheckedCandidates are the matches that were not checked for
+// free slots because they rank below the best 100, a client narrows the filters when it is not zero
+type TalentSearchResponse struct {
+	utils.RecordsResponse
+	UncheckedCandidates int `json:"unchecked_candidates"`
+}
+
+type SkillCriterionRequest struct {
+	SkillID  uint   `json:"skill_id" validate:"required_without=Name"`
+	Name     string `json:"name" validate:"required_without=SkillID,max=100"`
+	MinLevel int    `json:"min_level" validate:"min=0,max=5"`
+	// Optional skills do not filter, they rank the users that have them higher
+	Optional bool `json:"optional"`
+	// IncludeDescendants also matches the skills below the skill, "Kubernetes" for "Container Orchestration"
+	IncludeDescendants bool `json:"include_descendants"`
+}
@@ -0,0 +1,12 @@
+package talent
+
+import (
+	user "github.com/Octek/resource-profile-management-backend.git/api/users"
+)
+
+// TalentRepository Used to find the users that match a talent search
+type TalentRepository interface {
+	fetchCandidates(criteria SearchCriteria) ([]user.User, error)
+	fetchUserSkillLevels(userIDs []uint) ([]UserSkillLevel, error)
+	fetchExperiencePeriods(userIDs []uint) ([]ExperiencePeriod, error)
+}
@@ -0,0 +1,77 @@
+package talent
+
+import (
+	"strings"
+
+	user "github.com/Octek/resource-profile-management-backend.git/api/users"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type talentRepositoryPostgres struct {
+	db *gorm.DB
+}
+
+// NewTalentRepositoryPostgres has no tables of its own, it reads the users, skills and experiences
+func NewTalentRepositoryPostgres(db *gorm.DB) TalentRepository {
+	log.Print("Successfully connected to postgres in talent service!")
+
+	return &talentRepositoryPostgres{
+		db: db,
+	}
+}
+
+// fetchCandidates applies the filters that can be answered by the database, the years of
+// experience and the free slots are checked afterwards. An availability filter only keeps the
+// users with availability settings.
+func (repo *talentRepositoryPostgres) fetchCandidates(criteria SearchCriteria) ([]user.User, error) {
+	var candidates []user.User
+	query := repo.db.Model(&user.User{}).
+		Select("users.id, users.first_name, users.last_name, users.job_title, users.location, users.user_category_id").
+		Where("users.deleted_at IS NULL").Preload("UserCategory")
+	if criteria.UserCategoryID != 0 {
+		query = query.Where("users.user_category_id = ?", criteria.UserCategoryID)
+	}
+	if criteria.Location != "" {
+		query = query.Where("LOWER(users.location) LIKE ?", "%"+strings.ToLower(criteria.Location)+"%")
+	}
+	if criteria.JobTitle != "" {
+		query = query.Where("LOWER(users.job_title) LIKE ?", "%"+strings.ToLower(criteria.JobTitle)+"%")
+	}
+	for _, criterion := range criteria.Skills {
+		if criterion.Optional {
+			continue
+		}
+		query = query.Where(`EXISTS (SELECT 1 FROM user_skills
+			WHERE user_skills.user_id = users.id AND user_skills.skill_id IN ? AND user_skills.proficiency >= ?)`,
+			criterion.skillIDs, criterion.MinLevel)
+	}
+	if criteria.AvailableFrom != nil && criteria.AvailableTo != nil {
+		query = query.Where("EXISTS (SELECT 1 FROM user_availabilities WHERE user_availabilities.user_id = users.id)")
+	}
+	if err := query.Order("users.id asc").Find(&candidates).Error; err != nil {
+		return nil, err
+	}
+	return candidates, nil
+}
+
+func (repo *talentRepositoryPostgres) fetchUserSkillLevels(userIDs []uint) ([]UserSkillLevel, error) {
+	var skillLevels []UserSkillLevel
+	err := repo.db.Table("user_skills").
+		Select("user_skills.user_id, skills.id AS skill_id, skills.name, user_skills.skill_level, user_skills.proficiency, user_skills.assessment, "+
+			"(SELECT COUNT(*) FROM skill_endorsements WHERE skill_endorsements.user_skill_id = user_skills.id) AS endorsements").
+		Joins("JOIN skills ON skills.id = user_skills.skill_id AND skills.deleted_at IS NULL").
+		Where("user_skills.user_id IN ?", userIDs).
+		Scan(&skillLevels).Error
+	return skillLevels, err
+}
+
+func (repo *talentRepositoryPostgres) fetchExperiencePeriods(userIDs []uint) ([]ExperiencePeriod, error) {
+	var periods []ExperiencePeriod
+	err := repo.db.Table("user_experiences").
+		Select("user_experiences.user_id, experiences.start_date, experiences.end_date, experiences.is_currently_working").
+		Joins("JOIN experiences ON experiences.id = user_experiences.experience_id AND experiences.deleted_at IS NULL").
+		Where("user_experiences.user_id IN ?", userIDs).
+		Scan(&periods).Error
+	return periods, err
+}
@@ -0,0 +1,273 @@
+package talent
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/Octek/resource-profile-management-backend.git/api/bookings"
+	"github.com/Octek/resource-profile-management-backend.git/api/skills"
+	"github.com/Octek/resource-profile-management-backend.git/api/timeline"
+	"gorm.io/gorm"
+)
+
+type TalentService struct {
+	talentRepository TalentRepository
+	bookingService   bookings.BookingService
+	skillService     skills.SkillService
+}
+
+func NewService(r TalentRepository, bookingService bookings.BookingService, skillService skills.SkillService) TalentService {
+	return TalentService{talentRepository: r, bookingService: bookingService, skillService: skillService}
+}
+
+// SearchTalent ranks the users that match every filter, best match first. Users without
+// availability settings never match an availability filter, and only the best
+// maxAvailabilityCandidates matches are checked for free slots. The matches below them are
+// counted as unchecked, they are neither in the results nor in the total.
+func (svc *TalentService) SearchTalent(criteria SearchCriteria, limit, offset int) ([]SearchResult, int64, int, error) {
+	resolvedSkills := make([]SkillCriterion, 0, len(criteria.Skills))
+	for _, criterion := range criteria.Skills {
+		skillIDs, err := svc.skillService.ResolveSkillIDs(skills.SkillQuery{
+			SkillID:            criterion.SkillID,
+			Name:               criterion.Name,
+			IncludeDescendants: criterion.IncludeDescendants,
+		})
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		criterion.skillIDs = skillIDs
+		resolvedSkills = append(resolvedSkills, criterion)
+	}
+	criteria.Skills = resolvedSkills
+
+	candidates, err := svc.talentRepository.fetchCandidates(criteria)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	if len(candidates) == 0 {
+		return []SearchResult{}, 0, 0, nil
+	}
+	userIDs := make([]uint, 0, len(candidates))
+	for _, candidate := range candidates {
+		userIDs = append(userIDs, candidate.ID)
+	}
+	skillLevels, err := svc.talentRepository.fetchUserSkillLevels(userIDs)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	skillLevelsByUser := make(map[uint][]UserSkillLevel, len(candidates))
+	for _, skillLevel := range skillLevels {
+		skillLevelsByUser[skillLevel.UserID] = append(skillLevelsByUser[skillLevel.UserID], skillLevel)
+	}
+	periods, err := svc.talentRepository.fetchExperiencePeriods(userIDs)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	periodsByUser := make(map[uint][]ExperiencePeriod, len(candidates))
+	for _, period := range periods {
+		periodsByUser[period.UserID] = append(periodsByUser[period.UserID], period)
+	}
+
+	now := time.Now()
+	results := make([]SearchResult, 0, len(candidates))
+	for _, candidate := range candidates {
+		result := SearchResult{
+			UserID:        candidate.ID,
+			FirstName:     candidate.FirstName,
+			LastName:      candidate.LastName,
+			JobTitle:      candidate.JobTitle,
+			Location:      candidate.Location,
+			MatchedSkills: []MatchedSkill{},
+			Explanations:  []string{},
+		}
+		if candidate.UserCategory != nil {
+			result.UserCategory = candidate.UserCategory.Name
+		}
+
+		result.YearsOfExperience = yearsOfExperience(periodsByUser[candidate.ID], now)
+		if result.YearsOfExperience < criteria.MinYearsOfExperience {
+			continue
+		}
+		scoreSkills(&result, criteria.Skills, skillLevelsByUser[candidate.ID])
+		scoreProfile(&result, criteria)
+
+		results = append(results, result)
+	}
+
+	unchecked := 0
+	if criteria.AvailableFrom != nil && criteria.AvailableTo != nil {
+		// every check reads the availability and the bookings of the user, only the best matches are checked
+		sortResults(results)
+		if len(results) > maxAvailabilityCandidates {
+			unchecked = len(results) - maxAvailabilityCandidates
+			results = results[:maxAvailabilityCandidates]
+		}
+		availableResults := make([]SearchResult, 0, len(results))
+		for _, result := range results {
+			freeSlots, err := svc.bookingService.GetFreeSlots(result.UserID, *criteria.AvailableFrom, *criteria.AvailableTo)
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				continue
+			}
+			if err != nil {
+				return nil, 0, 0, err
+			}
+			if len(freeSlots) == 0 {
+				continue
+			}
+			freeSlotCount := len(freeSlots)
+			result.FreeSlots = &freeSlotCount
+			result.Score += float64(min(freeSlotCount, maxScoredFreeSlots)) * freeSlotScore
+			result.Explanations = append(result.Explanations, fmt.Sprintf("%d free slots between %s and %s", freeSlotCount,
+				criteria.AvailableFrom.Format(searchDateLayout), criteria.AvailableTo.Format(searchDateLayout)))
+			availableResults = append(availableResults, result)
+		}
+		results = availableResults
+	}
+	for i := range results {
+		results[i].Score = math.Round(results[i].Score*100) / 100
+	}
+
+	sortResults(results)
+	totalRecords := int64(len(results))
+	if offset >= len(results) {
+		return []SearchResult{}, totalRecords, unchecked, nil
+	}
+	end := offset + limit
+	if limit <= 0 || end > len(results) {
+		end = len(results)
+	}
+	return results[offset:end], totalRecords, unchecked, nil
+}
+
+// sortResults orders the results best match first
+func sortResults(results []SearchResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		if results[i].YearsOfExperience != results[j].YearsOfExperience {
+			return results[i].YearsOfExperience > results[j].YearsOfExperience
+		}
+		return results[i].UserID < results[j].UserID
+	})
+}
+
+// scoreSkills scores every requested skill by the best matching skill of the user
+func scoreSkills(result *SearchResult, criteria []SkillCriterion, skillLevels []UserSkillLevel) {
+	for _, criterion := range criteria {
+		best, found := bestSkillMatch(criterion, skillLevels)
+		label := criterion.Name
+		if found {
+			label = best.Name
+		}
+		if label == "" {
+			label = fmt.Sprintf("skill %d", criterion.SkillID)
+		}
+		kind := "required"
+		baseScore := requiredSkillScore
+		if criterion.Optional {
+			kind = "optional"
+			baseScore = optionalSkillScore
+		}
+		if !found {
+			result.Explanations = append(result.Explanations, fmt.Sprintf("Missing %s skill %s", kind, label))
+			continue
+		}
+		levelRank := best.Proficiency
+		verified := best.Assessment == skills.AssessmentVerified
+		result.MatchedSkills = append(result.MatchedSkills, MatchedSkill{
+			SkillID:      best.SkillID,
+			Name:         best.Name,
+			Level:        skills.ProficiencyLabel(levelRank),
+			LevelRank:    levelRank,
+			Verified:     verified,
+			Endorsements: best.Endorsements,
+			Optional:     criterion.Optional,
+		})
+
+		explanation := fmt.Sprintf("Has %s skill %s", kind, best.Name)
+		if criterion.Name != "" && skills.NormalizeSkillName(criterion.Name) != skills.NormalizeSkillName(best.Name) {
+			explanation += fmt.Sprintf(" for %s", criterion.Name)
+		}
+		explanation += fmt.Sprintf(" at level %s", describeLevel(levelRank))
+		switch {
+		case levelRank < criterion.MinLevel:
+			// only optional skills get here, required ones are filtered by the database
+			result.Score += belowMinimumLevelScore
+			explanation += fmt.Sprintf(", below the requested %d", criterion.MinLevel)
+		case criterion.MinLevel > 0:
+			result.Score += baseScore + float64(levelRank-criterion.MinLevel)*levelAboveMinimumScore
+			explanation += fmt.Sprintf(", at least %d requested", criterion.MinLevel)
+		default:
+			result.Score += baseScore + float64(levelRank)
+		}
+		if verified {
+			result.Score += verifiedSkillScore
+			explanation += ", verified"
+		}
+		if best.Endorsements > 0 {
+			result.Score += float64(min(best.Endorsements, maxScoredEndorsements)) * endorsementScore
+			explanation += fmt.Sprintf(", endorsed %d times", best.Endorsements)
+		}
+		result.Explanations = append(result.Explanations, explanation)
+	}
+}
+
+func scoreProfile(result *SearchResult, criteria SearchCriteria) {
+	if criteria.JobTitle != "" {
+		result.Score += jobTitleMatchScore
+		result.Explanations = append(result.Explanations, fmt.Sprintf("Job title %q matches %q", result.JobTitle, criteria.JobTitle))
+	}
+	if criteria.Location != "" {
+		result.Score += locationMatchScore
+		result.Explanations = append(result.Explanations, fmt.Sprintf("Location %q matches %q", result.Location, criteria.Location))
+	}
+	if criteria.UserCategoryID != 0 {
+		result.Explanations = append(result.Explanations, fmt.Sprintf("In category %s", result.UserCategory))
+	}
+	result.Score += math.Min(result.YearsOfExperience, maxScoredYears) * yearOfExperienceScore
+	explanation := fmt.Sprintf("%.1f years of experience", result.YearsOfExperience)
+	if criteria.MinYearsOfExperience > 0 {
+		explanation += fmt.Sprintf(", at least %.1f requested", criteria.MinYearsOfExperience)
+	}
+	result.Explanations = append(result.Explanations, explanation)
+}
+
+func bestSkillMatch(criterion SkillCriterion, skillLevels []UserSkillLevel) (UserSkillLevel, bool) {
+	var best UserSkillLevel
+	found := false
+	for _, skillLevel := range skillLevels {
+		if !slices.Contains(criterion.skillIDs, skillLevel.SkillID) {
+			continue
+		}
+		if !found || skillLevel.Proficiency > best.Proficiency ||
+			skillLevel.Proficiency == best.Proficiency && skillLevel.Assessment == skills.AssessmentVerified ||
+			skillLevel.Proficiency == best.Proficiency && skillLevel.Assessment == best.Assessment && skillLevel.Endorsements > best.Endorsements {
+			best = skillLevel
+			found = true
+		}
+	}
+	return best, found
+}
+
+func describeLevel(proficiency int) string {
+	if proficiency == skills.ProficiencyUnrated {
+		return "unrated"
+	}
+	return fmt.Sprintf("%d (%s)", proficiency, skills.ProficiencyLabel(proficiency))
+}
+
+// yearsOfExperience adds up the experience periods, overlapping periods are only counted once
+func yearsOfExperience(periods []ExperiencePeriod, now time.Time) float64 {
+	spans := make([]timeline.Period, 0, len(periods))
+	for _, period := range periods {
+		if span, ok := timeline.NewPeriod(period.StartDate, period.EndDate, period.IsCurrentlyWorking, now); ok {
+			spans = append(spans, span)
+		}
+	}
+	return timeline.Years(spans)
+}
@@ -0,0 +1,84 @@
+package timeline
+
+import (
+	"time"
+)
+
+const (
+	EntryTypeExperience = "experience"
+	EntryTypeEducation  = "education"
+	DefaultMinGapDays   = 30
+	MaxMinGapDays       = 3650
+	daysPerYear         = 365.25
+	yearsPrecision      = 10
+)
+
+// Timeline is the career of a user, the entries are ordered oldest first. TotalYearsOfExperience only
+// counts experiences and overlapping time once, gaps are the periods without an experience or an
+// education since the first one started.
+type Timeline struct {
+	UserID                 uint         `json:"user_id"`
+	TotalYearsOfExperience float64      `json:"total_years_of_experience"`
+	YearsOfEducation       float64      `json:"years_of_education"`
+	Entries                []Entry      `json:"entries"`
+	Gaps                   []Gap        `json:"gaps"`
+	SkillYears             []SkillYears `json:"skill_years"`
+}
+
+// Entry is an experience or an education on the timeline, EndDate is empty while it is ongoing
+type Entry struct {
+	Type         string     `json:"type"`
+	ID           uint       `json:"id"`
+	Title        string     `json:"title"`
+	Organization string     `json:"organization"`
+	StartDate    time.Time  `json:"start_date"`
+	EndDate      *time.Time `json:"end_date"`
+	IsCurrent    bool       `json:"is_current"`
+	Years        float64    `json:"years"`
+	Skills       []string   `json:"skills,omitempty"`
+}
+
+// Gap is a period without an experience or an education, EndDate is empty for a gap lasting until today
+type Gap struct {
+	StartDate time.Time  `json:"start_date"`
+	EndDate   *time.Time `json:"end_date"`
+	Days      int        `json:"days"`
+}
+
+// SkillYears are the years a skill was used, from the experiences it is linked to
+type SkillYears struct {
+	SkillID     uint    `json:"skill_id"`
+	Name        string  `json:"name"`
+	Years       float64 `json:"years"`
+	Experiences int     `json:"experiences"`
+}
+
+// Period is a time span, an ongoing period ends now
+type Period struct {
+	Start time.Time
+	End   time.Time
+}
+
+type experienceRow struct {
+	ID                 uint
+	Position           string
+	Company            string
+	StartDate          time.Time
+	EndDate            time.Time
+	IsCurrentlyWorking bool
+}
+
+type educationRow struct {
+	ID              uint
+	InstitutionName string
+	Degree          string
+	FieldOfStudy    string
+	StartDate       time.Time
+	EndDate         time.Time
+}
+
+type experienceSkillRow struct {
+	ExperienceID uint
+	SkillID      uint
+	Name         string
+}
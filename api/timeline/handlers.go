@@ -0,0 +1,67 @@
+package timeline
+
+import (
+	"errors"
+	"fmt"
+	"github.com/Octek/resource-profile-management-backend.git/api/auth"
+	"github.com/Octek/resource-profile-management-backend.git/utils"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"net/http"
+	"strconv"
+)
+
+// Routes Exports all routes handled by this service, every route declares who may call it
+func Routes(router gin.IRouter, timelineSvc TimelineService) {
+	timelineRouter := router.Group("/timeline")
+	{
+		timelineRouter.GET("/user/:userId", auth.Authorize(auth.AllowAuthenticated), func(c *gin.Context) {
+			HandlerToGetUserTimeline(c, timelineSvc)
+		})
+	}
+}
+
+// HandlerToGetUserTimeline godoc
+// @Tags Timeline
+// @Summary Get career timeline of user
+// @Description The experiences and educations of a user oldest first, with the total years of experience counting overlapping experiences once,
+// @Description the gaps without an experience or an education and the years every skill was used in the experiences it is linked to
+// @ID get-user-timeline
+// @Security ApiAuthKey
+// @Produce json
+// @Param userId path int true "User ID"
+// @Param   minGapDays    query     int     false  "Breaks up to this many days are not gaps, defaults to 30"     minGapDays(int)
+// @Success 200 {object} Timeline
+// @Failure 400 {object} utils.ResponseMessage
+// @Failure 404 {object} utils.ResponseMessage
+// @Failure 500 {object} utils.ResponseMessage
+// @Router /timeline/user/{userId} [get]
+func HandlerToGetUserTimeline(c *gin.Context, timelineSvc TimelineService) {
+	fmt.Println("HandlerToGetUserTimeline")
+	userID, ok := utils.BindID(c, "userId")
+	if !ok {
+		return
+	}
+	minGapDays := DefaultMinGapDays
+	if value := c.Query("minGapDays"); value != "" {
+		var err error
+		minGapDays, err = strconv.Atoi(value)
+		if err == nil && (minGapDays < 0 || minGapDays > MaxMinGapDays) {
+			err = fmt.Errorf("expected a value between 0 and %d", MaxMinGapDays)
+		}
+		if err != nil {
+			utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.InvalidIntegerValueMessage, "minGapDays", err), err))
+			return
+		}
+	}
+	timelineObj, err := timelineSvc.GetUserTimeline(userID, minGapDays)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileGettingTimeline, err), err))
+		return
+	}
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.Success, Data: timelineObj})
+}
@@ -0,0 +1,82 @@
+package timeline
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// NewPeriod clamps a span to now, an ongoing span or one without an end ends now. Spans that have not
+// started yet or have no start are not a period.
+func NewPeriod(start, end time.Time, ongoing bool, now time.Time) (Period, bool) {
+	if start.IsZero() {
+		return Period{}, false
+	}
+	if ongoing || end.IsZero() || end.After(now) {
+		end = now
+	}
+	if !end.After(start) {
+		return Period{}, false
+	}
+	return Period{Start: start, End: end}, true
+}
+
+// MergePeriods joins the overlapping and touching periods, the result is ordered oldest first
+func MergePeriods(periods []Period) []Period {
+	if len(periods) == 0 {
+		return nil
+	}
+	sorted := append([]Period{}, periods...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Start.Before(sorted[j].Start) })
+
+	merged := []Period{sorted[0]}
+	for _, next := range sorted[1:] {
+		current := &merged[len(merged)-1]
+		if next.Start.After(current.End) {
+			merged = append(merged, next)
+			continue
+		}
+		if next.End.After(current.End) {
+			current.End = next.End
+		}
+	}
+	return merged
+}
+
+// Years adds up the periods, overlapping periods are only counted once
+func Years(periods []Period) float64 {
+	var total time.Duration
+	for _, period := range MergePeriods(periods) {
+		total += period.End.Sub(period.Start)
+	}
+	return roundYears(total)
+}
+
+// findGaps returns the breaks between the periods longer than minGapDays, and the break since the
+// last period when it ended before now
+func findGaps(periods []Period, minGapDays int, now time.Time) []Gap {
+	gaps := []Gap{}
+	merged := MergePeriods(periods)
+	for i, period := range merged {
+		gapStart := period.End
+		gapEnd := now
+		if i+1 < len(merged) {
+			gapEnd = merged[i+1].Start
+		}
+		days := int(gapEnd.Sub(gapStart).Hours() / 24)
+		if days <= minGapDays {
+			continue
+		}
+		gap := Gap{StartDate: gapStart, Days: days}
+		if i+1 < len(merged) {
+			gap.EndDate = &gapEnd
+		}
+		gaps = append(gaps, gap)
+	}
+	return gaps
+}
+
+func roundYears(duration time.Duration) float64 {
+	years := duration.Hours() / 24 / daysPerYear
+	return math.Round(years*yearsPrecision) / yearsPrecision
+}
@@ -0,0 +1,9 @@
+package timeline
+
+// TimelineRepository Used to read the experiences and educations a timeline is built from
+type TimelineRepository interface {
+	userExists(userID uint) (bool, error)
+	fetchExperiences(userID uint) ([]experienceRow, error)
+	fetchExperienceSkills(experienceIDs []uint) ([]experienceSkillRow, error)
+	fetchEducations(userID uint) ([]educationRow, error)
+}
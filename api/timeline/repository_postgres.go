@@ -0,0 +1,60 @@
+package timeline
+
+import (
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type timelineRepositoryPostgres struct {
+	db *gorm.DB
+}
+
+// NewTimelineRepositoryPostgres has no tables of its own, it reads the experiences, educations and skills
+func NewTimelineRepositoryPostgres(db *gorm.DB) TimelineRepository {
+	log.Print("Successfully connected to postgres in timeline service!")
+
+	return &timelineRepositoryPostgres{
+		db: db,
+	}
+}
+
+func (repo *timelineRepositoryPostgres) userExists(userID uint) (bool, error) {
+	var count int64
+	err := repo.db.Table("users").Where("id = ? AND deleted_at IS NULL", userID).Count(&count).Error
+	return count > 0, err
+}
+
+func (repo *timelineRepositoryPostgres) fetchExperiences(userID uint) ([]experienceRow, error) {
+	var experiences []experienceRow
+	err := repo.db.Table("experiences").
+		Select("experiences.id, experiences.position, experiences.company, experiences.start_date, experiences.end_date, experiences.is_currently_working").
+		Joins("JOIN user_experiences ON user_experiences.experience_id = experiences.id").
+		Where("user_experiences.user_id = ? AND experiences.deleted_at IS NULL", userID).
+		Order("experiences.start_date asc, experiences.id asc").
+		Scan(&experiences).Error
+	return experiences, err
+}
+
+func (repo *timelineRepositoryPostgres) fetchExperienceSkills(experienceIDs []uint) ([]experienceSkillRow, error) {
+	var experienceSkills []experienceSkillRow
+	if len(experienceIDs) == 0 {
+		return experienceSkills, nil
+	}
+	err := repo.db.Table("experience_skills").
+		Select("experience_skills.experience_id, skills.id AS skill_id, skills.name").
+		Joins("JOIN skills ON skills.id = experience_skills.skill_id AND skills.deleted_at IS NULL").
+		Where("experience_skills.experience_id IN ?", experienceIDs).
+		Order("skills.name asc").
+		Scan(&experienceSkills).Error
+	return experienceSkills, err
+}
+
+func (repo *timelineRepositoryPostgres) fetchEducations(userID uint) ([]educationRow, error) {
+	var educations []educationRow
+	err := repo.db.Table("educations").
+		Select("id, institution_name, degree, field_of_study, start_date, end_date").
+		Where("user_id = ?", userID).
+		Order("start_date asc, id asc").
+		Scan(&educations).Error
+	return educations, err
+}
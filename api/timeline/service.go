@@ -0,0 +1,141 @@
+package timeline
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type TimelineService struct {
+	timelineRepository TimelineRepository
+}
+
+func NewService(r TimelineRepository) TimelineService {
+	return TimelineService{timelineRepository: r}
+}
+
+// GetUserTimeline builds the career timeline of the user, breaks of at most minGapDays are not gaps
+func (svc *TimelineService) GetUserTimeline(userID uint, minGapDays int) (*Timeline, error) {
+	exists, err := svc.timelineRepository.userExists(userID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("no user found for id %d: %w", userID, gorm.ErrRecordNotFound)
+	}
+	experiences, err := svc.timelineRepository.fetchExperiences(userID)
+	if err != nil {
+		return nil, err
+	}
+	experienceIDs := make([]uint, 0, len(experiences))
+	for _, experienceObj := range experiences {
+		experienceIDs = append(experienceIDs, experienceObj.ID)
+	}
+	experienceSkills, err := svc.timelineRepository.fetchExperienceSkills(experienceIDs)
+	if err != nil {
+		return nil, err
+	}
+	educations, err := svc.timelineRepository.fetchEducations(userID)
+	if err != nil {
+		return nil, err
+	}
+	return buildTimeline(userID, experiences, experienceSkills, educations, minGapDays, time.Now()), nil
+}
+
+func buildTimeline(userID uint, experiences []experienceRow, experienceSkills []experienceSkillRow, educations []educationRow, minGapDays int, now time.Time) *Timeline {
+	timelineObj := &Timeline{
+		UserID:     userID,
+		Entries:    make([]Entry, 0, len(experiences)+len(educations)),
+		SkillYears: []SkillYears{},
+	}
+	skillsByExperience := make(map[uint][]experienceSkillRow, len(experiences))
+	for _, experienceSkill := range experienceSkills {
+		skillsByExperience[experienceSkill.ExperienceID] = append(skillsByExperience[experienceSkill.ExperienceID], experienceSkill)
+	}
+
+	var experiencePeriods, educationPeriods []Period
+	periodsBySkill := map[uint][]Period{}
+	skillYearsByID := map[uint]*SkillYears{}
+	for _, experienceObj := range experiences {
+		entry := Entry{
+			Type:         EntryTypeExperience,
+			ID:           experienceObj.ID,
+			Title:        strings.TrimSpace(experienceObj.Position),
+			Organization: strings.TrimSpace(experienceObj.Company),
+			StartDate:    experienceObj.StartDate,
+			IsCurrent:    experienceObj.IsCurrentlyWorking || experienceObj.EndDate.IsZero() || experienceObj.EndDate.After(now),
+		}
+		if !entry.IsCurrent {
+			endDate := experienceObj.EndDate
+			entry.EndDate = &endDate
+		}
+		period, ok := NewPeriod(experienceObj.StartDate, experienceObj.EndDate, experienceObj.IsCurrentlyWorking, now)
+		if ok {
+			experiencePeriods = append(experiencePeriods, period)
+			entry.Years = roundYears(period.End.Sub(period.Start))
+		}
+		for _, experienceSkill := range skillsByExperience[experienceObj.ID] {
+			entry.Skills = append(entry.Skills, experienceSkill.Name)
+			skillYears, found := skillYearsByID[experienceSkill.SkillID]
+			if !found {
+				skillYears = &SkillYears{SkillID: experienceSkill.SkillID, Name: experienceSkill.Name}
+				skillYearsByID[experienceSkill.SkillID] = skillYears
+			}
+			skillYears.Experiences++
+			if ok {
+				periodsBySkill[experienceSkill.SkillID] = append(periodsBySkill[experienceSkill.SkillID], period)
+			}
+		}
+		timelineObj.Entries = append(timelineObj.Entries, entry)
+	}
+	for _, educationObj := range educations {
+		entry := Entry{
+			Type:         EntryTypeEducation,
+			ID:           educationObj.ID,
+			Title:        strings.Join(nonEmpty(educationObj.Degree, educationObj.FieldOfStudy), ", "),
+			Organization: strings.TrimSpace(educationObj.InstitutionName),
+			StartDate:    educationObj.StartDate,
+			IsCurrent:    educationObj.EndDate.IsZero() || educationObj.EndDate.After(now),
+		}
+		if !entry.IsCurrent {
+			endDate := educationObj.EndDate
+			entry.EndDate = &endDate
+		}
+		if period, ok := NewPeriod(educationObj.StartDate, educationObj.EndDate, false, now); ok {
+			educationPeriods = append(educationPeriods, period)
+			entry.Years = roundYears(period.End.Sub(period.Start))
+		}
+		timelineObj.Entries = append(timelineObj.Entries, entry)
+	}
+	sort.SliceStable(timelineObj.Entries, func(i, j int) bool {
+		return timelineObj.Entries[i].StartDate.Before(timelineObj.Entries[j].StartDate)
+	})
+
+	timelineObj.TotalYearsOfExperience = Years(experiencePeriods)
+	timelineObj.YearsOfEducation = Years(educationPeriods)
+	timelineObj.Gaps = findGaps(append(experiencePeriods, educationPeriods...), minGapDays, now)
+	for skillID, skillYears := range skillYearsByID {
+		skillYears.Years = Years(periodsBySkill[skillID])
+		timelineObj.SkillYears = append(timelineObj.SkillYears, *skillYears)
+	}
+	sort.Slice(timelineObj.SkillYears, func(i, j int) bool {
+		if timelineObj.SkillYears[i].Years != timelineObj.SkillYears[j].Years {
+			return timelineObj.SkillYears[i].Years > timelineObj.SkillYears[j].Years
+		}
+		return timelineObj.SkillYears[i].Name < timelineObj.SkillYears[j].Name
+	})
+	return timelineObj
+}
+
+func nonEmpty(values ...string) []string {
+	var result []string
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			result = append(result, value)
+		}
+	}
+	return result
+}
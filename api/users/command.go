@@ -0,0 +1,60 @@
+package user
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+const (
+	CommandDuplicates = "duplicates"
+	CommandMergeUsers = "merge-users"
+)
+
+// RunCommand runs the subcommands resolving the users that fail the unique email migration. They only need the
+// baseline schema, so they work while that migration is pending and the service does not start:
+//
+//	duplicates                          lists the users sharing an email or a name and mobile number
+//	merge-users <id> <duplicate id>...  merges the duplicates into the user
+func RunCommand(userSvc UserService, command string, args []string, out io.Writer) error {
+	switch command {
+	case CommandDuplicates:
+		if len(args) > 0 {
+			return fmt.Errorf("too many arguments for %s: %v", command, args)
+		}
+		groups, err := userSvc.FindDuplicateUsers()
+		if err != nil {
+			return err
+		}
+		if len(groups) == 0 {
+			fmt.Fprintln(out, "no duplicate users")
+		}
+		for _, group := range groups {
+			fmt.Fprintf(out, "%s\t%s\n", group.Reason, group.Key)
+			for _, u := range group.Users {
+				fmt.Fprintf(out, "\t%d\t%s %s\t%s\n", u.ID, u.FirstName, u.LastName, u.Email)
+			}
+		}
+		return nil
+	case CommandMergeUsers:
+		if len(args) < 2 || len(args) > MaxMergedUsers+1 {
+			return fmt.Errorf("%s expects the id of the kept user and 1 to %d duplicate ids, got %v", command, MaxMergedUsers, args)
+		}
+		ids := make([]uint, 0, len(args))
+		for _, arg := range args {
+			id, err := strconv.ParseUint(arg, 10, 64)
+			if err != nil || id == 0 {
+				return fmt.Errorf("%s expects user ids, got %q", command, arg)
+			}
+			ids = append(ids, uint(id))
+		}
+		result, err := userSvc.MergeUsers(ids[0], ids[1:])
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "merged users %s into %d: %d educations, %d experiences, %d projects, %d skills, %d bookings moved\n",
+			joinIDs(result.MergedUserIDs), result.UserID, result.Educations, result.Experiences, result.Projects, result.Skills, result.Bookings)
+		return nil
+	}
+	return fmt.Errorf("unknown command %q, expected %s or %s", command, CommandDuplicates, CommandMergeUsers)
+}
@@ -2,6 +2,7 @@ package user
 
 import (
 	"crypto/sha256"
+	"errors"
 	"fmt"
 	"github.com/Octek/resource-profile-management-backend.git/api/bookings"
 	"github.com/Octek/resource-profile-management-backend.git/api/experience"
@@ -11,28 +12,31 @@ import (
 	"time"
 )
 
+// User is a profile, EndorsementCount is the number of endorsements of all skills of the user and is
+// only computed when reading. Emails are unique regardless of case among the users that are not deleted.
 type User struct {
-	ID             uint                    `json:"id" gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
-	FirstName      string                  `json:"first_name"`
-	LastName       string                  `json:"last_name"`
-	Email          string                  `json:"email" gorm:"constraint:UNIQUE;NOT NULL"`
-	MobileNumber   string                  `json:"mobile_number"`
-	Bio            string                  `json:"bio"`
-	JobTitle       string                  `json:"job_title"`
-	Location       string                  `json:"location"`
-	VideoUrl       string                  `json:"video_url"`
-	Certifications string                  `json:"certifications"`
-	UserCategoryID uint                    `json:"user_category_id" gorm:"NOT NULL;index:user_category_id"`
-	UserCategory   *UserCategory           `json:"user_category" gorm:"foreignKey:UserCategoryID;references:ID"`
-	Educations     []Education             `json:"educations" gorm:"foreignKey:UserID"`
-	Bookings       []bookings.Booking      `json:"bookings" gorm:"foreignKey:UserID"`
-	Roles          []Role                  `json:"roles" gorm:"many2many:user_roles;"`
-	Skills         []skills.Skill          `json:"skills" gorm:"many2many:user_skills;"`
-	Experiences    []experience.Experience `json:"experiences" gorm:"many2many:user_experiences;"`
-	Projects       []projects.Project      `json:"projects" gorm:"many2many:user_projects;"`
-	DeletedAt      gorm.DeletedAt          `json:"deleted_at"`
-	CreatedAt      time.Time               `json:"created_at"`
-	UpdatedAt      time.Time               `json:"updated_at"`
+	ID               uint                    `json:"id" gorm:"PRIMARY_KEY;AUTO_INCREMENT;UNIQUE;"`
+	FirstName        string                  `json:"first_name"`
+	LastName         string                  `json:"last_name"`
+	Email            string                  `json:"email" gorm:"NOT NULL"`
+	MobileNumber     string                  `json:"mobile_number"`
+	Bio              string                  `json:"bio"`
+	JobTitle         string                  `json:"job_title"`
+	Location         string                  `json:"location"`
+	VideoUrl         string                  `json:"video_url"`
+	Certifications   string                  `json:"certifications"`
+	UserCategoryID   uint                    `json:"user_category_id" gorm:"NOT NULL;index:user_category_id"`
+	UserCategory     *UserCategory           `json:"user_category" gorm:"foreignKey:UserCategoryID;references:ID"`
+	Educations       []Education             `json:"educations" gorm:"foreignKey:UserID"`
+	Bookings         []bookings.Booking      `json:"bookings" gorm:"foreignKey:UserID"`
+	Roles            []Role                  `json:"roles" gorm:"many2many:user_roles;"`
+	Skills           []skills.Skill          `json:"skills" gorm:"many2many:user_skills;"`
+	Experiences      []experience.Experience `json:"experiences" gorm:"many2many:user_experiences;"`
+	Projects         []projects.Project      `json:"projects" gorm:"many2many:user_projects;"`
+	EndorsementCount int64                   `json:"endorsement_count" gorm:"->;-:migration"`
+	DeletedAt        gorm.DeletedAt          `json:"deleted_at"`
+	CreatedAt        time.Time               `json:"created_at"`
+	UpdatedAt        time.Time               `json:"updated_at"`
 }
 
 type Education struct {
@@ -70,6 +74,11 @@ type UserRole struct {
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+var (
+	ErrRoleAlreadyAssigned = errors.New("role is already assigned to the user")
+	ErrLastAdmin           = errors.New("the last admin cannot lose the admin role")
+)
+
 func asSha256Category(category UserCategory) string {
 	org := UserCategory{
 		ID:   category.ID,
@@ -1,56 +1,79 @@
 package user
 
 import (
+	"errors"
 	"fmt"
+	"github.com/Octek/resource-profile-management-backend.git/api/auth"
 	"github.com/Octek/resource-profile-management-backend.git/utils"
 	"github.com/gin-gonic/gin"
-	"github.com/go-playground/validator/v10"
 	"gorm.io/gorm"
 	"net/http"
-	"strconv"
+	"strings"
 	"time"
 )
 
-var validate = validator.New()
+var validate = utils.NewValidator()
 
-// Routes Exports all routes handled by this service
-func Routes(router *gin.Engine, userSvc UserService) {
+// the columns the lists can be ordered by
+var (
+	userSortColumns         = []string{"id", "first_name", "last_name", "email", "job_title", "location", "user_category_id", "endorsement_count", "created_at", "updated_at"}
+	educationSortColumns    = []string{"id", "institution_name", "degree", "field_of_study", "start_date", "end_date", "created_at", "updated_at"}
+	userCategorySortColumns = []string{"id", "name", "created_at", "updated_at"}
+)
+
+// Routes Exports all routes handled by this service, every route declares who may call it
+func Routes(router gin.IRouter, userSvc UserService) {
 	subRouter := router.Group("/user")
 	{
-		subRouter.POST("", func(c *gin.Context) {
+		subRouter.POST("", auth.Authorize(auth.AllowAdmin), func(c *gin.Context) {
 			CreateUserHandler(userSvc, c)
 		})
-		subRouter.GET("/all", func(c *gin.Context) {
+		subRouter.GET("/all", auth.Authorize(auth.AllowAuthenticated), func(c *gin.Context) {
 			GetAllUsersListHandler(userSvc, c)
 		})
-		subRouter.GET("/:id", func(c *gin.Context) {
+		subRouter.GET("/roles", auth.Authorize(auth.AllowAuthenticated), func(c *gin.Context) {
+			GetAllRolesHandler(userSvc, c)
+		})
+		subRouter.GET("/duplicates", auth.Authorize(auth.AllowAdmin), func(c *gin.Context) {
+			GetDuplicateUsersHandler(userSvc, c)
+		})
+		subRouter.POST("/:id/merge", auth.Authorize(auth.AllowAdmin), func(c *gin.Context) {
+			MergeUsersHandler(userSvc, c)
+		})
+		subRouter.GET("/:id", auth.Authorize(auth.AllowAuthenticated), func(c *gin.Context) {
 			GetUserDetailsByUserIdHandler(userSvc, c)
 		})
-		subRouter.DELETE("/:id", func(c *gin.Context) {
+		subRouter.DELETE("/:id", auth.Authorize(auth.AllowAdmin), func(c *gin.Context) {
 			DeleteUserByUserIdHandler(userSvc, c)
 		})
-		subRouter.PATCH("/:id", func(c *gin.Context) {
+		subRouter.PATCH("/:id", auth.Authorize(auth.AllowAdmin, auth.AllowSelf(auth.UserIDFromParam("id"))), func(c *gin.Context) {
 			UpdateUserByUserIdHandler(userSvc, c)
 		})
-		subRouter.GET("/get-all-user-categories", func(c *gin.Context) {
+		subRouter.POST("/:id/roles/:roleId", auth.Authorize(auth.AllowAdmin), func(c *gin.Context) {
+			AssignRoleToUserHandler(userSvc, c)
+		})
+		subRouter.DELETE("/:id/roles/:roleId", auth.Authorize(auth.AllowAdmin), func(c *gin.Context) {
+			RevokeRoleFromUserHandler(userSvc, c)
+		})
+		subRouter.GET("/get-all-user-categories", auth.Authorize(auth.AllowAuthenticated), func(c *gin.Context) {
 			GetAllUserCategoriesHandler(userSvc, c)
 		})
 	}
 	subCodeRouter := router.Group("/user/education")
 	{
-		subCodeRouter.POST("", func(c *gin.Context) {
+		subCodeRouter.POST("", auth.Authorize(auth.AllowAdmin, auth.AllowSelf(auth.UserIDFromJSONBody("user_id"))), func(c *gin.Context) {
 			AddUserEducationHandler(userSvc, c)
 		})
-		subCodeRouter.PATCH("/:id", func(c *gin.Context) {
+		subCodeRouter.PATCH("/:id", auth.Authorize(auth.AllowAdmin, auth.AllowOwner(userSvc.isEducationOwner)), func(c *gin.Context) {
 			UpdateUserEducationByIdHandler(userSvc, c)
 		})
-		subCodeRouter.DELETE("/:id", func(c *gin.Context) {
+		subCodeRouter.DELETE("/:id", auth.Authorize(auth.AllowAdmin, auth.AllowSelf(auth.UserIDFromParam("id"))), func(c *gin.Context) {
 			DeleteUserEducationByUserIdHandler(userSvc, c)
 		})
-		subCodeRouter.GET("/:id", func(c *gin.Context) {
+		subCodeRouter.GET("/:id", auth.Authorize(auth.AllowAuthenticated), func(c *gin.Context) {
 			GetUserEducationByUserIdHandler(userSvc, c)
 		})
-		subCodeRouter.GET("/all/:id", func(c *gin.Context) {
+		subCodeRouter.GET("/all/:id", auth.Authorize(auth.AllowAuthenticated), func(c *gin.Context) {
 			GetAllUserEducationHandler(userSvc, c)
 		})
 	}
@@ -59,7 +82,7 @@ func Routes(router *gin.Engine, userSvc UserService) {
 type CreateUserRequest struct {
 	FirstName      string `json:"first_name" validate:"required"`
 	LastName       string `json:"last_name" validate:"required"`
-	Email          string `json:"email" validate:"required"`
+	Email          string `json:"email" validate:"required,email,max=255"`
 	MobileNumber   string `json:"mobile_number"`
 	UserCategoryID uint   `json:"user_category_id"`
 	JobTitle       string `json:"job_title"`
@@ -68,39 +91,45 @@ type CreateUserRequest struct {
 // CreateUserHandler godoc
 // @Tags user
 // @Summary Create user
-// @Description creates a new complete user
+// @Description creates a new complete user, emails are unique regardless of case and a taken email is a 409 with the code email_already_exists
 // @ID create-user
+// @Security ApiAuthKey
 // @Accept  json
 // @Produce  json
 // @Param CreateUserRequest body CreateUserRequest true "CreateUserRequest"
 // @Success 200 {object} utils.ResponseMessage
 // @Failure 400 {object} string
 // @Failure 404 {object} string
+// @Failure 409 {object} utils.ResponseMessage
 // @Failure 500 {object} string
 // @Router /user [post]
 func CreateUserHandler(userSvc UserService, c *gin.Context) {
 	createUserRequest := CreateUserRequest{}
 	if err := c.ShouldBind(&createUserRequest); err != nil {
-		c.JSON(http.StatusBadRequest, utils.ResponseMessage{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf("Failed to create user: %v", err), Data: nil})
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf("Failed to create user: %v", err), err))
 		return
 	}
 
 	if err := validate.Struct(createUserRequest); err != nil {
-		c.JSON(http.StatusBadRequest, utils.ResponseMessage{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf("Failed to create user: %v", err), Data: nil})
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf("Failed to create user: %v", err), err))
 		return
 	}
 
 	user := User{
 		FirstName:      createUserRequest.FirstName,
 		LastName:       createUserRequest.LastName,
-		Email:          createUserRequest.Email,
+		Email:          strings.TrimSpace(createUserRequest.Email),
 		MobileNumber:   createUserRequest.MobileNumber,
 		UserCategoryID: createUserRequest.UserCategoryID,
 		JobTitle:       createUserRequest.JobTitle,
 	}
 	createUser, err := userSvc.CreateUser(&user)
+	if errors.Is(err, ErrEmailAlreadyExists) {
+		utils.AbortWithError(c, utils.NewError(http.StatusConflict, fmt.Sprintf(utils.SomethingWentWrongWhileCreatingUser, err), err).WithCode(utils.ErrorCodeEmailAlreadyExists))
+		return
+	}
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, utils.ResponseMessage{StatusCode: http.StatusInternalServerError, Message: "Something went wrong while creating user.", Data: nil})
+		utils.AbortWithError(c, utils.NewError(http.StatusInternalServerError, "Something went wrong while creating user.", err))
 		return
 	}
 
@@ -118,45 +147,29 @@ type GetAllUsers struct {
 // @Summary Get all user
 // @Description get all user
 // @ID get-all-user
+// @Security ApiAuthKey
 // @Accept  json
 // @Produce  json
 // @Param   limit    query     int     false  "example - 50"     limit(int)
 // @Param   offset     query     int     false  "example - 0"     offset(int)
-// @Param   orderBy     query     string     false  "example - created_at desc"  orderBy(string)
+// @Param   orderBy     query     string     false  "example - created_at desc, or endorsement_count desc for the most endorsed users first"  orderBy(string)
+// @Param   keyword   query   string  false  "Search for a keyword in the first name, last name or job title, use /talent/search for skills"
 // @Success 200 {object} string
 // @Failure 400 {object} string
 // @Failure 404 {object} string
 // @Failure 500 {object} string
 // @Router /user/all [get]
 func GetAllUsersListHandler(userSvc UserService, c *gin.Context) {
-	limit := c.Request.URL.Query().Get("limit")
-	offset := c.Request.URL.Query().Get("offset")
-	orderBy := c.Request.URL.Query().Get("orderBy")
-	//keyword := c.Request.URL.Query().Get("keyword")
+	keyword := c.Request.URL.Query().Get("keyword")
 
-	if limit == "" {
-		limit = utils.DefaultLimit // default limit
-	}
-	if offset == "" {
-		offset = utils.DefaultOffset // default offset
-	}
-	if orderBy == "" {
-		orderBy = utils.DefaultOrderBy // default orderBy
-	}
-	limitInt, err := strconv.Atoi(limit)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, utils.ResponseMessage{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf(utils.InvalidIntegerValueLimitMessage, err), Data: nil})
-		return
-	}
-	offsetInt, err := strconv.Atoi(offset)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, utils.ResponseMessage{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf(utils.InvalidIntegerValueOffsetMessage, err), Data: nil})
+	page, ok := utils.BindPage(c, utils.DefaultOrderBy, userSortColumns...)
+	if !ok {
 		return
 	}
 
-	allUsers, total, err := userSvc.GetAllUser("", limitInt, offsetInt, orderBy)
+	allUsers, total, err := userSvc.GetAllUser(keyword, page.Limit, page.Offset, page.OrderBy)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, utils.ResponseMessage{StatusCode: http.StatusInternalServerError, Message: fmt.Sprintf("Cannot fetch Users:", err), Data: nil})
+		utils.AbortWithError(c, utils.NewError(http.StatusInternalServerError, fmt.Sprintf("Cannot fetch Users: %v", err), err))
 		return
 	}
 
@@ -168,6 +181,7 @@ func GetAllUsersListHandler(userSvc UserService, c *gin.Context) {
 // @Summary Get user details by id
 // @Description get user details by id
 // @ID get-user-details-by-id
+// @Security ApiAuthKey
 // @Accept  json
 // @Produce  json
 // @Param id path uint true "id"
@@ -177,11 +191,13 @@ func GetAllUsersListHandler(userSvc UserService, c *gin.Context) {
 // @Failure 500 {object} string
 // @Router /user/{id} [get]
 func GetUserDetailsByUserIdHandler(userSvc UserService, c *gin.Context) {
-	userId := c.Param("id")
-	userIdInt, _ := strconv.Atoi(userId)
-	userDetails, err := userSvc.GetUserDetailsByUserId(uint(userIdInt))
+	userId, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
+	userDetails, err := userSvc.GetUserDetailsByUserId(userId)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, utils.ResponseMessage{StatusCode: http.StatusInternalServerError, Message: fmt.Sprintf("Cannot fetch user against provided ID:", err), Data: nil})
+		utils.AbortWithError(c, utils.NewError(http.StatusInternalServerError, fmt.Sprintf("Cannot fetch user against provided ID: %v", err), err))
 		return
 	}
 
@@ -193,6 +209,7 @@ func GetUserDetailsByUserIdHandler(userSvc UserService, c *gin.Context) {
 // @Summary Delete user by id
 // @Description delete user by id
 // @ID delete-user-by-id
+// @Security ApiAuthKey
 // @Accept  json
 // @Produce  json
 // @Param id path int true "id"
@@ -202,22 +219,24 @@ func GetUserDetailsByUserIdHandler(userSvc UserService, c *gin.Context) {
 // @Failure 500 {object} string
 // @Router /user/{id} [delete]
 func DeleteUserByUserIdHandler(userSvc UserService, c *gin.Context) {
-	userId := c.Param("id")
-	userIdInt, _ := strconv.Atoi(userId)
+	userId, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
 
 	statusCode := http.StatusInternalServerError
-	_, err := userSvc.GetUserDetailsByUserId(uint(userIdInt))
-	if err == gorm.ErrRecordNotFound {
+	_, err := userSvc.GetUserDetailsByUserId(userId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
 		statusCode = http.StatusNotFound
 	}
 	if err != nil {
-		c.JSON(statusCode, utils.ResponseMessage{StatusCode: statusCode, Message: fmt.Sprintf("Something went wrong while fetching data against given id: %v", err), Data: nil})
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf("Something went wrong while fetching data against given id: %v", err), err))
 		return
 	}
 
-	err = userSvc.DeleteUserByUserID(uint(userIdInt))
+	err = userSvc.DeleteUserByUserID(userId)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, utils.ResponseMessage{StatusCode: http.StatusInternalServerError, Message: fmt.Sprintf("Unable to Delete user against provided id:", err), Data: nil})
+		utils.AbortWithError(c, utils.NewError(http.StatusInternalServerError, fmt.Sprintf("Unable to Delete user against provided id: %v", err), err))
 		return
 	}
 
@@ -227,7 +246,7 @@ func DeleteUserByUserIdHandler(userSvc UserService, c *gin.Context) {
 type UpdateUser struct {
 	FirstName      string `json:"first_name"`
 	LastName       string `json:"last_name"`
-	Email          string `json:"email"`
+	Email          string `json:"email" validate:"omitempty,email,max=255"`
 	MobileNumber   string `json:"mobile_number"`
 	Bio            string `json:"bio"`
 	Location       string `json:"location"`
@@ -241,6 +260,7 @@ type UpdateUser struct {
 // @Summary Update user
 // @Description Updates user
 // @ID update-user
+// @Security ApiAuthKey
 // @Accept  json
 // @Produce  json
 // @Param id path uint true "id"
@@ -248,32 +268,40 @@ type UpdateUser struct {
 // @Success 200 {object} string
 // @Failure 400 {object} string
 // @Failure 404 {object} string
+// @Failure 409 {object} utils.ResponseMessage
 // @Failure 500 {object} string
 // @Router /user/{id} [patch]
 func UpdateUserByUserIdHandler(userSvc UserService, c *gin.Context) {
-	userId := c.Param("id")
-	userIdInt, _ := strconv.Atoi(userId)
+	userId, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
 
 	updateUserRequest := UpdateUser{}
 	if err := c.ShouldBind(&updateUserRequest); err != nil {
-		c.JSON(http.StatusBadRequest, utils.ResponseMessage{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf("Failed to bind user: %v", err), Data: nil})
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf("Failed to bind user: %v", err), err))
 		return
 	}
 	if err := validate.Struct(&updateUserRequest); err != nil {
-		c.JSON(http.StatusBadRequest, utils.ResponseMessage{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf("Validation failed: %v", err), Data: nil})
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf("Validation failed: %v", err), err))
 		return
 	}
-	existingUserData, err := userSvc.GetUserDetailsByUserId(uint(userIdInt))
+	existingUserData, err := userSvc.GetUserDetailsByUserId(userId)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, utils.ResponseMessage{StatusCode: http.StatusInternalServerError, Message: fmt.Sprintf("Something went wrong while fetching data against given id: %v", err), Data: nil})
+		utils.AbortWithError(c, utils.NewError(http.StatusInternalServerError, fmt.Sprintf("Something went wrong while fetching data against given id: %v", err), err))
 		return
 	}
 
+	updateUserRequest.Email = strings.TrimSpace(updateUserRequest.Email)
 	_ = utils.UpdateEntity(existingUserData, updateUserRequest)
 
 	updatedUser, err := userSvc.UpdateUserByUserID(existingUserData)
+	if errors.Is(err, ErrEmailAlreadyExists) {
+		utils.AbortWithError(c, utils.NewError(http.StatusConflict, fmt.Sprintf("Failed to update user: %v", err), err).WithCode(utils.ErrorCodeEmailAlreadyExists))
+		return
+	}
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, utils.ResponseMessage{StatusCode: http.StatusInternalServerError, Message: "Failed to update user.", Data: nil})
+		utils.AbortWithError(c, utils.NewError(http.StatusInternalServerError, "Failed to update user.", err))
 		return
 	}
 
@@ -304,6 +332,7 @@ type UpdateUserEducation struct {
 // @Summary add user education
 // @Description add user education
 // @ID add-user-education
+// @Security ApiAuthKey
 // @Accept  json
 // @Produce  json
 // @Param AddUserEducation body AddUserEducation true "AddUserEducation"
@@ -316,17 +345,17 @@ func AddUserEducationHandler(userSvc UserService, c *gin.Context) {
 	addUserEducationReq := AddUserEducation{}
 
 	if err := c.ShouldBindJSON(&addUserEducationReq); err != nil {
-		c.JSON(http.StatusBadRequest, utils.ResponseMessage{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf("Failed to bind education request: %v", err), Data: nil})
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf("Failed to bind education request: %v", err), err))
 		return
 	}
 
 	if err := validate.Struct(&addUserEducationReq); err != nil {
-		c.JSON(http.StatusBadRequest, utils.ResponseMessage{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf("Validation failed: %v", err), Data: nil})
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf("Validation failed: %v", err), err))
 		return
 	}
 
 	if addUserEducationReq.EndDate.Before(addUserEducationReq.StartDate) {
-		c.JSON(http.StatusBadRequest, utils.ResponseMessage{StatusCode: http.StatusBadRequest, Message: "End date cannot be before the start date.", Data: nil})
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, "End date cannot be before the start date.", nil))
 		return
 	}
 
@@ -342,7 +371,7 @@ func AddUserEducationHandler(userSvc UserService, c *gin.Context) {
 
 	createdExperiences, err := userSvc.AddUserEducation(education)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, utils.ResponseMessage{StatusCode: http.StatusInternalServerError, Message: fmt.Sprintf("Failed to add education: %v", err), Data: nil})
+		utils.AbortWithError(c, utils.NewError(http.StatusInternalServerError, fmt.Sprintf("Failed to add education: %v", err), err))
 		return
 	}
 
@@ -353,54 +382,50 @@ func AddUserEducationHandler(userSvc UserService, c *gin.Context) {
 // UpdateUserEducationByIdHandler godoc
 // @Tags education
 // @Summary Update user education
-// @Description Update user education
+// @Description Update user education, users can only update their own educations
 // @ID update-user-education
+// @Security ApiAuthKey
 // @Accept  json
 // @Produce  json
 // @Param id path uint true "id"
-// @Param userId query uint true "userId"
 // @Param UpdateUserEducation body UpdateUserEducation true "UpdateUserEducation"
 // @Success 200 {object} string
 // @Failure 400 {object} string
+// @Failure 403 {object} string
 // @Failure 404 {object} string
 // @Failure 500 {object} string
 // @Router /user/education/{id} [patch]
 func UpdateUserEducationByIdHandler(userSvc UserService, c *gin.Context) {
-	eduId := c.Param("id")
-	eduIdInt, _ := strconv.Atoi(eduId)
-	userId := c.Request.URL.Query().Get("userId")
-	userIdInt, _ := strconv.Atoi(userId)
+	eduId, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
 	var updateEduRequest UpdateUserEducation
 
 	if err := c.ShouldBindJSON(&updateEduRequest); err != nil {
-		c.JSON(http.StatusBadRequest, utils.ResponseMessage{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf("Validation failed: %v", err), Data: nil})
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf("Validation failed: %v", err), err))
 		return
 	}
 
 	if err := validate.Struct(&updateEduRequest); err != nil {
-		c.JSON(http.StatusBadRequest, utils.ResponseMessage{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf("Validation failed: %v", err), Data: nil})
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf("Validation failed: %v", err), err))
 		return
 	}
 
-	existingExperience, err := userSvc.GetEducationById(uint(eduIdInt))
+	// the route policy has already checked that the education belongs to the caller unless they are an admin
+	existingEducation, err := userSvc.GetEducationById(eduId)
 	if err != nil {
-		c.JSON(http.StatusNotFound, utils.ResponseMessage{StatusCode: http.StatusNotFound, Message: "Education not found", Data: nil})
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf("Something went wrong while fetching the education: %v", err), err))
 		return
 	}
 
-	statusCode := http.StatusInternalServerError
-	_, err = userSvc.GetUserEducationByUserId(uint(userIdInt))
-	if err == gorm.ErrRecordNotFound {
-		statusCode = http.StatusNotFound
-	}
-	if err != nil {
-		c.JSON(statusCode, utils.ResponseMessage{StatusCode: statusCode, Message: fmt.Sprintf("Something went wrong while fetching user education: %v", err), Data: nil})
-		return
-	}
-
-	_ = utils.UpdateEntity(existingExperience, updateEduRequest)
-	if err = userSvc.UpdateEducation(existingExperience); err != nil {
-		c.JSON(http.StatusInternalServerError, utils.ResponseMessage{StatusCode: http.StatusInternalServerError, Message: "Failed to update Education", Data: nil})
+	_ = utils.UpdateEntity(existingEducation, updateEduRequest)
+	if err = userSvc.UpdateEducation(existingEducation); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusInternalServerError, "Failed to update Education", err))
 		return
 	}
 
@@ -412,6 +437,7 @@ func UpdateUserEducationByIdHandler(userSvc UserService, c *gin.Context) {
 // @Summary Delete user education by user id
 // @Description delete user education by user id
 // @ID delete-user-education-by-user-id
+// @Security ApiAuthKey
 // @Accept  json
 // @Produce  json
 // @Param id path int true "id"
@@ -421,21 +447,23 @@ func UpdateUserEducationByIdHandler(userSvc UserService, c *gin.Context) {
 // @Failure 500 {object} string
 // @Router /user/education/{id} [delete]
 func DeleteUserEducationByUserIdHandler(userSvc UserService, c *gin.Context) {
-	userId := c.Param("id")
-	userIdInt, _ := strconv.Atoi(userId)
+	userId, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
 	statusCode := http.StatusInternalServerError
-	_, err := userSvc.GetUserEducationByUserId(uint(userIdInt))
-	if err == gorm.ErrRecordNotFound {
+	_, err := userSvc.GetUserEducationByUserId(userId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
 		statusCode = http.StatusNotFound
 	}
 	if err != nil {
-		c.JSON(statusCode, utils.ResponseMessage{StatusCode: statusCode, Message: fmt.Sprintf("Something went wrong while fetching user education: %v", err), Data: nil})
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf("Something went wrong while fetching user education: %v", err), err))
 		return
 	}
 
-	err = userSvc.DeleteUserEducationByID(uint(userIdInt))
+	err = userSvc.DeleteUserEducationByID(userId)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, utils.ResponseMessage{StatusCode: http.StatusInternalServerError, Message: fmt.Sprintf("Unable to delete user education: %v", err), Data: nil})
+		utils.AbortWithError(c, utils.NewError(http.StatusInternalServerError, fmt.Sprintf("Unable to delete user education: %v", err), err))
 		return
 	}
 
@@ -447,6 +475,7 @@ func DeleteUserEducationByUserIdHandler(userSvc UserService, c *gin.Context) {
 // @Summary Get user education details by user id
 // @Description get user education details by user id
 // @ID get-user-education-details-by-user-id
+// @Security ApiAuthKey
 // @Accept  json
 // @Produce  json
 // @Param id path uint true "id"
@@ -456,12 +485,14 @@ func DeleteUserEducationByUserIdHandler(userSvc UserService, c *gin.Context) {
 // @Failure 500 {object} string
 // @Router /user/education/{id} [get]
 func GetUserEducationByUserIdHandler(userSvc UserService, c *gin.Context) {
-	userId := c.Param("id")
-	userIdInt, _ := strconv.Atoi(userId)
+	userId, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
 
-	expDetails, err := userSvc.GetUserEducationByUserId(uint(userIdInt))
+	expDetails, err := userSvc.GetUserEducationByUserId(userId)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, utils.ResponseMessage{StatusCode: http.StatusInternalServerError, Message: fmt.Sprintf("Cannot fetch user education against provided ID:", err), Data: nil})
+		utils.AbortWithError(c, utils.NewError(http.StatusInternalServerError, fmt.Sprintf("Cannot fetch user education against provided ID: %v", err), err))
 		return
 	}
 
@@ -479,6 +510,7 @@ type GetAllUserEducation struct {
 // @Summary Get all user education
 // @Description get all user education
 // @ID get-all-user-education
+// @Security ApiAuthKey
 // @Accept  json
 // @Produce  json
 // @Param id path uint true "id"
@@ -491,42 +523,26 @@ type GetAllUserEducation struct {
 // @Failure 500 {object} string
 // @Router /user/education/all/{id} [get]
 func GetAllUserEducationHandler(userSvc UserService, c *gin.Context) {
-	limit := c.Request.URL.Query().Get("limit")
-	offset := c.Request.URL.Query().Get("offset")
-	orderBy := c.Request.URL.Query().Get("orderBy")
-	userId := c.Param("id")
-	userIdInt, _ := strconv.Atoi(userId)
-	fmt.Println("userID", userIdInt)
-
-	if limit == "" {
-		limit = utils.DefaultLimit // default limit
-	}
-	if offset == "" {
-		offset = utils.DefaultOffset // default offset
-	}
-	if orderBy == "" {
-		orderBy = utils.DefaultOrderBy // default orderBy
-	}
-	limitInt, err := strconv.Atoi(limit)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, utils.ResponseMessage{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf(utils.InvalidIntegerValueLimitMessage, err), Data: nil})
+	userId, ok := utils.BindID(c, "id")
+	if !ok {
 		return
 	}
-	offsetInt, err := strconv.Atoi(offset)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, utils.ResponseMessage{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf(utils.InvalidIntegerValueOffsetMessage, err), Data: nil})
+	fmt.Println("userID", userId)
+
+	page, ok := utils.BindPage(c, utils.DefaultOrderBy, educationSortColumns...)
+	if !ok {
 		return
 	}
 
-	_, err = userSvc.GetUserEducationByUserId(uint(userIdInt))
+	_, err := userSvc.GetUserEducationByUserId(userId)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, utils.ResponseMessage{StatusCode: http.StatusInternalServerError, Message: fmt.Sprintf("Cannot fetch user education against provided ID:", err), Data: nil})
+		utils.AbortWithError(c, utils.NewError(http.StatusInternalServerError, fmt.Sprintf("Cannot fetch user education against provided ID: %v", err), err))
 		return
 	}
 
-	allUserEducation, total, err := userSvc.GetAllUserEducation(uint(userIdInt), limitInt, offsetInt, orderBy)
+	allUserEducation, total, err := userSvc.GetAllUserEducation(userId, page.Limit, page.Offset, page.OrderBy)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, utils.ResponseMessage{StatusCode: http.StatusInternalServerError, Message: fmt.Sprintf("Cannot fetch Users:", err), Data: nil})
+		utils.AbortWithError(c, utils.NewError(http.StatusInternalServerError, fmt.Sprintf("Cannot fetch Users: %v", err), err))
 		return
 	}
 
@@ -544,6 +560,7 @@ type CategoriesResponse struct {
 // @Summary Get all user categories
 // @Description gets all user categories
 // @ID get-all-user-categories
+// @Security ApiAuthKey
 // @Accept  json
 // @Produce  json
 // @Param   limit    query     int     false  "example - 50"     limit(int)
@@ -555,35 +572,193 @@ type CategoriesResponse struct {
 // @Failure 500 {object} string
 // @Router /users/get-all-user-categories [get]
 func GetAllUserCategoriesHandler(userSvc UserService, c *gin.Context) {
-	limit := c.Request.URL.Query().Get("limit")
-	offset := c.Request.URL.Query().Get("offset")
-	orderBy := c.Request.URL.Query().Get("orderBy")
 	//keyword := c.Request.URL.Query().Get("keyword")
 
-	if limit == "" {
-		limit = utils.DefaultLimit // default limit
+	page, ok := utils.BindPage(c, utils.DefaultOrderBy, userCategorySortColumns...)
+	if !ok {
+		return
+	}
+	categoriesList, count, err := userSvc.GetAllUserCategories("", page.Limit, page.Offset, page.OrderBy)
+	if err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusInternalServerError, fmt.Sprintf("Something went wrong while getting the categories: %v", err), err))
+		return
+	}
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: "success", Data: CategoriesResponse{Total: count, UserCategories: categoriesList, RecordsFiltered: len(categoriesList)}})
+}
+
+// GetAllRolesHandler godoc
+// @Tags user
+// @Summary Get all roles
+// @Description gets all roles that can be assigned to users
+// @ID get-all-roles
+// @Security ApiAuthKey
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} string
+// @Failure 401 {object} string
+// @Failure 500 {object} string
+// @Router /user/roles [get]
+func GetAllRolesHandler(userSvc UserService, c *gin.Context) {
+	roles, err := userSvc.GetAllRoles()
+	if err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusInternalServerError, fmt.Sprintf("Something went wrong while getting the roles: %v", err), err))
+		return
+	}
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: "Success", Data: roles})
+}
+
+// AssignRoleToUserHandler godoc
+// @Tags user
+// @Summary Assign role to user
+// @Description assigns a role to a user, admins only
+// @ID assign-role-to-user
+// @Security ApiAuthKey
+// @Accept  json
+// @Produce  json
+// @Param id path uint true "user id"
+// @Param roleId path uint true "role id"
+// @Success 200 {object} string
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Router /user/{id}/roles/{roleId} [post]
+func AssignRoleToUserHandler(userSvc UserService, c *gin.Context) {
+	userId, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
+	roleId, ok := utils.BindID(c, "roleId")
+	if !ok {
+		return
+	}
+
+	err := userSvc.AssignRoleToUser(userId, roleId)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		if errors.Is(err, ErrRoleAlreadyAssigned) {
+			statusCode = http.StatusConflict
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf("Something went wrong while assigning the role: %v", err), err))
+		return
 	}
-	if offset == "" {
-		offset = utils.DefaultOffset // default offset
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: "Role assigned successfully.", Data: nil})
+}
+
+// RevokeRoleFromUserHandler godoc
+// @Tags user
+// @Summary Revoke role from user
+// @Description revokes a role from a user, admins only. The last admin keeps the admin role
+// @ID revoke-role-from-user
+// @Security ApiAuthKey
+// @Accept  json
+// @Produce  json
+// @Param id path uint true "user id"
+// @Param roleId path uint true "role id"
+// @Success 200 {object} string
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 409 {object} string
+// @Failure 500 {object} string
+// @Router /user/{id}/roles/{roleId} [delete]
+func RevokeRoleFromUserHandler(userSvc UserService, c *gin.Context) {
+	userId, ok := utils.BindID(c, "id")
+	if !ok {
+		return
 	}
-	if orderBy == "" {
-		orderBy = utils.DefaultOrderBy // default orderBy
+	roleId, ok := utils.BindID(c, "roleId")
+	if !ok {
+		return
 	}
 
-	limitInt, err := strconv.Atoi(limit)
+	err := userSvc.RevokeRoleFromUser(userId, roleId)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, utils.ResponseMessage{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf(utils.InvalidIntegerValueLimitMessage, err), Data: nil})
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		if errors.Is(err, ErrLastAdmin) {
+			statusCode = http.StatusConflict
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf("Something went wrong while revoking the role: %v", err), err))
 		return
 	}
-	offsetInt, err := strconv.Atoi(offset)
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: "Role revoked successfully.", Data: nil})
+}
+
+// GetDuplicateUsersHandler godoc
+// @Tags user
+// @Summary Get duplicate users
+// @Description lists the groups of users that look like the same person, admins only. Users are grouped by email, ignoring case and a +tag,
+// @Description and by first name, last name and mobile number. A user can be in a group of each kind
+// @ID get-duplicate-users
+// @Security ApiAuthKey
+// @Produce  json
+// @Success 200 {object} []DuplicateGroup
+// @Failure 403 {object} string
+// @Failure 500 {object} string
+// @Router /user/duplicates [get]
+func GetDuplicateUsersHandler(userSvc UserService, c *gin.Context) {
+	groups, err := userSvc.FindDuplicateUsers()
 	if err != nil {
-		c.JSON(http.StatusBadRequest, utils.ResponseMessage{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf(utils.InvalidIntegerValueOffsetMessage, err), Data: nil})
+		utils.AbortWithError(c, utils.NewError(http.StatusInternalServerError, fmt.Sprintf(utils.SomethingWentWrongWhileFindingDuplicateUsers, err), err))
+		return
+	}
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.Success, Data: groups})
+}
+
+type MergeUsersRequest struct {
+	DuplicateUserIDs []uint `json:"duplicate_user_ids" validate:"required,min=1,max=20,dive,gt=0"`
+}
+
+// MergeUsersHandler godoc
+// @Tags user
+// @Summary Merge duplicate users
+// @Description moves the educations, experiences, projects, skills and bookings of the duplicates onto the user and deletes the duplicates, admins only.
+// @Description The user keeps its details and roles. A skill both have keeps the verified or best rated record with the endorsements of both
+// @ID merge-users
+// @Security ApiAuthKey
+// @Accept  json
+// @Produce  json
+// @Param id path uint true "id of the user to keep"
+// @Param MergeUsersRequest body MergeUsersRequest true "MergeUsersRequest"
+// @Success 200 {object} MergeResult
+// @Failure 400 {object} string
+// @Failure 403 {object} string
+// @Failure 404 {object} string
+// @Failure 500 {object} string
+// @Router /user/{id}/merge [post]
+func MergeUsersHandler(userSvc UserService, c *gin.Context) {
+	userId, ok := utils.BindID(c, "id")
+	if !ok {
+		return
+	}
+	var mergeRequest MergeUsersRequest
+	if err := c.ShouldBindJSON(&mergeRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.InvalidJsonBody, err), err))
 		return
 	}
-	categoriesList, count, err := userSvc.GetAllUserCategories("", limitInt, offsetInt, orderBy)
+	if err := validate.Struct(mergeRequest); err != nil {
+		utils.AbortWithError(c, utils.NewError(http.StatusBadRequest, fmt.Sprintf(utils.RequestSchemaInvalid, err), err))
+		return
+	}
+
+	result, err := userSvc.MergeUsers(userId, mergeRequest.DuplicateUserIDs)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, utils.ResponseMessage{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf("Something went wrong while getting the categories", err), Data: nil})
+		statusCode := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, ErrMergeIntoItself):
+			statusCode = http.StatusBadRequest
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			statusCode = http.StatusNotFound
+		}
+		utils.AbortWithError(c, utils.NewError(statusCode, fmt.Sprintf(utils.SomethingWentWrongWhileMergingUsers, err), err))
 		return
 	}
-	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: "success", Data: CategoriesResponse{Total: count, UserCategories: categoriesList, RecordsFiltered: len(categoriesList)}})
+	c.JSON(http.StatusOK, utils.ResponseMessage{StatusCode: http.StatusOK, Message: utils.SuccessfullyMergedUsers, Data: result})
 }
@@ -0,0 +1,139 @@
+package user
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/Octek/resource-profile-management-backend.git/utils"
+	"github.com/Octek/resource-profile-management-backend.git/utils/apitest"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// fakeUserRepository keeps users in memory
+type fakeUserRepository struct {
+	UserRepository
+	users map[uint]*User
+	page  utils.Page
+}
+
+func (repo *fakeUserRepository) GetUserDetailsByUserId(userId uint) (*User, error) {
+	if userObj, found := repo.users[userId]; found {
+		return userObj, nil
+	}
+	return &User{}, gorm.ErrRecordNotFound
+}
+
+func (repo *fakeUserRepository) GetAllUser(keyword string, limit int, offset int, orderBy string) ([]User, uint, error) {
+	repo.page = utils.Page{Limit: limit, Offset: offset, OrderBy: orderBy}
+	return []User{}, 0, nil
+}
+
+func (repo *fakeUserRepository) DeleteUserByUserID(userId uint) error {
+	delete(repo.users, userId)
+	return nil
+}
+
+func (repo *fakeUserRepository) UpdateUserByUserID(userObj *User) (*User, error) {
+	return userObj, nil
+}
+
+func newTestRouter(repo *fakeUserRepository) *gin.Engine {
+	userSvc := NewService(repo)
+	router := apitest.NewRouter()
+	router.GET("/user/all", func(c *gin.Context) {
+		GetAllUsersListHandler(userSvc, c)
+	})
+	router.GET("/user/:id", func(c *gin.Context) {
+		GetUserDetailsByUserIdHandler(userSvc, c)
+	})
+	router.DELETE("/user/:id", func(c *gin.Context) {
+		DeleteUserByUserIdHandler(userSvc, c)
+	})
+	router.PATCH("/user/:id", func(c *gin.Context) {
+		UpdateUserByUserIdHandler(userSvc, c)
+	})
+	return router
+}
+
+func TestGetUserDetailsByUserIdHandler(t *testing.T) {
+	router := newTestRouter(&fakeUserRepository{users: map[uint]*User{1: {ID: 1, Email: "jane@example.com"}}})
+	tests := []struct {
+		path       string
+		statusCode int
+		code       string
+	}{
+		{path: "/user/1", statusCode: http.StatusOK},
+		{path: "/user/2", statusCode: http.StatusNotFound, code: utils.ErrorCodeNotFound},
+		{path: "/user/abc", statusCode: http.StatusBadRequest, code: utils.ErrorCodeBadRequest},
+		{path: "/user/0", statusCode: http.StatusBadRequest, code: utils.ErrorCodeBadRequest},
+		{path: "/user/-1", statusCode: http.StatusBadRequest, code: utils.ErrorCodeBadRequest},
+		{path: "/user/1.5", statusCode: http.StatusBadRequest, code: utils.ErrorCodeBadRequest},
+	}
+	for _, test := range tests {
+		statusCode, response := apitest.Serve(t, router, http.MethodGet, test.path, "")
+		if statusCode != test.statusCode || response.StatusCode != test.statusCode || response.Code != test.code {
+			t.Errorf("GET %s = %d with code %q, want %d with code %q", test.path, statusCode, response.Code, test.statusCode, test.code)
+		}
+	}
+}
+
+func TestDeleteUserByUserIdHandler(t *testing.T) {
+	router := newTestRouter(&fakeUserRepository{users: map[uint]*User{1: {ID: 1}}})
+	tests := []struct {
+		path       string
+		statusCode int
+	}{
+		{path: "/user/abc", statusCode: http.StatusBadRequest},
+		{path: "/user/1", statusCode: http.StatusOK},
+		{path: "/user/1", statusCode: http.StatusNotFound},
+	}
+	for _, test := range tests {
+		if statusCode, _ := apitest.Serve(t, router, http.MethodDelete, test.path, ""); statusCode != test.statusCode {
+			t.Errorf("DELETE %s = %d, want %d", test.path, statusCode, test.statusCode)
+		}
+	}
+}
+
+func TestUpdateUserByUserIdHandler(t *testing.T) {
+	router := newTestRouter(&fakeUserRepository{users: map[uint]*User{1: {ID: 1, Email: "jane@example.com"}}})
+
+	statusCode, response := apitest.Serve(t, router, http.MethodPatch, "/user/2", `{"first_name": "Jane"}`)
+	if statusCode != http.StatusNotFound || response.Code != utils.ErrorCodeNotFound {
+		t.Errorf("PATCH of a missing user = %d with code %q, want 404", statusCode, response.Code)
+	}
+
+	statusCode, response = apitest.Serve(t, router, http.MethodPatch, "/user/1", `{"email": "not an email"}`)
+	if statusCode != http.StatusBadRequest || response.Code != utils.ErrorCodeValidationFailed {
+		t.Fatalf("PATCH with an invalid email = %d with code %q, want 400 with code %q", statusCode, response.Code, utils.ErrorCodeValidationFailed)
+	}
+	if len(response.Details) != 1 || response.Details[0].Field != "email" || response.Details[0].Rule != "email" {
+		t.Errorf("PATCH with an invalid email reported %+v, want the email rule of the email field", response.Details)
+	}
+
+	statusCode, _ = apitest.Serve(t, router, http.MethodPatch, "/user/1", `{"first_name": "Jane"}`)
+	if statusCode != http.StatusOK {
+		t.Errorf("PATCH of a user = %d, want 200", statusCode)
+	}
+}
+
+func TestGetAllUsersListHandler(t *testing.T) {
+	repo := &fakeUserRepository{}
+	router := newTestRouter(repo)
+
+	statusCode, _ := apitest.Serve(t, router, http.MethodGet, "/user/all", "")
+	if want := (utils.Page{Limit: 20, Offset: 0, OrderBy: utils.DefaultOrderBy}); statusCode != http.StatusOK || repo.page != want {
+		t.Errorf("GET /user/all = %d listing %+v, want 200 listing %+v", statusCode, repo.page, want)
+	}
+	statusCode, _ = apitest.Serve(t, router, http.MethodGet, "/user/all?limit=5&offset=10&orderBy=endorsement_count%20desc", "")
+	if want := (utils.Page{Limit: 5, Offset: 10, OrderBy: "endorsement_count desc"}); statusCode != http.StatusOK || repo.page != want {
+		t.Errorf("GET /user/all with a page = %d listing %+v, want 200 listing %+v", statusCode, repo.page, want)
+	}
+
+	for _, query := range []string{"limit=abc", "limit=0", "limit=1001", "offset=-1", "offset=abc", "orderBy=id%3Bdrop%20table%20users", "orderBy=password%20desc"} {
+		statusCode, response := apitest.Serve(t, router, http.MethodGet, "/user/all?"+query, "")
+		if statusCode != http.StatusBadRequest || response.Code != utils.ErrorCodeBadRequest {
+			t.Errorf("GET /user/all?%s = %d with code %q, want 400", query, statusCode, response.Code)
+		}
+	}
+}
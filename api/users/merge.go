@@ -0,0 +1,304 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Octek/resource-profile-management-backend.git/api/skills"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+const (
+	// UserEmailIndex keeps the emails of the users that are not deleted unique regardless of case
+	UserEmailIndex = "idx_users_email_lower"
+
+	DuplicateReasonEmail         = "email"
+	DuplicateReasonNameAndMobile = "name_and_mobile"
+	MaxMergedUsers               = 20
+
+	uniqueViolationCode = "23505"
+	// normalizedEmailSQL ignores the case and a +tag of the local part, john+cv@x.com is john@x.com
+	normalizedEmailSQL = `LOWER(regexp_replace(btrim(email), '\+[^@]*@', '@'))`
+	nameAndMobileSQL   = `LOWER(btrim(first_name) || ' ' || btrim(last_name)) || ' ' || regexp_replace(mobile_number, '[^0-9]', '', 'g')`
+)
+
+var (
+	ErrEmailAlreadyExists = errors.New("a user with this email already exists")
+	ErrMergeIntoItself    = errors.New("a user cannot be merged into itself")
+	ErrDuplicateEmails    = errors.New("users share an email, merge them before making the emails unique")
+)
+
+// DuplicateGroup is a set of profiles that look like the same person, Key is the email or the name and mobile
+// number they share
+type DuplicateGroup struct {
+	Reason string `json:"reason"`
+	Key    string `json:"key"`
+	Users  []User `json:"users"`
+}
+
+// MergeResult counts the records moved from the merged users onto the kept user
+type MergeResult struct {
+	UserID        uint   `json:"user_id"`
+	MergedUserIDs []uint `json:"merged_user_ids"`
+	Educations    int64  `json:"educations"`
+	Experiences   int64  `json:"experiences"`
+	Projects      int64  `json:"projects"`
+	Skills        int64  `json:"skills"`
+	Bookings      int64  `json:"bookings"`
+}
+
+// userLinkTables link a user to records other users can be linked to as well, the user_id of one link per record
+// moves to the kept user unless it is already linked, the other links are dropped
+var userLinkTables = []struct {
+	table  string
+	column string
+}{
+	{table: "user_experiences", column: "experience_id"},
+	{table: "user_projects", column: "project_id"},
+	{table: "share_link_users", column: "share_link_id"},
+}
+
+// singleUserTables hold at most one row per user, the row of a merged user moves when the kept user has none
+var singleUserTables = []string{"credentials", "user_availabilities", "calendar_feeds"}
+
+// isEmailConflict tells whether err violates the unique email index
+func isEmailConflict(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode && pgErr.ConstraintName == UserEmailIndex
+}
+
+// MigrateUniqueEmail trims the emails and makes them unique regardless of case. Users sharing an email fail
+// the migration, an admin merges them with the merge-users command before it is applied again.
+func MigrateUniqueEmail(db *gorm.DB) error {
+	if err := db.Exec("UPDATE users SET email = btrim(email) WHERE email <> btrim(email)").Error; err != nil {
+		return err
+	}
+	var groups []struct {
+		Email   string
+		UserIDs string
+	}
+	err := db.Table("users").Select("LOWER(email) AS email, string_agg(id::text, ', ' ORDER BY id) AS user_ids").
+		Where("deleted_at IS NULL").Group("LOWER(email)").Having("COUNT(*) > 1").Order("email").Scan(&groups).Error
+	if err != nil {
+		return err
+	}
+	if len(groups) > 0 {
+		conflicts := make([]string, 0, len(groups))
+		for _, group := range groups {
+			conflicts = append(conflicts, fmt.Sprintf("users %s share %q", group.UserIDs, group.Email))
+		}
+		return fmt.Errorf("%w: %s", ErrDuplicateEmails, strings.Join(conflicts, "; "))
+	}
+	return db.Exec("CREATE UNIQUE INDEX IF NOT EXISTS " + UserEmailIndex + " ON users (LOWER(email)) WHERE deleted_at IS NULL").Error
+}
+
+// FindDuplicateUsers groups the users sharing an email, ignoring case and +tags, or a name and mobile number
+func (repo *userRepositoryPostgres) FindDuplicateUsers() ([]DuplicateGroup, error) {
+	groups := []DuplicateGroup{}
+	for _, criterion := range []struct {
+		reason string
+		key    string
+		where  string
+	}{
+		{reason: DuplicateReasonEmail, key: normalizedEmailSQL, where: "email <> ''"},
+		{reason: DuplicateReasonNameAndMobile, key: nameAndMobileSQL, where: "regexp_replace(mobile_number, '[^0-9]', '', 'g') <> ''"},
+	} {
+		var rows []struct {
+			Key     string
+			UserIDs string
+		}
+		err := repo.db.Table("users").
+			Select(criterion.key + " AS key, string_agg(id::text, ',' ORDER BY id) AS user_ids").
+			Where("deleted_at IS NULL AND " + criterion.where).
+			Group(criterion.key).Having("COUNT(*) > 1").Order("key").Scan(&rows).Error
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			userIDs, err := parseIDList(row.UserIDs)
+			if err != nil {
+				return nil, err
+			}
+			var users []User
+			if err := repo.db.Where("id IN ?", userIDs).Order("id asc").Find(&users).Error; err != nil {
+				return nil, err
+			}
+			groups = append(groups, DuplicateGroup{Reason: criterion.reason, Key: row.Key, Users: users})
+		}
+	}
+	return groups, nil
+}
+
+// MergeUsers moves the records of the duplicates onto the user and deletes the duplicates, the user keeps its
+// own details and roles
+func (repo *userRepositoryPostgres) MergeUsers(userID uint, duplicateIDs []uint) (MergeResult, error) {
+	var result MergeResult
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&User{}).Where("id IN ?", append([]uint{userID}, duplicateIDs...)).Count(&count).Error; err != nil {
+			return err
+		}
+		if count != int64(len(duplicateIDs)+1) {
+			return gorm.ErrRecordNotFound
+		}
+		var err error
+		result, err = mergeUsers(tx, userID, duplicateIDs)
+		return err
+	})
+	return result, err
+}
+
+// mergeUsers is the merge within a transaction. A skill both users have keeps the verified or best rated record,
+// with the longest use and the latest last use of both, and the endorsements of both without a self endorsement.
+func mergeUsers(tx *gorm.DB, userID uint, duplicateIDs []uint) (MergeResult, error) {
+	result := MergeResult{UserID: userID, MergedUserIDs: duplicateIDs}
+	update := tx.Model(&Education{}).Where("user_id IN ?", duplicateIDs).Update("user_id", userID)
+	if update.Error != nil {
+		return result, update.Error
+	}
+	result.Educations = update.RowsAffected
+	update = tx.Table("bookings").Where("user_id IN ?", duplicateIDs).Update("user_id", userID)
+	if update.Error != nil {
+		return result, update.Error
+	}
+	result.Bookings = update.RowsAffected
+
+	for _, link := range userLinkTables {
+		if !tx.Migrator().HasTable(link.table) {
+			continue
+		}
+		update = tx.Exec(fmt.Sprintf(`UPDATE %[1]s SET user_id = @user WHERE user_id IN @duplicates
+			AND ctid IN (SELECT DISTINCT ON (%[2]s) ctid FROM %[1]s WHERE user_id IN @duplicates ORDER BY %[2]s, id)
+			AND NOT EXISTS (SELECT 1 FROM %[1]s AS linked WHERE linked.user_id = @user AND linked.%[2]s = %[1]s.%[2]s)`,
+			link.table, link.column), map[string]interface{}{"user": userID, "duplicates": duplicateIDs})
+		if update.Error != nil {
+			return result, update.Error
+		}
+		switch link.table {
+		case "user_experiences":
+			result.Experiences = update.RowsAffected
+		case "user_projects":
+			result.Projects = update.RowsAffected
+		}
+		if err := tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE user_id IN ?", link.table), duplicateIDs).Error; err != nil {
+			return result, err
+		}
+	}
+
+	movedSkills, err := mergeUserSkills(tx, userID, duplicateIDs)
+	if err != nil {
+		return result, err
+	}
+	result.Skills = movedSkills
+
+	for _, table := range singleUserTables {
+		if !tx.Migrator().HasTable(table) {
+			continue
+		}
+		err := tx.Exec(fmt.Sprintf(`UPDATE %[1]s SET user_id = @user WHERE id = (
+			SELECT id FROM %[1]s WHERE user_id IN @duplicates ORDER BY user_id, id LIMIT 1)
+			AND NOT EXISTS (SELECT 1 FROM %[1]s WHERE user_id = @user)`, table),
+			map[string]interface{}{"user": userID, "duplicates": duplicateIDs}).Error
+		if err != nil {
+			return result, err
+		}
+	}
+	if tx.Migrator().HasTable("share_links") {
+		if err := tx.Table("share_links").Where("created_by_user_id IN ?", duplicateIDs).
+			Update("created_by_user_id", userID).Error; err != nil {
+			return result, err
+		}
+	}
+	return result, tx.Delete(&User{}, duplicateIDs).Error
+}
+
+// mergeUserSkills moves the skills of the duplicates and the endorsements and verifications they gave to the user,
+// it returns the number of skills the user gained
+func mergeUserSkills(tx *gorm.DB, userID uint, duplicateIDs []uint) (int64, error) {
+	userIDs := append([]uint{userID}, duplicateIDs...)
+	// CREATE TABLE AS takes no bind parameters, the ids are numbers
+	if err := tx.Exec(fmt.Sprintf(`CREATE TEMP TABLE user_merge_skills ON COMMIT DROP AS
+		SELECT id,
+			FIRST_VALUE(id) OVER (PARTITION BY skill_id ORDER BY assessment = '%s' DESC, proficiency DESC, user_id = %d DESC, id) AS kept_id,
+			MAX(years_of_use) OVER (PARTITION BY skill_id) AS years_of_use,
+			MAX(last_used_on) OVER (PARTITION BY skill_id) AS last_used_on,
+			COUNT(*) OVER (PARTITION BY skill_id) AS records
+		FROM user_skills WHERE user_id IN (%s)`, skills.AssessmentVerified, userID, joinIDs(userIDs))).Error; err != nil {
+		return 0, err
+	}
+	statements := []string{
+		`UPDATE user_skills SET years_of_use = user_merge_skills.years_of_use, last_used_on = user_merge_skills.last_used_on
+			FROM user_merge_skills WHERE user_skills.id = user_merge_skills.kept_id AND user_merge_skills.id = user_merge_skills.kept_id
+			AND user_merge_skills.records > 1`,
+		// an endorser keeps one endorsement on the kept record, the older one
+		`UPDATE skill_endorsements SET user_skill_id = user_merge_skills.kept_id FROM user_merge_skills
+			WHERE skill_endorsements.user_skill_id = user_merge_skills.id AND user_merge_skills.id <> user_merge_skills.kept_id
+			AND skill_endorsements.id IN (
+				SELECT DISTINCT ON (merges.kept_id, endorsements.endorser_user_id) endorsements.id
+				FROM skill_endorsements AS endorsements JOIN user_merge_skills AS merges ON merges.id = endorsements.user_skill_id
+				WHERE merges.id <> merges.kept_id
+				ORDER BY merges.kept_id, endorsements.endorser_user_id, endorsements.id)
+			AND NOT EXISTS (SELECT 1 FROM skill_endorsements AS kept WHERE kept.user_skill_id = user_merge_skills.kept_id
+				AND kept.endorser_user_id = skill_endorsements.endorser_user_id)`,
+		`DELETE FROM skill_endorsements USING user_merge_skills
+			WHERE skill_endorsements.user_skill_id = user_merge_skills.id AND user_merge_skills.id <> user_merge_skills.kept_id`,
+		`DELETE FROM user_skills USING user_merge_skills
+			WHERE user_skills.id = user_merge_skills.id AND user_merge_skills.id <> user_merge_skills.kept_id`,
+		`DROP TABLE user_merge_skills`,
+	}
+	for _, statement := range statements {
+		if err := tx.Exec(statement).Error; err != nil {
+			return 0, err
+		}
+	}
+	update := tx.Model(&skills.UserSkill{}).Where("user_id IN ?", duplicateIDs).Update("user_id", userID)
+	if update.Error != nil {
+		return 0, update.Error
+	}
+
+	// the endorsements given by a duplicate are given by the user, unless the user would endorse itself or twice,
+	// and a skill the user verified for a duplicate is self assessed
+	params := map[string]interface{}{"user": userID, "duplicates": duplicateIDs}
+	statements = []string{
+		`DELETE FROM skill_endorsements WHERE endorser_user_id = @user
+			AND user_skill_id IN (SELECT id FROM user_skills WHERE user_id = @user)`,
+		`UPDATE skill_endorsements SET endorser_user_id = @user WHERE endorser_user_id IN @duplicates
+			AND user_skill_id NOT IN (SELECT id FROM user_skills WHERE user_id = @user)
+			AND id IN (SELECT DISTINCT ON (user_skill_id) id FROM skill_endorsements WHERE endorser_user_id IN @duplicates ORDER BY user_skill_id, id)
+			AND NOT EXISTS (SELECT 1 FROM skill_endorsements AS kept WHERE kept.user_skill_id = skill_endorsements.user_skill_id
+				AND kept.endorser_user_id = @user)`,
+		`DELETE FROM skill_endorsements WHERE endorser_user_id IN @duplicates`,
+		fmt.Sprintf(`UPDATE user_skills SET assessment = '%s', verified_by_user_id = NULL, verified_at = NULL
+			WHERE user_id = @user AND (verified_by_user_id = @user OR verified_by_user_id IN @duplicates)`, skills.AssessmentSelf),
+		`UPDATE user_skills SET verified_by_user_id = @user WHERE verified_by_user_id IN @duplicates`,
+	}
+	for _, statement := range statements {
+		if err := tx.Exec(statement, params).Error; err != nil {
+			return 0, err
+		}
+	}
+	return update.RowsAffected, nil
+}
+
+func parseIDList(list string) ([]uint, error) {
+	var ids []uint
+	for _, part := range strings.Split(list, ",") {
+		id, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, uint(id))
+	}
+	return ids, nil
+}
+
+func joinIDs(ids []uint) string {
+	parts := make([]string, 0, len(ids))
+	for _, id := range ids {
+		parts = append(parts, strconv.FormatUint(uint64(id), 10))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -17,4 +17,10 @@ type UserRepository interface {
 	DeleteUserEducationByID(userId uint) error
 	GetAllUserEducation(userId uint, limit int, offset int, orderBy string) ([]Education, uint, error)
 	GetAllUserCategories(keyword string, limit int, offset int, orderBy string) ([]UserCategory, int64, error)
+	GetAllRoles() ([]Role, error)
+	GetRoleByName(name string) (Role, error)
+	AssignRoleToUser(userId, roleId uint) error
+	RevokeRoleFromUser(userId, roleId uint) error
+	FindDuplicateUsers() ([]DuplicateGroup, error)
+	MergeUsers(userID uint, duplicateIDs []uint) (MergeResult, error)
 }
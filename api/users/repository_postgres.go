@@ -3,8 +3,11 @@ package user
 import (
 	"errors"
 	"fmt"
+	"github.com/Octek/resource-profile-management-backend.git/api/auth"
+	"github.com/Octek/resource-profile-management-backend.git/api/skills"
 	log "github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"strings"
 )
 
@@ -13,10 +16,6 @@ type userRepositoryPostgres struct {
 }
 
 func NewUserRepositoryPostgres(db *gorm.DB) UserRepository {
-	err := db.AutoMigrate(&Role{}, &UserRole{}, &UserCategory{}, &User{}, &Education{})
-	if err != nil {
-		log.Fatal(err)
-	}
 	log.Print("Successfully connected to postgres in users service!")
 
 	return &userRepositoryPostgres{
@@ -24,25 +23,50 @@ func NewUserRepositoryPostgres(db *gorm.DB) UserRepository {
 	}
 }
 
+// CreateUser gives new users the User role so the permission checks apply to them, an email in use fails with
+// ErrEmailAlreadyExists
 func (repo *userRepositoryPostgres) CreateUser(user *User) (*User, error) {
-	err := repo.db.Create(user).Error
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		return AssignDefaultRole(tx, user.ID)
+	})
+	if isEmailConflict(err) {
+		return user, ErrEmailAlreadyExists
+	}
 	return user, err
 }
 
+// AssignDefaultRole gives a new user the User role within the transaction creating the user
+func AssignDefaultRole(tx *gorm.DB, userID uint) error {
+	var defaultRole Role
+	err := tx.Where("name = ?", auth.RoleUser).First(&defaultRole).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return tx.Create(&UserRole{UserID: userID, RoleID: defaultRole.ID}).Error
+}
+
 func (repo *userRepositoryPostgres) GetAllUser(keyword string, limit int, offset int, orderBy string) ([]User, uint, error) {
 	var users []User
 	var total int64
 
 	query := repo.db.Model(&User{}).Where("deleted_at IS NULL")
 	if keyword != "" {
-		query = query.Where("LOWER(first_name) LIKE ?", "%"+strings.ToLower(keyword)+"%")
+		keyword = "%" + strings.ToLower(keyword) + "%"
+		query = query.Where("LOWER(first_name) LIKE ? OR LOWER(last_name) LIKE ? OR LOWER(job_title) LIKE ?", keyword, keyword, keyword)
 	}
 
 	err := query.Count(&total).Error
 	if err != nil {
 		return nil, 0, err
 	}
-	err = query.Order(orderBy).Limit(limit).Offset(offset).Find(&users).Error
+	err = query.Select("users.*, " + skills.EndorsementCountSQL("users.id") + " AS endorsement_count").
+		Order(orderBy).Limit(limit).Offset(offset).Find(&users).Error
 	if err != nil {
 		return nil, uint(total), err
 	}
@@ -57,13 +81,41 @@ func (repo *userRepositoryPostgres) GetUserDetailsByUserId(id uint) (*User, erro
 		Preload("Skills.SkillCategory").
 		Preload("Experiences").Preload("Projects").Preload("UserCategory").
 		First(&user).Error
-	for i := range user.Experiences {
-		user.Experiences[i].ParseResponsibilities()
+	if err != nil {
+		return &user, err
 	}
-
+	err = repo.setEndorsementCounts(&user)
 	return &user, err
 }
 
+// setEndorsementCounts sets the number of endorsements of every skill of the user and their total
+func (repo *userRepositoryPostgres) setEndorsementCounts(user *User) error {
+	var counts []struct {
+		SkillID uint
+		Count   int64
+	}
+	err := repo.db.Table("user_skills").
+		Select("user_skills.skill_id, COUNT(skill_endorsements.id) AS count").
+		Joins("JOIN skill_endorsements ON skill_endorsements.user_skill_id = user_skills.id").
+		Where("user_skills.user_id = ?", user.ID).
+		Group("user_skills.skill_id").
+		Scan(&counts).Error
+	if err != nil {
+		return err
+	}
+	countBySkill := make(map[uint]int64, len(counts))
+	for _, count := range counts {
+		countBySkill[count.SkillID] = count.Count
+	}
+	user.EndorsementCount = 0
+	for i := range user.Skills {
+		count := countBySkill[user.Skills[i].ID]
+		user.Skills[i].EndorsementCount = &count
+		user.EndorsementCount += count
+	}
+	return nil
+}
+
 func (repo *userRepositoryPostgres) DeleteUserByUserID(id uint) error {
 	err := repo.db.Delete(&User{}, id).Error
 
@@ -72,6 +124,9 @@ func (repo *userRepositoryPostgres) DeleteUserByUserID(id uint) error {
 
 func (repo *userRepositoryPostgres) UpdateUserByUserID(user *User) (*User, error) {
 	if err := repo.db.Model(&User{}).Where("id = ?", user.ID).Updates(&user).Error; err != nil {
+		if isEmailConflict(err) {
+			return &User{}, ErrEmailAlreadyExists
+		}
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return &User{}, fmt.Errorf("user with ID %d not found", user.ID)
 		}
@@ -196,3 +251,68 @@ func (repo *userRepositoryPostgres) GetAllUserCategories(keyword string, limit i
 	}
 	return users, count, nil
 }
+
+func (repo *userRepositoryPostgres) GetAllRoles() ([]Role, error) {
+	var roles []Role
+	err := repo.db.Model(&Role{}).Order("id asc").Find(&roles).Error
+	return roles, err
+}
+
+func (repo *userRepositoryPostgres) GetRoleByName(name string) (Role, error) {
+	var role Role
+	err := repo.db.Model(&Role{}).Where("name = ?", name).First(&role).Error
+	return role, err
+}
+
+func (repo *userRepositoryPostgres) AssignRoleToUser(userId, roleId uint) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&User{}).Where("id = ? AND deleted_at IS NULL", userId).First(&User{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&Role{}).Where("id = ?", roleId).First(&Role{}).Error; err != nil {
+			return err
+		}
+		var count int64
+		if err := tx.Model(&UserRole{}).Where("user_id = ? AND role_id = ?", userId, roleId).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrRoleAlreadyAssigned
+		}
+		if err := tx.Create(&UserRole{UserID: userId, RoleID: roleId}).Error; err != nil {
+			return err
+		}
+		fmt.Printf("Role %d has been assigned to user %d\n", roleId, userId)
+		return nil
+	})
+}
+
+func (repo *userRepositoryPostgres) RevokeRoleFromUser(userId, roleId uint) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		var role Role
+		if err := tx.Model(&Role{}).Where("id = ?", roleId).First(&role).Error; err != nil {
+			return err
+		}
+		if role.Name == auth.RoleAdmin {
+			// lock the admin assignments so two concurrent revocations cannot both pass the check
+			var adminUserIDs []uint
+			err := tx.Model(&UserRole{}).Where("role_id = ?", roleId).Clauses(clause.Locking{Strength: "UPDATE"}).
+				Pluck("user_id", &adminUserIDs).Error
+			if err != nil {
+				return err
+			}
+			if len(adminUserIDs) == 1 && adminUserIDs[0] == userId {
+				return ErrLastAdmin
+			}
+		}
+		result := tx.Where("user_id = ? AND role_id = ?", userId, roleId).Delete(&UserRole{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		fmt.Printf("Role %d has been revoked from user %d\n", roleId, userId)
+		return nil
+	})
+}
@@ -1,5 +1,12 @@
 package user
 
+import (
+	"errors"
+	"github.com/Octek/resource-profile-management-backend.git/api/auth"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
 type UserService struct {
 	userRepository UserRepository
 }
@@ -60,3 +67,56 @@ func (svc *UserService) GetAllUserEducation(userId uint, limit int, offset int,
 func (svc *UserService) GetAllUserCategories(keyword string, limit int, offset int, orderBy string) ([]UserCategory, int64, error) {
 	return svc.userRepository.GetAllUserCategories(keyword, limit, offset, orderBy)
 }
+
+func (svc *UserService) GetAllRoles() ([]Role, error) {
+	return svc.userRepository.GetAllRoles()
+}
+
+func (svc *UserService) GetRoleByName(name string) (Role, error) {
+	return svc.userRepository.GetRoleByName(name)
+}
+
+func (svc *UserService) AssignRoleToUser(userId, roleId uint) error {
+	return svc.userRepository.AssignRoleToUser(userId, roleId)
+}
+
+func (svc *UserService) RevokeRoleFromUser(userId, roleId uint) error {
+	return svc.userRepository.RevokeRoleFromUser(userId, roleId)
+}
+
+func (svc *UserService) FindDuplicateUsers() ([]DuplicateGroup, error) {
+	return svc.userRepository.FindDuplicateUsers()
+}
+
+// MergeUsers merges the duplicates into the user, listing a duplicate twice merges it once
+func (svc *UserService) MergeUsers(userID uint, duplicateIDs []uint) (MergeResult, error) {
+	var uniqueIDs []uint
+	seen := make(map[uint]bool, len(duplicateIDs))
+	for _, duplicateID := range duplicateIDs {
+		if duplicateID == userID {
+			return MergeResult{}, ErrMergeIntoItself
+		}
+		if !seen[duplicateID] {
+			seen[duplicateID] = true
+			uniqueIDs = append(uniqueIDs, duplicateID)
+		}
+	}
+	return svc.userRepository.MergeUsers(userID, uniqueIDs)
+}
+
+// isEducationOwner is the owner check of the routes addressing an education by its id,
+// a missing education is reported as not found before ownership is considered
+func (svc *UserService) isEducationOwner(c *gin.Context, userId uint) (bool, error) {
+	educationId, err := auth.ParamID(c, "id")
+	if err != nil {
+		return false, err
+	}
+	if _, err := svc.userRepository.GetEducationById(educationId); err != nil {
+		return false, err
+	}
+	_, err = svc.userRepository.GetUserEducationByUserAndEducationId(userId, educationId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
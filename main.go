@@ -3,12 +3,20 @@ package main
 import (
 	"errors"
 	"fmt"
+	"github.com/Octek/resource-profile-management-backend.git/api/auth"
 	"github.com/Octek/resource-profile-management-backend.git/api/bookings"
+	"github.com/Octek/resource-profile-management-backend.git/api/bulkimport"
 	"github.com/Octek/resource-profile-management-backend.git/api/experience"
+	"github.com/Octek/resource-profile-management-backend.git/api/migrations"
 	"github.com/Octek/resource-profile-management-backend.git/api/projects"
 	"github.com/Octek/resource-profile-management-backend.git/api/questions"
+	"github.com/Octek/resource-profile-management-backend.git/api/resume"
+	"github.com/Octek/resource-profile-management-backend.git/api/search"
 	"github.com/Octek/resource-profile-management-backend.git/api/seed"
+	"github.com/Octek/resource-profile-management-backend.git/api/sharing"
 	"github.com/Octek/resource-profile-management-backend.git/api/skills"
+	"github.com/Octek/resource-profile-management-backend.git/api/talent"
+	"github.com/Octek/resource-profile-management-backend.git/api/timeline"
 	user "github.com/Octek/resource-profile-management-backend.git/api/users"
 	"github.com/Octek/resource-profile-management-backend.git/docs"
 	"github.com/Octek/resource-profile-management-backend.git/utils"
@@ -24,6 +32,8 @@ import (
 	"net/http"
 	"os"
 	"time"
+	// embed the time zone database, the runtime image has no tzdata for booking availability
+	_ "time/tzdata"
 )
 
 var logger *log.Logger
@@ -66,6 +76,10 @@ func SetupDatabase(connString string) (*gorm.DB, error) {
 	return db, nil
 }
 
+// @securityDefinitions.apikey ApiAuthKey
+// @in header
+// @name Authorization
+// @description Bearer token from /auth/login, e.g. "Bearer eyJhbGciOi..."
 func main() {
 	// setup database connection
 	db, err := SetupDatabase(utils.GetConnectionString())
@@ -74,17 +88,36 @@ func main() {
 	}
 	fmt.Println("setup database connection successful")
 
+	// `main migrate [up [version] | down [steps] | status]` changes the schema, the service only runs on a migrated one
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := migrations.RunCommand(db, os.Args[2:], os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	// `main duplicates` and `main merge-users <id> <duplicate id>...` resolve the users failing the unique email
+	// migration, they run while it is pending
+	if len(os.Args) > 1 && (os.Args[1] == user.CommandDuplicates || os.Args[1] == user.CommandMergeUsers) {
+		if err := user.RunCommand(user.NewService(user.NewUserRepositoryPostgres(db)), os.Args[1], os.Args[2:], os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if err := migrations.RequireCurrent(db); err != nil {
+		log.Fatal(err)
+	}
+
 	router := gin.New()
 
 	router.Use(cors.New(cors.Config{
-		AllowOrigins:     []string{"*"},
+		AllowOrigins:     utils.GetCorsAllowedOrigins(),
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS", "PATCH"},
 		AllowHeaders:     []string{"X-Requested-With", "Content-Type", "Authorization", "Access-Control-Allow-Headers", "Access-Control-Request-Method", "Access-Control-Request-Headers"},
 		AllowCredentials: false,
 		MaxAge:           12 * time.Hour,
 	}))
 
-	router.Use(ginlogrus.Logger(logger), gin.Recovery())
+	router.Use(ginlogrus.Logger(logger), gin.Recovery(), utils.ErrorHandler())
 	router.MaxMultipartMemory = 8 << 20 // 8 MiB
 
 	// programmatically set swagger info
@@ -101,37 +134,73 @@ func main() {
 		c.JSON(http.StatusOK, gin.H{"status": "OK", "statusCode": http.StatusOK})
 	})
 
+	// Auth
+	var authRepo = auth.NewAuthRepositoryPostgres(db)
+	authService := auth.NewService(authRepo, auth.NewLocalIdentityProvider(authRepo), utils.GetJwtSigningKey(), utils.GetJwtTokenTTL(auth.DefaultTokenTTL))
+	auth.Routes(router, authService)
+	authenticatedRouter := router.Group("", auth.RequireAuthentication(authService))
+
 	// Skill
 	var skillRepo = skills.NewSkillRepositoryPostgres(db)
 	skillService := skills.NewService(skillRepo)
-	skills.Routes(router, skillService)
+	skills.Routes(authenticatedRouter, skillService)
 
 	// Experience
 	var experienceRepo = experience.NewExperienceRepositoryPostgres(db)
 	experienceService := experience.NewService(experienceRepo)
-	experience.Routes(router, experienceService)
+	experience.Routes(authenticatedRouter, experienceService)
 
 	// Question
 	var questionRepo = questions.NewQuestionRepositoryPostgres(db)
 	questionService := questions.NewService(questionRepo)
-	questions.Routes(router, questionService)
+	questions.Routes(router, authenticatedRouter, questionService)
 
 	// Project
 	var projectRepo = projects.NewProjectRepositoryPostgres(db)
 	projectService := projects.NewService(projectRepo)
-	projects.Routes(router, projectService)
+	projects.Routes(authenticatedRouter, projectService)
 
 	// Booking
 	var bookingRepo = bookings.NewBookingRepositoryPostgres(db)
 	bookingService := bookings.NewService(bookingRepo)
-	bookings.Routes(router, bookingService)
+	bookings.Routes(router, authenticatedRouter, bookingService)
 
 	// User
 	var userRepo = user.NewUserRepositoryPostgres(db)
 	userService := user.NewService(userRepo)
-	user.Routes(router, userService)
-
-	seed.SeedData(userService)
+	user.Routes(authenticatedRouter, userService)
+
+	// Resume
+	resumeService := resume.NewService(userService, skillService)
+	resume.Routes(authenticatedRouter, resumeService)
+
+	// Search
+	var searchRepo = search.NewSearchRepositoryPostgres(db)
+	searchService := search.NewService(searchRepo)
+	search.Routes(authenticatedRouter, searchService)
+
+	// Talent
+	var talentRepo = talent.NewTalentRepositoryPostgres(db)
+	talentService := talent.NewService(talentRepo, bookingService, skillService)
+	talent.Routes(authenticatedRouter, talentService)
+
+	// Import
+	var importRepo = bulkimport.NewImportRepositoryPostgres(db)
+	importService := bulkimport.NewService(importRepo)
+	bulkimport.Routes(authenticatedRouter, importService)
+
+	// Timeline
+	var timelineRepo = timeline.NewTimelineRepositoryPostgres(db)
+	timelineService := timeline.NewService(timelineRepo)
+	timeline.Routes(authenticatedRouter, timelineService)
+
+	// Sharing
+	var sharingRepo = sharing.NewSharingRepositoryPostgres(db)
+	sharingService := sharing.NewService(sharingRepo, userService)
+	sharing.Routes(router, authenticatedRouter, sharingService)
+
+	seed.SeedData(userService, sharingService)
+	seed.SeedInitialAdmin(userService, authService)
 
 	API_SERVER_PORT := os.Getenv("SERVER_PORT")
 	if len(API_SERVER_PORT) == 0 {
@@ -0,0 +1,35 @@
+// Package apitest serves requests to the handlers in tests. The handler tests fake the repository of a service by
+// embedding its interface, the methods a test does not need panic through the nil interface.
+package apitest
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Octek/resource-profile-management-backend.git/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// NewRouter is a router rendering the errors the way the service does, the test registers the handlers on it
+func NewRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(utils.ErrorHandler())
+	return router
+}
+
+// Serve sends the request with the JSON body, if any, and decodes the ResponseMessage the handler rendered
+func Serve(t *testing.T, router *gin.Engine, method, path, body string) (int, utils.ResponseMessage) {
+	t.Helper()
+	request := httptest.NewRequest(method, path, strings.NewReader(body))
+	request.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	var response utils.ResponseMessage
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("%s %s rendered an invalid body %q: %v", method, path, recorder.Body.String(), err)
+	}
+	return recorder.Code, response
+}
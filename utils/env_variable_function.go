@@ -1,11 +1,20 @@
 package utils
 
-import "os"
+import (
+	"os"
+	"strings"
+	"time"
+)
 
 const (
 	EnvironmentVariableNotSet    = " environment variable not set"
 	DB_SERVICE_CONNECTION_STRING = "DB_SERVICE_CONNECTION_STRING"
 	SWAGGER_HOST_URL             = "SWAGGER_HOST_URL"
+	JWT_SIGNING_KEY              = "JWT_SIGNING_KEY"
+	JWT_TOKEN_TTL                = "JWT_TOKEN_TTL"
+	CORS_ALLOWED_ORIGINS         = "CORS_ALLOWED_ORIGINS"
+	INITIAL_ADMIN_EMAIL          = "INITIAL_ADMIN_EMAIL"
+	INITIAL_ADMIN_PASSWORD       = "INITIAL_ADMIN_PASSWORD"
 )
 
 func GetConnectionString() string {
@@ -24,30 +33,173 @@ func GetSwaggerHostUrl() string {
 	return swaggerHostUrl
 }
 
+func GetJwtSigningKey() []byte {
+	signingKey, ok := os.LookupEnv(JWT_SIGNING_KEY)
+	if !ok || signingKey == "" {
+		panic(JWT_SIGNING_KEY + EnvironmentVariableNotSet)
+	}
+	return []byte(signingKey)
+}
+
+// GetJwtTokenTTL returns the lifetime of issued tokens, e.g. "8h", falling back to the given default
+func GetJwtTokenTTL(defaultTTL time.Duration) time.Duration {
+	tokenTTL, ok := os.LookupEnv(JWT_TOKEN_TTL)
+	if !ok || tokenTTL == "" {
+		return defaultTTL
+	}
+	parsedTTL, err := time.ParseDuration(tokenTTL)
+	if err != nil || parsedTTL <= 0 {
+		panic(JWT_TOKEN_TTL + " environment variable is not a valid duration")
+	}
+	return parsedTTL
+}
+
+// GetCorsAllowedOrigins reads the comma separated list of origins allowed to call the api
+func GetCorsAllowedOrigins() []string {
+	allowedOrigins, ok := os.LookupEnv(CORS_ALLOWED_ORIGINS)
+	if !ok || allowedOrigins == "" {
+		panic(CORS_ALLOWED_ORIGINS + EnvironmentVariableNotSet)
+	}
+	var origins []string
+	for _, origin := range strings.Split(allowedOrigins, ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			origins = append(origins, origin)
+		}
+	}
+	return origins
+}
+
+// GetInitialAdminCredentials is optional, empty values mean no admin login is bootstrapped
+func GetInitialAdminCredentials() (string, string) {
+	return os.Getenv(INITIAL_ADMIN_EMAIL), os.Getenv(INITIAL_ADMIN_PASSWORD)
+}
+
 const (
-	RequestSchemaInvalid                           = "The request schema is invalid: %v"
-	SomethingWentWrongWhileCreatingSkillCategories = "Something went wrong  while creating the skill categories: %v"
-	SomethingWentWrongWhileDeletingSkillCategories = "Something went wrong while deleting the skill category: %v"
-	SomethingWentWrongWhileUpdatingSkillCategory   = "Something went wrong while updating the skill category: %v"
-	SomethingWentWrongWhileGettingSkillCategory    = "Something went wrong while getting the skill category: %v"
-	InvalidJsonBody                                = "The JSON body is invalid: %v"
-	SuccessfullyCreatedSkillsCategories            = "The Skills categories has been created successfully"
-	InvalidIntegerValueLimitMessage                = "Invalid integer value for the limit : %v"
-	InvalidIntegerValueOffsetMessage               = "Invalid integer value for the offset : %v"
-	DefaultLimit                                   = "20"
-	DefaultOffset                                  = "0"
-	DefaultOrderBy                                 = "created_at desc"
-	SomethingWentWrong                             = "Something went wrong: %v"
-	SuccessfullyDeletedSkillsCategory              = "Skill category has been successfully deleted"
-	SuccessfullyUpdatedSkillsCategory              = "Skill category has been successfully Updated"
-	Success                                        = "Success"
-	RequiredSkillCategoryNames                     = "Skill category Name(s) missing. Provide at least one ID."
-	SomethingWentWrongWhileCreatingSkill           = "Something went wrong  while creating the skill: %v"
-	SomethingWentWrongWhileGettingSkill            = "Something went wrong while getting the skill: %v"
-	SomethingWentWrongWhileDeletingSkill           = "Something went wrong while deleting the skill: %v"
-	SomethingWentWrongWhileUpdatingSkill           = "Something went wrong while updating the skill: %v"
-	SuccessfullyUpdatedSkill                       = "Skill has been successfully Updated"
-	SuccessfullyDeletedSkill                       = "Skill has been successfully deleted"
-	SuccessfullyCreatedSkill                       = "Skill has been created successfully"
-	SomethingWentWrongWhileGettingExperience       = "Something went wrong while getting the experience: %v"
+	RequestSchemaInvalid                            = "The request schema is invalid: %v"
+	SomethingWentWrongWhileCreatingSkillCategories  = "Something went wrong  while creating the skill categories: %v"
+	SomethingWentWrongWhileDeletingSkillCategories  = "Something went wrong while deleting the skill category: %v"
+	SomethingWentWrongWhileUpdatingSkillCategory    = "Something went wrong while updating the skill category: %v"
+	SomethingWentWrongWhileGettingSkillCategory     = "Something went wrong while getting the skill category: %v"
+	InvalidJsonBody                                 = "The JSON body is invalid: %v"
+	SuccessfullyCreatedSkillsCategories             = "The Skills categories has been created successfully"
+	InvalidIntegerValueLimitMessage                 = "Invalid integer value for the limit : %v"
+	InvalidIntegerValueOffsetMessage                = "Invalid integer value for the offset : %v"
+	DefaultLimit                                    = "20"
+	DefaultOffset                                   = "0"
+	DefaultOrderBy                                  = "created_at desc"
+	SomethingWentWrong                              = "Something went wrong: %v"
+	SuccessfullyDeletedSkillsCategory               = "Skill category has been successfully deleted"
+	SuccessfullyUpdatedSkillsCategory               = "Skill category has been successfully Updated"
+	Success                                         = "Success"
+	RequiredSkillCategoryNames                      = "Skill category Name(s) missing. Provide at least one ID."
+	SomethingWentWrongWhileCreatingSkill            = "Something went wrong  while creating the skill: %v"
+	SomethingWentWrongWhileGettingSkill             = "Something went wrong while getting the skill: %v"
+	SomethingWentWrongWhileDeletingSkill            = "Something went wrong while deleting the skill: %v"
+	SomethingWentWrongWhileUpdatingSkill            = "Something went wrong while updating the skill: %v"
+	SuccessfullyUpdatedSkill                        = "Skill has been successfully Updated"
+	SuccessfullyDeletedSkill                        = "Skill has been successfully deleted"
+	SuccessfullyCreatedSkill                        = "Skill has been created successfully"
+	SomethingWentWrongWhileGettingExperience        = "Something went wrong while getting the experience: %v"
+	SomethingWentWrongWhileCreatingProject          = "Something went wrong while creating the project: %v"
+	SomethingWentWrongWhileGettingProject           = "Something went wrong while getting the project: %v"
+	SomethingWentWrongWhileUpdatingProject          = "Something went wrong while updating the project: %v"
+	SomethingWentWrongWhileDeletingProject          = "Something went wrong while deleting the project: %v"
+	SomethingWentWrongWhileAttachingProject         = "Something went wrong while attaching the project to the user: %v"
+	SomethingWentWrongWhileDetachingProject         = "Something went wrong while detaching the project from the user: %v"
+	SuccessfullyCreatedProject                      = "Project has been created successfully"
+	SuccessfullyUpdatedProject                      = "Project has been successfully Updated"
+	SuccessfullyDeletedProject                      = "Project has been successfully deleted"
+	SuccessfullyAttachedProject                     = "Project has been successfully attached to the user"
+	SuccessfullyDetachedProject                     = "Project has been successfully detached from the user"
+	SomethingWentWrongWhileCreatingBooking          = "Something went wrong while creating the booking: %v"
+	SomethingWentWrongWhileGettingBooking           = "Something went wrong while getting the booking: %v"
+	SomethingWentWrongWhileReschedulingBooking      = "Something went wrong while rescheduling the booking: %v"
+	SomethingWentWrongWhileCancellingBooking        = "Something went wrong while cancelling the booking: %v"
+	SomethingWentWrongWhileAttachingBookingSkills   = "Something went wrong while attaching skills to the booking: %v"
+	SomethingWentWrongWhileDetachingBookingSkill    = "Something went wrong while detaching the skill from the booking: %v"
+	InvalidBookingStatus                            = "Invalid booking status, expected one of: upcoming, past"
+	SuccessfullyCreatedBooking                      = "Booking has been created successfully"
+	SuccessfullyRescheduledBooking                  = "Booking has been successfully rescheduled"
+	SuccessfullyCancelledBooking                    = "Booking has been successfully cancelled"
+	SuccessfullyAttachedBookingSkills               = "Skills have been successfully attached to the booking"
+	SuccessfullyDetachedBookingSkill                = "Skill has been successfully detached from the booking"
+	SomethingWentWrongWhileRecordingBookingAnswers  = "Something went wrong while recording the booking answers: %v"
+	SuccessfullyRecordedBookingAnswers              = "Booking answers have been successfully recorded"
+	InvalidBooleanValueMessage                      = "Invalid boolean value for the %s : %v"
+	SomethingWentWrongWhileCreatingQuestion         = "Something went wrong while creating the question: %v"
+	SomethingWentWrongWhileGettingQuestion          = "Something went wrong while getting the question: %v"
+	SomethingWentWrongWhileUpdatingQuestion         = "Something went wrong while updating the question: %v"
+	SuccessfullyCreatedQuestion                     = "Question has been created successfully"
+	SuccessfullyUpdatedQuestion                     = "Question has been successfully Updated"
+	SuccessfullyRetiredQuestion                     = "Question has been successfully retired"
+	SuccessfullyActivatedQuestion                   = "Question has been successfully activated"
+	InvalidDateValueMessage                         = "Invalid date value for the %s, expected YYYY-MM-DD : %v"
+	InvalidDateRangeMessage                         = "Invalid date range, to must not be before from and the range cannot exceed %d days"
+	SomethingWentWrongWhileGettingAvailability      = "Something went wrong while getting the availability: %v"
+	SomethingWentWrongWhileSavingAvailability       = "Something went wrong while saving the availability: %v"
+	SuccessfullySavedAvailability                   = "Availability has been successfully saved"
+	SomethingWentWrongWhileExportingCalendar        = "Something went wrong while exporting the calendar: %v"
+	SomethingWentWrongWhileCreatingCalendarFeed     = "Something went wrong while creating the calendar feed: %v"
+	SuccessfullyCreatedCalendarFeed                 = "Calendar feed has been successfully created"
+	Unauthorized                                    = "Unauthorized: %v"
+	Forbidden                                       = "Access denied: %v"
+	SomethingWentWrongWhileLoggingIn                = "Something went wrong while logging in: %v"
+	SuccessfullyLoggedIn                            = "Successfully logged in"
+	SomethingWentWrongWhileSavingPassword           = "Something went wrong while saving the password: %v"
+	SuccessfullySavedPassword                       = "Password has been successfully saved"
+	SomethingWentWrongWhileGeneratingResume         = "Something went wrong while generating the resume: %v"
+	SomethingWentWrongWhileGettingRedactionProfile  = "Something went wrong while getting the redaction profile: %v"
+	SomethingWentWrongWhileSavingRedactionProfile   = "Something went wrong while saving the redaction profile: %v"
+	SuccessfullySavedRedactionProfile               = "Redaction profile has been successfully saved"
+	SomethingWentWrongWhileDeletingRedactionProfile = "Something went wrong while deleting the redaction profile: %v"
+	SuccessfullyDeletedRedactionProfile             = "Redaction profile has been successfully deleted"
+	SomethingWentWrongWhileCreatingShareLink        = "Something went wrong while creating the share link: %v"
+	SuccessfullyCreatedShareLink                    = "Share link has been successfully created"
+	SomethingWentWrongWhileGettingShareLink         = "Something went wrong while getting the share link: %v"
+	SomethingWentWrongWhileRevokingShareLink        = "Something went wrong while revoking the share link: %v"
+	SuccessfullyRevokedShareLink                    = "Share link has been successfully revoked"
+	ShareLinkUnavailable                            = "This link is not available: %v"
+	SomethingWentWrongWhileSearchingTalent          = "Something went wrong while searching talent: %v"
+	SomethingWentWrongWhileSearching                = "Something went wrong while searching: %v"
+	InvalidSearchQuery                              = "Invalid search: %v"
+	InvalidSkillLevel                               = "Invalid skill level: %v"
+	SomethingWentWrongWhileGettingSkillProficiency  = "Something went wrong while getting the skill proficiencies: %v"
+	SomethingWentWrongWhileUpdatingSkillProficiency = "Something went wrong while updating the skill proficiency: %v"
+	SuccessfullyUpdatedSkillProficiency             = "Skill proficiency has been successfully updated"
+	SomethingWentWrongWhileGettingSkillDistribution = "Something went wrong while getting the skill level distribution: %v"
+	SomethingWentWrongWhileAssigningSkill           = "Something went wrong while assigning the skill: %v"
+	SuccessfullyAssignedSkill                       = "Skill has been successfully assigned"
+	SomethingWentWrongWhileUnassigningSkill         = "Something went wrong while unassigning the skill: %v"
+	SuccessfullyUnassignedSkill                     = "Skill has been successfully unassigned"
+	SomethingWentWrongWhileSettingUserSkills        = "Something went wrong while setting the skills of the user: %v"
+	SuccessfullySetUserSkills                       = "Skills of the user have been successfully set"
+	SomethingWentWrongWhileSavingSkillAlias         = "Something went wrong while saving the skill alias: %v"
+	SuccessfullyAddedSkillAlias                     = "Skill alias has been successfully added"
+	SomethingWentWrongWhileDeletingSkillAlias       = "Something went wrong while deleting the skill alias: %v"
+	SuccessfullyDeletedSkillAlias                   = "Skill alias has been successfully deleted"
+	SomethingWentWrongWhileSettingSkillParent       = "Something went wrong while setting the parent of the skill: %v"
+	SuccessfullySetSkillParent                      = "Parent of the skill has been successfully set"
+	SomethingWentWrongWhileEndorsingSkill           = "Something went wrong while endorsing the skill: %v"
+	SuccessfullyEndorsedSkill                       = "Skill has been successfully endorsed"
+	SomethingWentWrongWhileDeletingEndorsement      = "Something went wrong while deleting the endorsement: %v"
+	SuccessfullyDeletedEndorsement                  = "Endorsement has been successfully deleted"
+	SomethingWentWrongWhileGettingEndorsements      = "Something went wrong while getting the endorsements: %v"
+	SomethingWentWrongWhileAddingExperienceSkills   = "Something went wrong while adding the skills to the experience: %v"
+	SuccessfullyAddedExperienceSkills               = "Skills have been successfully added to the experience"
+	SomethingWentWrongWhileRemovingExperienceSkill  = "Something went wrong while removing the skill from the experience: %v"
+	SuccessfullyRemovedExperienceSkill              = "Skill has been successfully removed from the experience"
+	SomethingWentWrongWhileGettingResponsibilities  = "Something went wrong while getting the responsibilities: %v"
+	SomethingWentWrongWhileUpdatingResponsibilities = "Something went wrong while updating the responsibilities: %v"
+	SuccessfullyUpdatedResponsibilities             = "Responsibilities have been successfully updated"
+	InvalidIntegerValueMessage                      = "Invalid integer value for the %s : %v"
+	SomethingWentWrongWhileGettingTimeline          = "Something went wrong while getting the timeline: %v"
+	InvalidImportFile                               = "Invalid import file: %v"
+	SomethingWentWrongWhileImportingProfiles        = "Something went wrong while importing the profiles: %v"
+	SomethingWentWrongWhileExportingJSONResume      = "Something went wrong while exporting the JSON Resume: %v"
+	SomethingWentWrongWhileImportingJSONResume      = "Something went wrong while importing the JSON Resume: %v"
+	SomethingWentWrongWhileCreatingUser             = "Something went wrong while creating user: %v"
+	SomethingWentWrongWhileFindingDuplicateUsers    = "Something went wrong while finding duplicate users: %v"
+	SomethingWentWrongWhileMergingUsers             = "Something went wrong while merging users: %v"
+	SuccessfullyMergedUsers                         = "Users merged successfully."
+	InvalidOrderByMessage                           = "Invalid value for the orderBy : %v"
 )
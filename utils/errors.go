@@ -0,0 +1,145 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+// The codes of the errors are stable, clients branch on them instead of the messages
+const (
+	ErrorCodeBadRequest       = "bad_request"
+	ErrorCodeValidationFailed = "validation_failed"
+	ErrorCodeUnauthorized     = "unauthorized"
+	ErrorCodeForbidden        = "forbidden"
+	ErrorCodeNotFound         = "not_found"
+	ErrorCodeConflict         = "conflict"
+	ErrorCodeGone             = "gone"
+	ErrorCodePayloadTooLarge  = "payload_too_large"
+	ErrorCodeUnprocessable    = "unprocessable_entity"
+	ErrorCodeInternal         = "internal_error"
+	// ErrorCodeEmailAlreadyExists is the code of a conflict with the email of another user
+	ErrorCodeEmailAlreadyExists = "email_already_exists"
+)
+
+var errorCodes = map[int]string{
+	http.StatusBadRequest:            ErrorCodeBadRequest,
+	http.StatusUnauthorized:          ErrorCodeUnauthorized,
+	http.StatusForbidden:             ErrorCodeForbidden,
+	http.StatusNotFound:              ErrorCodeNotFound,
+	http.StatusConflict:              ErrorCodeConflict,
+	http.StatusGone:                  ErrorCodeGone,
+	http.StatusRequestEntityTooLarge: ErrorCodePayloadTooLarge,
+	http.StatusUnprocessableEntity:   ErrorCodeUnprocessable,
+	http.StatusInternalServerError:   ErrorCodeInternal,
+}
+
+// FieldError is a field of a request that failed a validation rule, Field is the json path of the field
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+}
+
+// Error is the error of a request, ErrorHandler renders it with its status code. Err is the cause, it is
+// not shown to the client unless the message includes it.
+type Error struct {
+	StatusCode int
+	Code       string
+	Message    string
+	Details    []FieldError
+	Err        error
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// WithCode replaces the code of the status with a more specific one
+func (e *Error) WithCode(code string) *Error {
+	e.Code = code
+	return e
+}
+
+// NewError classifies the cause of an error. Failed validation rules are a 400 with the fields that
+// failed and a missing record is a 404 unless the handler chose a status other than 500 for it.
+func NewError(statusCode int, message string, err error) *Error {
+	var validationErrors validator.ValidationErrors
+	switch {
+	case errors.As(err, &validationErrors):
+		return &Error{StatusCode: http.StatusBadRequest, Code: ErrorCodeValidationFailed, Message: message, Details: NewFieldErrors(validationErrors), Err: err}
+	case statusCode == http.StatusInternalServerError && errors.Is(err, gorm.ErrRecordNotFound):
+		statusCode = http.StatusNotFound
+	}
+	code, found := errorCodes[statusCode]
+	if !found && statusCode < http.StatusInternalServerError {
+		code = ErrorCodeBadRequest
+	} else if !found {
+		code = ErrorCodeInternal
+	}
+	return &Error{StatusCode: statusCode, Code: code, Message: message, Err: err}
+}
+
+// AbortWithError stops the handler chain with the error, ErrorHandler renders it
+func AbortWithError(c *gin.Context, err *Error) {
+	_ = c.Error(err)
+	c.Abort()
+}
+
+// ErrorHandler renders the last error of a request that has no response yet, errors other than
+// an Error are a 500
+func ErrorHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+		err := c.Errors.Last().Err
+		var requestErr *Error
+		if !errors.As(err, &requestErr) {
+			requestErr = NewError(http.StatusInternalServerError, fmt.Sprintf(SomethingWentWrong, err), err)
+		}
+		c.JSON(requestErr.StatusCode, ResponseMessage{StatusCode: requestErr.StatusCode, Code: requestErr.Code, Message: requestErr.Message, Details: requestErr.Details, Data: nil})
+	}
+}
+
+// NewValidator returns a validator naming the fields by their json names
+func NewValidator() *validator.Validate {
+	validate := validator.New()
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+	return validate
+}
+
+// NewFieldErrors lists the failed rules, the field is the path below the validated struct
+func NewFieldErrors(validationErrors validator.ValidationErrors) []FieldError {
+	fieldErrors := make([]FieldError, 0, len(validationErrors))
+	for _, validationError := range validationErrors {
+		field := validationError.Namespace()
+		if i := strings.Index(field, "."); i >= 0 {
+			field = field[i+1:]
+		}
+		message := fmt.Sprintf("failed on the %s rule", validationError.Tag())
+		if validationError.Param() != "" {
+			message = fmt.Sprintf("failed on the %s=%s rule", validationError.Tag(), validationError.Param())
+		}
+		fieldErrors = append(fieldErrors, FieldError{Field: field, Rule: validationError.Tag(), Param: validationError.Param(), Message: message})
+	}
+	return fieldErrors
+}
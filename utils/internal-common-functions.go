@@ -4,10 +4,14 @@ import (
 	"reflect"
 )
 
+// ResponseMessage is the body of every response. Errors have a stable machine readable Code and
+// failed validations list the fields in Details.
 type ResponseMessage struct {
-	StatusCode int         `json:"status_code"`
-	Message    string      `json:"message"`
-	Data       interface{} `json:"data"`
+	StatusCode int          `json:"status_code"`
+	Code       string       `json:"code,omitempty"`
+	Message    string       `json:"message"`
+	Details    []FieldError `json:"details,omitempty"`
+	Data       interface{}  `json:"data"`
 }
 
 type RecordsResponse struct {
@@ -0,0 +1,114 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MaxLimit is the largest page a list returns
+const MaxLimit = 1000
+
+var (
+	ErrInvalidID      = errors.New("ids are positive integers")
+	ErrInvalidLimit   = fmt.Errorf("the limit is an integer from 1 to %d", MaxLimit)
+	ErrInvalidOffset  = errors.New("the offset is a non negative integer")
+	ErrInvalidOrderBy = errors.New("the order is a comma separated list of columns, each optionally followed by asc or desc")
+	ErrUnsortable     = errors.New("the list cannot be ordered by this column")
+	ErrFixedOrder     = errors.New("the list has a fixed order")
+)
+
+// orderByTerm is a column, optionally of a table, and a direction
+var orderByTerm = regexp.MustCompile(`(?i)^[a-z_][a-z0-9_]*(\.[a-z_][a-z0-9_]*)?(\s+(asc|desc))?$`)
+
+// Page is the window and order of a list request
+type Page struct {
+	Limit   int
+	Offset  int
+	OrderBy string
+}
+
+// ParseID parses an id, it rejects signs, spaces and zero
+func ParseID(value string) (uint, error) {
+	id, err := strconv.ParseUint(value, 10, strconv.IntSize)
+	if err != nil || id == 0 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidID, value)
+	}
+	return uint(id), nil
+}
+
+// ParseOrderBy checks an order of the sortable columns, the value ends up in the ORDER BY of a query
+func ParseOrderBy(value string, sortableColumns []string) (string, error) {
+	if len(sortableColumns) == 0 {
+		return "", ErrFixedOrder
+	}
+	terms := strings.Split(value, ",")
+	for i, term := range terms {
+		fields := strings.Fields(term)
+		terms[i] = strings.Join(fields, " ")
+		if !orderByTerm.MatchString(terms[i]) {
+			return "", fmt.Errorf("%w: %q", ErrInvalidOrderBy, value)
+		}
+		if !slices.Contains(sortableColumns, strings.ToLower(fields[0])) {
+			return "", fmt.Errorf("%w: %q, the sortable columns are %s", ErrUnsortable, fields[0], strings.Join(sortableColumns, ", "))
+		}
+	}
+	return strings.Join(terms, ","), nil
+}
+
+// BindID reads the id in a path parameter, an invalid id aborts the request with a 400
+func BindID(c *gin.Context, name string) (uint, bool) {
+	id, err := ParseID(c.Param(name))
+	if err != nil {
+		AbortWithError(c, NewError(http.StatusBadRequest, fmt.Sprintf(InvalidIntegerValueMessage, name, err), err))
+		return 0, false
+	}
+	return id, true
+}
+
+// BindQueryID reads the id in a query parameter, a missing or invalid id aborts the request with a 400
+func BindQueryID(c *gin.Context, name string) (uint, bool) {
+	id, err := ParseID(c.Query(name))
+	if err != nil {
+		AbortWithError(c, NewError(http.StatusBadRequest, fmt.Sprintf(InvalidIntegerValueMessage, name, err), err))
+		return 0, false
+	}
+	return id, true
+}
+
+// BindPage reads limit, offset and orderBy from the query, the limit defaults to DefaultLimit, the offset
+// to 0 and the order to defaultOrderBy. orderBy may only name the sortable columns, a list without any
+// has a fixed order. An invalid value aborts the request with a 400.
+func BindPage(c *gin.Context, defaultOrderBy string, sortableColumns ...string) (Page, bool) {
+	page := Page{OrderBy: defaultOrderBy}
+	var err error
+	if page.Limit, err = strconv.Atoi(queryOrDefault(c, "limit", DefaultLimit)); err != nil || page.Limit < 1 || page.Limit > MaxLimit {
+		AbortWithError(c, NewError(http.StatusBadRequest, fmt.Sprintf(InvalidIntegerValueLimitMessage, ErrInvalidLimit), ErrInvalidLimit))
+		return page, false
+	}
+	if page.Offset, err = strconv.Atoi(queryOrDefault(c, "offset", DefaultOffset)); err != nil || page.Offset < 0 {
+		AbortWithError(c, NewError(http.StatusBadRequest, fmt.Sprintf(InvalidIntegerValueOffsetMessage, ErrInvalidOffset), ErrInvalidOffset))
+		return page, false
+	}
+	if orderBy := c.Query("orderBy"); orderBy != "" {
+		if page.OrderBy, err = ParseOrderBy(orderBy, sortableColumns); err != nil {
+			AbortWithError(c, NewError(http.StatusBadRequest, fmt.Sprintf(InvalidOrderByMessage, err), err))
+			return page, false
+		}
+	}
+	return page, true
+}
+
+// queryOrDefault treats an empty query value like a missing one
+func queryOrDefault(c *gin.Context, name, defaultValue string) string {
+	if value := c.Query(name); value != "" {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,120 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestParseID(t *testing.T) {
+	tests := []struct {
+		value string
+		want  uint
+		valid bool
+	}{
+		{value: "1", want: 1, valid: true},
+		{value: "4294967296", want: 4294967296, valid: true},
+		{value: "", valid: false},
+		{value: "0", valid: false},
+		{value: "-1", valid: false},
+		{value: "+1", valid: false},
+		{value: " 1", valid: false},
+		{value: "1.5", valid: false},
+		{value: "abc", valid: false},
+		{value: "18446744073709551616", valid: false},
+	}
+	for _, test := range tests {
+		got, err := ParseID(test.value)
+		if test.valid && (err != nil || got != test.want) {
+			t.Errorf("ParseID(%q) = %d, %v, want %d", test.value, got, err, test.want)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("ParseID(%q) = %d, want an error", test.value, got)
+		}
+	}
+}
+
+func TestParseOrderBy(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+		valid bool
+	}{
+		{value: "created_at desc", want: "created_at desc", valid: true},
+		{value: "created_at desc,updated_at desc", want: "created_at desc,updated_at desc", valid: true},
+		{value: " first_name  ASC , id", want: "first_name ASC,id", valid: true},
+		{value: "Created_At", want: "Created_At", valid: true},
+		{value: "users.id asc", valid: false},
+		{value: "password desc", valid: false},
+		{value: "id;drop table users", valid: false},
+		{value: "id desc nulls last", valid: false},
+		{value: "(select 1)", valid: false},
+		{value: "id,", valid: false},
+		{value: "1", valid: false},
+	}
+	for _, test := range tests {
+		got, err := ParseOrderBy(test.value, []string{"id", "first_name", "created_at", "updated_at"})
+		if test.valid && (err != nil || got != test.want) {
+			t.Errorf("ParseOrderBy(%q) = %q, %v, want %q", test.value, got, err, test.want)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("ParseOrderBy(%q) = %q, want an error", test.value, got)
+		}
+	}
+	if got, err := ParseOrderBy("id", nil); !errors.Is(err, ErrFixedOrder) {
+		t.Errorf("ParseOrderBy of a list with a fixed order = %q, %v, want %v", got, err, ErrFixedOrder)
+	}
+}
+
+func TestBindPage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		query string
+		want  Page
+		valid bool
+	}{
+		{query: "", want: Page{Limit: 20, Offset: 0, OrderBy: "created_at desc"}, valid: true},
+		{query: "limit=&offset=", want: Page{Limit: 20, Offset: 0, OrderBy: "created_at desc"}, valid: true},
+		{query: "limit=5&offset=10&orderBy=name%20asc", want: Page{Limit: 5, Offset: 10, OrderBy: "name asc"}, valid: true},
+		{query: "limit=1000", want: Page{Limit: 1000, Offset: 0, OrderBy: "created_at desc"}, valid: true},
+		{query: "limit=abc", valid: false},
+		{query: "limit=0", valid: false},
+		{query: "limit=-1", valid: false},
+		{query: "limit=1001", valid: false},
+		{query: "offset=abc", valid: false},
+		{query: "offset=-1", valid: false},
+		{query: "orderBy=id%3Bdrop%20table%20users", valid: false},
+		{query: "orderBy=password", valid: false},
+	}
+	for _, test := range tests {
+		router := gin.New()
+		router.Use(ErrorHandler())
+		var got Page
+		var ok bool
+		router.GET("/list", func(c *gin.Context) {
+			if got, ok = BindPage(c, DefaultOrderBy, "name", "created_at"); ok {
+				c.Status(http.StatusOK)
+			}
+		})
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/list?"+test.query, nil))
+
+		if test.valid {
+			if !ok || got != test.want || recorder.Code != http.StatusOK {
+				t.Errorf("BindPage(%q) = %+v, %v with status %d, want %+v", test.query, got, ok, recorder.Code, test.want)
+			}
+			continue
+		}
+		var response ResponseMessage
+		if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+			t.Fatalf("BindPage(%q) rendered an invalid body: %v", test.query, err)
+		}
+		if ok || recorder.Code != http.StatusBadRequest || response.Code != ErrorCodeBadRequest {
+			t.Errorf("BindPage(%q) = %v with status %d and code %q, want a 400 with code %q", test.query, ok, recorder.Code, response.Code, ErrorCodeBadRequest)
+		}
+	}
+}